@@ -0,0 +1,22 @@
+package main
+
+import (
+	"api-gateway/internal/proxy"
+
+	"shared/config"
+	"shared/identity"
+	"shared/lifecycle"
+	"shared/logging"
+)
+
+// Config là toàn bộ cấu hình của gateway, nạp một lần lúc khởi động bằng config.MustLoad
+type Config struct {
+	Port int `env:"GATEWAY_PORT" default:"8000" min:"1"`
+
+	Log       logging.Config
+	Lifecycle lifecycle.Config
+	JWT       config.JWT
+	CORS      config.CORS
+	Identity  identity.Config
+	Services  proxy.Config
+}
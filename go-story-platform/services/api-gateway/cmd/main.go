@@ -0,0 +1,85 @@
+package main
+
+import (
+	"api-gateway/internal/proxy"
+	"api-gateway/internal/swagger"
+	"api-gateway/internal/transport/http/middleware"
+	"log"
+	"log/slog"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+
+	"shared/auth"
+	"shared/config"
+	"shared/health"
+	"shared/identity"
+	"shared/lifecycle"
+	"shared/logging"
+
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/swaggo/swag"
+)
+
+func main() {
+	// 0. Nạp và kiểm tra cấu hình, dừng ngay nếu thiếu biến bắt buộc hoặc secret quá yếu
+	var cfg Config
+	config.MustLoad(&cfg)
+	if err := logging.Setup("api-gateway", cfg.Log); err != nil {
+		log.Fatal("❌ Cấu hình log không hợp lệ: ", err)
+	}
+	config.LogEffective("API Gateway", &cfg)
+
+	// 1. Bảng route theo tiền tố path tới user-service, content-service, payment-service
+	router, services, err := proxy.NewSystemRouter(cfg.Services)
+	if err != nil {
+		log.Fatal("❌ Không thể khởi tạo gateway:", err)
+	}
+
+	// 2. JWT được xác thực một lần tại gateway. Danh tính gửi xuống service được ký bằng
+	// GATEWAY_IDENTITY_SECRET; service phải dùng cùng secret mới tin header danh tính.
+	verifier := auth.NewVerifier(cfg.JWT)
+	signer := identity.NewSigner(cfg.Identity)
+	if signer == nil {
+		slog.Warn("Gateway: GATEWAY_IDENTITY_SECRET trống, service phía sau sẽ tự kiểm lại JWT")
+	}
+
+	// 3. Tài liệu Swagger gộp từ các service, phục vụ tại /swagger/index.html
+	swag.Register(swag.Name, swagger.NewAggregator(services, time.Minute))
+
+	// 4. Khởi tạo Gin. CORS chỉ xử lý ở gateway, header CORS của service bị bỏ khi chuyển tiếp.
+	// Request ID và access log JSON thay cho logger mặc định của Gin; panic trả 500 với message chung
+	r := gin.New()
+	r.Use(logging.Middleware(), logging.Recovery())
+	r.Use(cors.New(cors.Config{
+		AllowOrigins:     cfg.CORS.AllowedOrigins, // URL của UI, cấu hình qua CORS_ALLOWED_ORIGINS
+		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", logging.HeaderRequestID},
+		ExposeHeaders:    []string{logging.HeaderRequestID},
+		AllowCredentials: true,
+	}))
+
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	// Liveness và readiness của gateway. Gateway sẵn sàng khi các service phía sau còn sống;
+	// /readyz trả 503 ngay khi bắt đầu dừng.
+	app := lifecycle.New("API Gateway", cfg.Lifecycle)
+	checker := health.New()
+	for _, svc := range services {
+		checker.Add(svc.Name, health.HTTP(svc.BaseURL.JoinPath("healthz").String()))
+	}
+	app.OnShutdown(checker.Drain)
+	r.GET("/healthz", gin.WrapF(checker.Liveness))
+	r.GET("/readyz", gin.WrapF(checker.Readiness))
+
+	// 5. Mọi request còn lại được chuyển tiếp theo bảng route
+	r.NoRoute(router.Resolve(), middleware.AuthMiddleware(verifier, signer), router.Forward)
+
+	// 6. Chạy Server cho tới khi nhận SIGINT/SIGTERM. Cổng đọc từ GATEWAY_PORT, mặc định 8000.
+	app.Add(lifecycle.HTTP("HTTP server", cfg.Port, r))
+	if err := app.Run(); err != nil {
+		log.Fatal("❌ API Gateway dừng do lỗi: ", err)
+	}
+}
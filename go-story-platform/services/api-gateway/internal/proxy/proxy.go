@@ -0,0 +1,169 @@
+// Package proxy chuyển tiếp request từ gateway tới service phụ trách theo tiền tố path
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	"api-gateway/internal/transport/http/dto"
+
+	"shared/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Service là một service phía sau gateway
+type Service struct {
+	Name    string
+	BaseURL *url.URL
+	proxy   *httputil.ReverseProxy
+}
+
+// Route gắn một tiền tố path với service phụ trách
+type Route struct {
+	Prefix  string
+	Service *Service
+	// Public = true thì gateway không kiểm access token, dùng cho các endpoint cấp token
+	// (đăng nhập, refresh...) nơi client có thể còn gửi kèm token đã hết hạn
+	Public bool
+}
+
+// Router chọn Route cho request theo tiền tố path
+type Router struct {
+	routes []Route
+}
+
+// NewService tạo Service chuyển tiếp tới baseURL
+func NewService(name, baseURL string) (*Service, error) {
+	target, err := url.Parse(baseURL)
+	if err != nil || target.Scheme == "" || target.Host == "" {
+		return nil, fmt.Errorf("URL của %s không hợp lệ: %q", name, baseURL)
+	}
+
+	svc := &Service{Name: name, BaseURL: target}
+	svc.proxy = &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(target)
+			pr.SetXForwarded()
+		},
+		// CORS do gateway xử lý, bỏ header CORS của service để trình duyệt không nhận hai lần.
+		// Request ID cũng đã được gateway đặt sẵn trong response (service nhận cùng giá trị).
+		ModifyResponse: func(resp *http.Response) error {
+			for header := range resp.Header {
+				if strings.HasPrefix(header, "Access-Control-") {
+					resp.Header.Del(header)
+				}
+			}
+			resp.Header.Del(logging.HeaderRequestID)
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			slog.ErrorContext(r.Context(), "Gateway: không gọi được service", "upstream", name, "error", err)
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadGateway)
+			_ = json.NewEncoder(w).Encode(dto.ApiResponse{
+				Success: false,
+				Message: "Dịch vụ tạm thời không khả dụng, vui lòng thử lại sau",
+			})
+		},
+	}
+	return svc, nil
+}
+
+// Config là địa chỉ các service phía sau gateway (mặc định cổng local 8080-8082)
+type Config struct {
+	UserServiceURL    string `env:"USER_SERVICE_URL" default:"http://localhost:8080"`
+	ContentServiceURL string `env:"CONTENT_SERVICE_URL" default:"http://localhost:8081"`
+	PaymentServiceURL string `env:"PAYMENT_SERVICE_URL" default:"http://localhost:8082"`
+}
+
+// NewSystemRouter tạo Router với bảng route của hệ thống, trỏ tới các service trong cfg
+func NewSystemRouter(cfg Config) (*Router, []*Service, error) {
+	user, err := NewService("user-service", cfg.UserServiceURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := NewService("content-service", cfg.ContentServiceURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	payment, err := NewService("payment-service", cfg.PaymentServiceURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	router := NewRouter([]Route{
+		{Prefix: "/auth", Service: user, Public: true},
+		{Prefix: "/users", Service: user},
+
+		{Prefix: "/books", Service: content},
+		{Prefix: "/categories", Service: content},
+		{Prefix: "/tags", Service: content},
+		{Prefix: "/search", Service: content},
+		{Prefix: "/me", Service: content},
+
+		{Prefix: "/wallet", Service: payment},
+		{Prefix: "/transactions", Service: payment},
+		{Prefix: "/purchases", Service: payment},
+		{Prefix: "/entitlements", Service: payment},
+		{Prefix: "/nfts", Service: payment},
+	})
+	return router, []*Service{user, content, payment}, nil
+}
+
+// NewRouter tạo Router từ danh sách route
+func NewRouter(routes []Route) *Router {
+	return &Router{routes: routes}
+}
+
+// Match tìm route cho path, ưu tiên tiền tố dài nhất. Tiền tố chỉ khớp trọn đoạn path:
+// "/books" khớp "/books" và "/books/1" nhưng không khớp "/bookshelf".
+func (r *Router) Match(path string) *Route {
+	var best *Route
+	for i := range r.routes {
+		route := &r.routes[i]
+		if path != route.Prefix && !strings.HasPrefix(path, route.Prefix+"/") {
+			continue
+		}
+		if best == nil || len(route.Prefix) > len(best.Prefix) {
+			best = route
+		}
+	}
+	return best
+}
+
+// Resolve là middleware tìm route cho request và lưu vào context, trả 404 nếu không service nào phụ trách
+func (r *Router) Resolve() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := r.Match(c.Request.URL.Path)
+		if route == nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, dto.ApiResponse{
+				Success: false,
+				Message: "Không tìm thấy endpoint",
+			})
+			return
+		}
+		c.Set("route", route)
+		c.Next()
+	}
+}
+
+// Forward chuyển tiếp request tới service của route đã được Resolve chọn
+func (r *Router) Forward(c *gin.Context) {
+	CurrentRoute(c).Service.proxy.ServeHTTP(c.Writer, c.Request)
+}
+
+// CurrentRoute lấy route do Resolve lưu vào context, nil nếu chưa resolve
+func CurrentRoute(c *gin.Context) *Route {
+	if v, exists := c.Get("route"); exists {
+		if route, ok := v.(*Route); ok {
+			return route
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// Package swagger gộp tài liệu Swagger của các service thành một tài liệu duy nhất phục vụ tại gateway
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"api-gateway/internal/proxy"
+)
+
+const definitionRefPrefix = "#/definitions/"
+
+// Aggregator tải swagger.json của từng service, gộp paths và definitions rồi cache trong ttl.
+// Aggregator implement swag.Swagger nên đăng ký được với swag để gin-swagger phục vụ.
+type Aggregator struct {
+	services []*proxy.Service
+	client   *http.Client
+	ttl      time.Duration
+
+	mu        sync.Mutex
+	doc       string
+	fetchedAt time.Time
+}
+
+// NewAggregator tạo Aggregator cho các service
+func NewAggregator(services []*proxy.Service, ttl time.Duration) *Aggregator {
+	return &Aggregator{
+		services: services,
+		client:   &http.Client{Timeout: 5 * time.Second},
+		ttl:      ttl,
+	}
+}
+
+// ReadDoc trả về tài liệu đã gộp. Service không tải được tài liệu thì bị bỏ qua
+// và không được cache để lần sau thử lại.
+func (a *Aggregator) ReadDoc() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.doc != "" && time.Since(a.fetchedAt) < a.ttl {
+		return a.doc
+	}
+
+	merged := map[string]interface{}{
+		"swagger": "2.0",
+		"info": map[string]interface{}{
+			"title":       "Story Platform API",
+			"description": "Tài liệu gộp của user-service, content-service và payment-service qua API gateway",
+			"version":     "1.0",
+		},
+		"basePath": "/",
+	}
+	paths := map[string]interface{}{}
+	definitions := map[string]interface{}{}
+	securityDefinitions := map[string]interface{}{}
+	complete := true
+
+	for _, svc := range a.services {
+		spec, err := a.fetch(svc)
+		if err != nil {
+			slog.Warn("Swagger: không tải được tài liệu", "upstream", svc.Name, "error", err)
+			complete = false
+			continue
+		}
+		mergeSpec(svc.Name, spec, paths, definitions, securityDefinitions)
+	}
+
+	merged["paths"] = paths
+	merged["definitions"] = definitions
+	if len(securityDefinitions) > 0 {
+		merged["securityDefinitions"] = securityDefinitions
+	}
+
+	doc, err := json.Marshal(merged)
+	if err != nil {
+		slog.Warn("Swagger: không gộp được tài liệu", "error", err)
+		return a.doc
+	}
+	if complete {
+		a.doc = string(doc)
+		a.fetchedAt = time.Now()
+	}
+	return string(doc)
+}
+
+// fetch tải swagger.json do gin-swagger của service phục vụ
+func (a *Aggregator) fetch(svc *proxy.Service) (map[string]interface{}, error) {
+	resp, err := a.client.Get(svc.BaseURL.JoinPath("/swagger/doc.json").String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	var spec map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// mergeSpec gộp tài liệu của service vào kết quả. Tên definition do swag sinh đã kèm tên module
+// nên hiếm khi trùng; nếu trùng tên mà khác nội dung với service khác thì definition được đổi tên
+// thành "<service>.<tên>" và mọi $ref trong tài liệu của service được sửa theo.
+func mergeSpec(service string, spec, paths, definitions, securityDefinitions map[string]interface{}) {
+	serviceDefinitions, _ := spec["definitions"].(map[string]interface{})
+
+	renames := map[string]string{}
+	for name, def := range serviceDefinitions {
+		if existing, ok := definitions[name]; ok && !reflect.DeepEqual(existing, def) {
+			renames[name] = service + "." + name
+		}
+	}
+	if len(renames) > 0 {
+		spec = rewriteRefs(spec, renames).(map[string]interface{})
+		serviceDefinitions, _ = spec["definitions"].(map[string]interface{})
+	}
+
+	for name, def := range serviceDefinitions {
+		if renamed, ok := renames[name]; ok {
+			name = renamed
+		}
+		definitions[name] = def
+	}
+	if servicePaths, ok := spec["paths"].(map[string]interface{}); ok {
+		for path, item := range servicePaths {
+			paths[path] = item
+		}
+	}
+	if security, ok := spec["securityDefinitions"].(map[string]interface{}); ok {
+		for name, def := range security {
+			securityDefinitions[name] = def
+		}
+	}
+}
+
+// rewriteRefs đổi các $ref "#/definitions/<tên>" theo renames trong toàn bộ cây JSON
+func rewriteRefs(node interface{}, renames map[string]string) interface{} {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if ref, ok := child.(string); ok && key == "$ref" && strings.HasPrefix(ref, definitionRefPrefix) {
+				if renamed, ok := renames[strings.TrimPrefix(ref, definitionRefPrefix)]; ok {
+					v[key] = definitionRefPrefix + renamed
+				}
+				continue
+			}
+			v[key] = rewriteRefs(child, renames)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = rewriteRefs(child, renames)
+		}
+	}
+	return node
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"api-gateway/internal/proxy"
+	"api-gateway/internal/transport/http/dto"
+
+	"shared/auth"
+	"shared/identity"
+	"shared/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthMiddleware xác thực access token một lần tại gateway rồi gắn danh tính đã ký cho service phía sau.
+// Header danh tính client tự gửi luôn bị xóa. Request không có token được chuyển tiếp như khách,
+// service tự quyết định endpoint nào cần đăng nhập. Phải đặt sau Router.Resolve.
+func AuthMiddleware(verifier *auth.Verifier, signer *identity.Signer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity.Strip(c.Request.Header)
+
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" || proxy.CurrentRoute(c).Public {
+			c.Next()
+			return
+		}
+		// Tách chuỗi để lấy token
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ApiResponse{
+				Success: false,
+				Message: "Định dạng Token phải là 'Bearer <token>'",
+			})
+			return
+		}
+		claims, err := verifier.Verify(parts[1])
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ApiResponse{
+				Success: false,
+				Message: "Token không hợp lệ hoặc đã hết hạn",
+			})
+			return
+		}
+		// Token vẫn được chuyển tiếp để service gọi service khác thay mặt user
+		logging.SetUserID(c.Request.Context(), claims.UserID)
+		signer.Sign(c.Request, identity.Identity{UserID: claims.UserID, Role: claims.Role})
+
+		c.Next()
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"content-service/internal/entitlement"
+	"content-service/internal/storage"
+	"time"
+
+	"shared/config"
+	"shared/eventbus"
+	"shared/identity"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/rpc"
+)
+
+// Config là toàn bộ cấu hình của content-service, nạp một lần lúc khởi động bằng config.MustLoad
+type Config struct {
+	HTTPPort int `env:"HTTP_PORT" default:"8081" min:"1"`
+	GRPCPort int `env:"GRPC_PORT" default:"9081" min:"1"`
+
+	Log       logging.Config
+	Lifecycle lifecycle.Config
+	Database  config.Database
+	JWT       config.JWT
+	CORS      config.CORS
+	EventBus  eventbus.Config
+	Gateway   identity.Config
+	GRPCTLS   rpc.TLSConfig
+
+	Storage      storage.Config
+	Entitlements entitlement.Config
+
+	// Giới hạn kích thước nội dung chương tải lên (byte)
+	MaxChapterContent int64 `env:"CHAPTER_MAX_CONTENT_BYTES" default:"10485760" min:"1"`
+	// Chu kỳ xuất bản truyện/chương hẹn giờ và chu kỳ cập nhật chỉ mục tìm kiếm
+	PublishInterval time.Duration `env:"PUBLISH_SCHEDULER_INTERVAL" default:"30s" min:"1s"`
+	IndexInterval   time.Duration `env:"SEARCH_INDEX_INTERVAL" default:"10s" min:"1s"`
+}
@@ -1,21 +1,238 @@
 package main
 
 import (
+	"content-service/internal/consumer"
 	"content-service/internal/database"
-	"fmt"
+	"content-service/internal/entitlement"
+	"content-service/internal/publishing"
+	"content-service/internal/repository"
+	"content-service/internal/search"
+	"content-service/internal/storage"
+	"content-service/internal/transport/grpc"
+	"content-service/internal/transport/http"
+	"content-service/internal/transport/http/middleware"
+	"content-service/migrations"
+	"content-service/pkg/auth"
+	"context"
+	"log"
+	"os"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	_ "content-service/docs"
+
+	jwtauth "shared/auth"
+	"shared/config"
+	"shared/eventbus"
+	"shared/health"
+	"shared/identity"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/migrate"
+	"shared/outbox"
+	"shared/rpc"
+	"shared/rpc/contentpb"
+
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+// @title Content Service API
+
+// @version 1.0
+
+// @description API quản lý truyện, chương và thể loại (Gin + Swagger)
+
+// @host localhost:8081
+
+// @BasePath /
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 func main() {
-	// 1. Kết nối DB
-	db := database.InitDB()
+	// Lệnh quản lý schema: go run ./cmd migrate up|down|status|create, chỉ cần cấu hình database
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		var dbCfg config.Database
+		config.MustLoad(&dbCfg)
+		connect := func() *gorm.DB { return database.Connect(dbCfg) }
+		if err := migrate.RunCLI(os.Args[2:], connect, migrations.FS, "migrations"); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// 0. Nạp và kiểm tra cấu hình, dừng ngay nếu thiếu biến bắt buộc hoặc secret quá yếu
+	var cfg Config
+	config.MustLoad(&cfg)
+	if err := logging.Setup("content-service", cfg.Log); err != nil {
+		log.Fatal("❌ Cấu hình log không hợp lệ: ", err)
+	}
+	config.LogEffective("Content Service", &cfg)
+
+	// Các thành phần được dừng theo thứ tự ngược với lúc thêm vào app khi nhận SIGTERM:
+	// HTTP server trả lời xong request đang xử lý trước, database đóng sau cùng.
+	app := lifecycle.New("Content Service", cfg.Lifecycle)
+
+	// 1. Kết nối DB và event bus. Relay đẩy event BookPublished trong outbox lên broker.
+	db := database.InitDB(cfg.Database)
+	app.Add(lifecycle.Closer("database", func() error { return database.Close(db) }))
+	broker, err := eventbus.New(cfg.EventBus, cfg.Database)
+	if err != nil {
+		log.Fatal("❌ Không thể khởi tạo event bus:", err)
+	}
+	app.Add(lifecycle.Closer("event bus", broker.Close))
+	app.Add(lifecycle.Worker("outbox relay", outbox.NewRelay(db, broker, "content-service").Run))
+
+	// 2. Khởi tạo Repository & Handler
+	bookRepo := repository.NewBookRepository(db)
+	chapterRepo := repository.NewChapterRepository(db)
+	categoryRepo := repository.NewCategoryRepository(db)
+	entitlements, err := entitlement.New(cfg.Entitlements, cfg.GRPCTLS)
+	if err != nil {
+		log.Fatal("❌ Không thể khởi tạo kết nối tới payment-service:", err)
+	}
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		log.Fatal("❌ Không thể khởi tạo nơi lưu nội dung chương:", err)
+	}
+	bookHandler := http.NewBookHandler(bookRepo, chapterRepo, categoryRepo, entitlements, store, cfg.MaxChapterContent)
+	categoryHandler := http.NewCategoryHandler(categoryRepo)
+	tagHandler := http.NewTagHandler(repository.NewTagRepository(db))
+	progressHandler := http.NewProgressHandler(repository.NewProgressRepository(db), bookHandler)
+	reviewHandler := http.NewReviewHandler(repository.NewReviewRepository(db), bookHandler)
+	commentHandler := http.NewCommentHandler(repository.NewCommentRepository(db), bookHandler)
+	searchRepo := repository.NewSearchRepository(db)
+	searchHandler := http.NewSearchHandler(searchRepo)
+
+	app.Add(lifecycle.Background("event consumers", func(ctx context.Context) error {
+		return consumer.Register(ctx, broker, entitlements)
+	}))
 
-	// Thử kiểm tra kết nối bằng cách in ra thông tin DB
-	sqlDB, _ := db.DB()
-	err := sqlDB.Ping()
-	if err == nil {
-		fmt.Println("🚀 User Service đang chạy và sẵn sàng!")
+	// gRPC nội bộ cho payment-service tra cứu giá truyện/chương, chạy song song với Gin
+	grpcServer, err := rpc.NewServer(cfg.GRPCTLS)
+	if err != nil {
+		log.Fatal("❌ Không thể khởi tạo gRPC server:", err)
 	}
+	contentpb.RegisterContentServiceServer(grpcServer, grpc.NewContentServer(bookRepo, chapterRepo))
+	app.Add(lifecycle.GRPC("gRPC server", grpcServer, cfg.GRPCTLS.ListenAddr(cfg.GRPCPort)))
 
-	// Sau này bạn sẽ khởi chạy Server HTTP (Gin) hoặc gRPC ở đây
-	select {} // Giữ cho main không bị thoát
+	// Xuất bản truyện/chương hẹn giờ khi tới publish_at
+	app.Add(lifecycle.Worker("publish scheduler", publishing.NewScheduler(bookRepo, chapterRepo, cfg.PublishInterval).Run))
+	// Đưa văn bản của chương mới xuất bản vào chỉ mục tìm kiếm
+	app.Add(lifecycle.Worker("search indexer", search.NewIndexer(searchRepo, store, cfg.IndexInterval).Run))
+
+	// Token do user-service cấp; request qua API gateway mang danh tính đã ký bằng GATEWAY_IDENTITY_SECRET
+	verifier := jwtauth.NewVerifier(cfg.JWT)
+	gateway := identity.NewSigner(cfg.Gateway)
+	requireAuth := middleware.AuthMiddleware(verifier, gateway)
+	optionalAuth := middleware.OptionalAuthMiddleware(verifier, gateway)
+
+	// 3. Khởi tạo Gin
+	// Request ID và access log JSON thay cho logger mặc định của Gin; panic trả 500 với message chung
+	r := gin.New()
+	r.Use(logging.Middleware(), logging.Recovery())
+
+	// Cấu hình CORS để UI có thể gọi API
+	r.Use(cors.New(cors.Config{
+		AllowOrigins:     cfg.CORS.AllowedOrigins, // URL của UI, cấu hình qua CORS_ALLOWED_ORIGINS
+		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", logging.HeaderRequestID},
+		ExposeHeaders:    []string{logging.HeaderRequestID},
+		AllowCredentials: true,
+	}))
+
+	// 4. Swagger endpoint
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	// Liveness và readiness cho orchestrator/load balancer; /readyz trả 503 ngay khi bắt đầu dừng
+	checker := health.New()
+	checker.Add("database", health.DB(db))
+	checker.Add("event bus", broker.Ping)
+	checker.Add("payment-service", health.HTTP(cfg.Entitlements.ServiceURL+"/healthz"))
+	app.OnShutdown(checker.Drain)
+	r.GET("/healthz", gin.WrapF(checker.Liveness))
+	r.GET("/readyz", gin.WrapF(checker.Readiness))
+
+	// 5. Định nghĩa Routes
+
+	// Đọc truyện/chương/thể loại là công khai, thao tác ghi cần đăng nhập.
+	// Quyền sửa truyện và chương được kiểm tra theo Book.AuthorID trong handler.
+	books := r.Group("/books")
+	{
+		// Token là tùy chọn: tác giả/admin thấy thêm truyện chưa xuất bản
+		books.GET("", optionalAuth, bookHandler.ListBooks)
+		books.GET("/:id", optionalAuth, bookHandler.GetBook)
+		books.GET("/:id/chapters", optionalAuth, bookHandler.ListChapters)
+		// Token là tùy chọn: khách đọc được chương miễn phí, chương trả phí cần đăng nhập và đã mua
+		books.GET("/:id/chapters/:number", optionalAuth, bookHandler.GetChapter)
+		books.GET("/:id/chapters/:number/content", optionalAuth, bookHandler.DownloadChapterContent)
+		books.GET("/:id/ratings", optionalAuth, reviewHandler.GetRatingSummary)
+		books.GET("/:id/reviews", optionalAuth, reviewHandler.ListReviews)
+		// Token là tùy chọn: người viết và admin thấy nội dung bình luận bị ẩn
+		books.GET("/:id/chapters/:number/comments", optionalAuth, commentHandler.ListComments)
+		books.GET("/:id/chapters/:number/comments/:comment/replies", optionalAuth, commentHandler.ListReplies)
+
+		authed := books.Group("", requireAuth)
+		authed.POST("", middleware.RequirePermission(auth.PermBookCreate), bookHandler.CreateBook)
+		authed.PUT("/:id", bookHandler.UpdateBook)
+		authed.PUT("/:id/status", bookHandler.SetBookStatus)
+		authed.DELETE("/:id", bookHandler.DeleteBook)
+		authed.POST("/:id/chapters", bookHandler.CreateChapter)
+		authed.PUT("/:id/chapters/:number", bookHandler.UpdateChapter)
+		authed.PUT("/:id/chapters/:number/status", bookHandler.SetChapterStatus)
+		authed.DELETE("/:id/chapters/:number", bookHandler.DeleteChapter)
+		authed.POST("/:id/chapters/:number/content", bookHandler.UploadChapterContent)
+		// Lịch sử revision chỉ dành cho tác giả/admin
+		authed.GET("/:id/chapters/:number/revisions", bookHandler.ListChapterRevisions)
+		authed.GET("/:id/chapters/:number/revisions/:revision", bookHandler.GetChapterRevision)
+		authed.GET("/:id/chapters/:number/revisions/:revision/content", bookHandler.DownloadChapterRevision)
+		authed.GET("/:id/chapters/:number/revisions/:revision/diff", bookHandler.DiffChapterRevisions)
+		authed.POST("/:id/chapters/:number/revisions/:revision/restore", bookHandler.RestoreChapterRevision)
+		authed.POST("/:id/chapters/:number/revisions/:revision/publish", bookHandler.PublishChapterRevision)
+		// Tiến độ đọc của user hiện tại và thống kê hoàn thành cho tác giả/admin
+		authed.GET("/:id/progress", progressHandler.GetBookProgress)
+		authed.GET("/:id/reading-stats", progressHandler.GetReadingStats)
+		// Đánh giá và bình luận: sửa/xóa của chính mình, ẩn nội dung vi phạm cần quyền kiểm duyệt
+		authed.GET("/:id/review", reviewHandler.GetMyReview)
+		authed.PUT("/:id/review", reviewHandler.SaveMyReview)
+		authed.DELETE("/:id/review", reviewHandler.DeleteMyReview)
+		authed.PUT("/:id/reviews/:review/moderation", middleware.RequirePermission(auth.PermContentModerate), reviewHandler.ModerateReview)
+		authed.POST("/:id/chapters/:number/comments", commentHandler.CreateComment)
+		authed.PUT("/:id/chapters/:number/comments/:comment", commentHandler.UpdateComment)
+		authed.DELETE("/:id/chapters/:number/comments/:comment", commentHandler.DeleteComment)
+		authed.PUT("/:id/chapters/:number/comments/:comment/moderation", middleware.RequirePermission(auth.PermContentModerate), commentHandler.ModerateComment)
+	}
+
+	// Dữ liệu cá nhân của người đọc, theo userID trong token
+	me := r.Group("/me", requireAuth)
+	{
+		me.GET("/progress", progressHandler.ListProgressChanges)
+		me.PUT("/progress", progressHandler.SyncProgress)
+		me.GET("/shelf", progressHandler.GetShelf)
+	}
+
+	r.GET("/search", searchHandler.Search)
+
+	categories := r.Group("/categories")
+	{
+		categories.GET("", categoryHandler.ListCategories)
+		categories.GET("/tree", categoryHandler.GetCategoryTree)
+		categories.GET("/:id", categoryHandler.GetCategory)
+
+		admin := categories.Group("", requireAuth, middleware.RequirePermission(auth.PermCategoryManage))
+		admin.POST("", categoryHandler.CreateCategory)
+		admin.PUT("/:id", categoryHandler.UpdateCategory)
+		admin.DELETE("/:id", categoryHandler.DeleteCategory)
+	}
+
+	r.GET("/tags", tagHandler.ListTags)
+
+	// 6. Chạy Server cùng các thành phần đã đăng ký cho tới khi nhận SIGINT/SIGTERM
+	app.Add(lifecycle.HTTP("HTTP server", cfg.HTTPPort, r))
+	if err := app.Run(); err != nil {
+		log.Fatal("❌ Content Service dừng do lỗi: ", err)
+	}
 }
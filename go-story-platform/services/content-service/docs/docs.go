@@ -0,0 +1,4087 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplate = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "contact": {},
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/books": {
+            "get": {
+                "description": "Người đọc chỉ thấy truyện published. Tác giả xem truyện của mình (author_id = chính mình)\nhoặc admin được lọc theo status, mặc định là mọi trạng thái.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Books"
+                ],
+                "summary": "Lấy danh sách truyện",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lọc theo tác giả",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lọc theo thể loại (gồm cả thể loại con cháu)",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lọc theo slug thể loại (gồm cả thể loại con cháu)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lọc theo thẻ (tên hoặc slug)",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lọc theo trạng thái (chỉ tác giả/admin)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Trang (mặc định 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số bản ghi mỗi trang (mặc định 20, tối đa 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.BookListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "User đang đăng nhập (vai trò author/admin) trở thành tác giả của truyện.\nTruyện mới ở trạng thái draft, dùng PUT /books/{id}/status để xuất bản.\nTối đa 5 thể loại và 20 thẻ; thẻ chưa có được tạo mới, slug của thẻ được bỏ dấu tiếng Việt.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Books"
+                ],
+                "summary": "Tạo truyện mới",
+                "parameters": [
+                    {
+                        "description": "Thông tin truyện",
+                        "name": "book",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.CreateBookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.BookResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Books"
+                ],
+                "summary": "Lấy truyện theo ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.BookResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ tác giả của truyện hoặc admin được sửa. category_ids/tags thay toàn bộ danh sách cũ.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Books"
+                ],
+                "summary": "Cập nhật truyện",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Các trường cần sửa",
+                        "name": "book",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.UpdateBookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.BookResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Xóa mềm truyện cùng toàn bộ chương. Chỉ tác giả hoặc admin được xóa.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Books"
+                ],
+                "summary": "Xóa truyện",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/chapters": {
+            "get": {
+                "description": "Các chương được sắp xếp theo ChapterNumber tăng dần. Người đọc chỉ thấy chương published.\ncontent_url của chương bị khóa không được trả ở đây (trừ tác giả/admin), hãy lấy qua API từng chương.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chapters"
+                ],
+                "summary": "Lấy danh sách chương của truyện",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/content-service_internal_transport_http_dto.ChapterResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ tác giả của truyện hoặc admin được thêm chương. Số thứ tự chương không được trùng.\ncontent_url (nếu có) được lưu thành revision 1. Chương mới ở trạng thái draft.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chapters"
+                ],
+                "summary": "Thêm chương mới",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Thông tin chương",
+                        "name": "chapter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ChapterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ChapterResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/chapters/{number}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chương trả phí chỉ trả content_url khi người đọc đã mua chương hoặc trọn bộ truyện\n(kiểm tra qua payment-service), hoặc là tác giả/admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chapters"
+                ],
+                "summary": "Lấy một chương theo số thứ tự",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ChapterResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ tác giả của truyện hoặc admin được sửa. Có thể đổi số thứ tự nếu số mới chưa được dùng.\ncontent_url mới được lưu thành revision mới, là bản nháp trừ khi publish=true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chapters"
+                ],
+                "summary": "Cập nhật chương",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Thông tin chương",
+                        "name": "chapter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ChapterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ChapterResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ tác giả của truyện hoặc admin được xóa",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chapters"
+                ],
+                "summary": "Xóa chương",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/chapters/{number}/comments": {
+            "get": {
+                "description": "Mới nhất trước, mỗi bình luận kèm reply_count; dùng API replies để lấy trả lời.\nBình luận đã xóa hoặc bị ẩn vẫn có trong danh sách (body rỗng) để giữ mạch trả lời;\nngười viết và admin vẫn thấy nội dung bình luận bị ẩn.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Lấy bình luận gốc của chương",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Con trỏ trang tiếp theo",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số bản ghi mỗi trang (mặc định 20, tối đa 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.CommentListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gửi parent_id để trả lời một bình luận (tối đa 5 cấp). Không trả lời được bình luận đã xóa hoặc bị ẩn.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Bình luận chương",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nội dung bình luận",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.CreateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.CommentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/chapters/{number}/comments/{comment}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ người viết. Bình luận bị admin ẩn vẫn ở trạng thái ẩn sau khi sửa.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Sửa bình luận",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nội dung mới",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.UpdateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.CommentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ người viết. Nội dung bị xóa nhưng bình luận vẫn giữ chỗ trong luồng để các trả lời không bị mất.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Xóa bình luận",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/chapters/{number}/comments/{comment}/moderation": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ admin. Bình luận bị ẩn vẫn giữ chỗ trong luồng nhưng người khác không thấy nội dung.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Ẩn hoặc hiện lại bình luận",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ẩn/hiện và lý do",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ModerationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.CommentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/chapters/{number}/comments/{comment}/replies": {
+            "get": {
+                "description": "Các trả lời trực tiếp, cũ nhất trước.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Lấy trả lời của một bình luận",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Con trỏ trang tiếp theo",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số bản ghi mỗi trang (mặc định 20, tối đa 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.CommentListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/chapters/{number}/content": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Trả nội dung dạng stream, hỗ trợ header Range (206 Partial Content) và If-None-Match theo ETag là CID.\nChương trả phí cần đã mua chương hoặc trọn bộ truyện, hoặc là tác giả/admin.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Chapters"
+                ],
+                "summary": "Đọc nội dung chương đã tải lên",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ví dụ bytes=0-1023",
+                        "name": "Range",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "416": {
+                        "description": "Requested Range Not Satisfiable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ tác giả của truyện hoặc admin được tải lên. Nội dung gửi dạng body thô hoặc multipart (field \"file\").\nNội dung được lưu theo IPFS CID (CIDv1, raw/dag-pb, sha2-256), content_url trở thành ipfs://\u003ccid\u003e.\nMỗi lần tải lên tạo một revision mới. Revision chỉ được xuất bản ngay khi publish=true\nhoặc chương chưa có nội dung xuất bản; ngược lại nó là bản nháp cho tới khi được xuất bản.\nTải lại nội dung giống hệt revision mới nhất không tạo revision hay blob mới.",
+                "consumes": [
+                    "application/octet-stream",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chapters"
+                ],
+                "summary": "Tải lên nội dung chương",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File nội dung chương (khi gửi multipart)",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ghi chú thay đổi",
+                        "name": "note",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Xuất bản ngay revision mới",
+                        "name": "publish",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ChapterResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/chapters/{number}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ tác giả của truyện hoặc admin được xem. Revision mới nhất đứng trước.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chapter Revisions"
+                ],
+                "summary": "Lấy lịch sử revision của chương",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/content-service_internal_transport_http_dto.ChapterRevisionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/chapters/{number}/revisions/{revision}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ tác giả của truyện hoặc admin được xem",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chapter Revisions"
+                ],
+                "summary": "Lấy một revision của chương",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số revision",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ChapterRevisionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/chapters/{number}/revisions/{revision}/content": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ tác giả của truyện hoặc admin được đọc, kể cả revision chưa xuất bản. Hỗ trợ header Range.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Chapter Revisions"
+                ],
+                "summary": "Đọc nội dung của một revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số revision",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ví dụ bytes=0-1023",
+                        "name": "Range",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/chapters/{number}/revisions/{revision}/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "So sánh revision from (mặc định là revision liền trước) với revision trong path, theo dòng hoặc theo từ.\nGhép text của các op equal+delete ra nội dung cũ, equal+insert ra nội dung mới.\nRevision chỉ có link ngoài được so sánh theo link. Nội dung quá 2 MB hoặc tổng cộng quá 200.000 dòng/từ trả về 422.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chapter Revisions"
+                ],
+                "summary": "So sánh hai revision của chương",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số revision",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision gốc để so sánh",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "line (mặc định) hoặc word",
+                        "name": "mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.RevisionDiffResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/chapters/{number}/revisions/{revision}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ghim revision làm nội dung người đọc nhìn thấy; có thể ghim lại revision cũ hơn.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chapter Revisions"
+                ],
+                "summary": "Xuất bản một revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số revision",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ChapterResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/chapters/{number}/revisions/{revision}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tạo revision mới có nội dung giống revision được chọn; lịch sử không bị sửa.\nRevision mới là bản nháp trừ khi publish=true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chapter Revisions"
+                ],
+                "summary": "Khôi phục một revision cũ",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số revision",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ghi chú và tùy chọn xuất bản",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.RestoreRevisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ChapterResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/chapters/{number}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Giống trạng thái của truyện. Chương phải có revision đã xuất bản, và chương của truyện premium phải có giá.\nNgười đọc chỉ thấy chương khi cả truyện và chương đều hiển thị.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chapters"
+                ],
+                "summary": "Chuyển trạng thái xuất bản của chương",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số thứ tự chương",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Trạng thái mới",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.PublicationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ChapterResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/progress": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chương đọc gần nhất, tiến độ từng chương đã đọc và mức độ hoàn thành trên các chương đang hiển thị.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reading"
+                ],
+                "summary": "Tiến độ đọc truyện của user hiện tại",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.BookProgressResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/ratings": {
+            "get": {
+                "description": "Điểm trung bình, số đánh giá và số đánh giá theo từng mức sao. Đánh giá bị ẩn không được tính.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Điểm đánh giá của truyện",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.RatingSummaryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/reading-stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ tác giả của truyện hoặc admin. Số người đọc, số người đã đọc xong mọi chương đang hiển thị\nvà số người bắt đầu/đọc xong từng chương.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reading"
+                ],
+                "summary": "Thống kê hoàn thành của truyện",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ReadingStatsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/review": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Lấy đánh giá của user hiện tại cho truyện",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ReviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mỗi người đọc một đánh giá cho mỗi truyện: gửi lại sẽ sửa đánh giá cũ. Tác giả không đánh giá được truyện của mình.\nĐánh giá bị admin ẩn vẫn ở trạng thái ẩn sau khi sửa.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Đánh giá truyện",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Số sao và nhận xét",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ReviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ReviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Xóa đánh giá của user hiện tại cho truyện",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/reviews": {
+            "get": {
+                "description": "Đánh giá đang hiển thị, mới nhất trước. Gửi next_cursor của trang trước vào cursor để lấy trang tiếp.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Lấy danh sách đánh giá của truyện",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lọc theo số sao (1-5)",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Con trỏ trang tiếp theo",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số bản ghi mỗi trang (mặc định 20, tối đa 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ReviewListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/reviews/{review}/moderation": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ admin. Đánh giá bị ẩn không xuất hiện trong danh sách và không được tính vào điểm trung bình.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Ẩn hoặc hiện lại đánh giá",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "review",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ẩn/hiện và lý do",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ModerationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ReviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "draft → scheduled (cần publish_at) / published / unlisted; published ⇄ unlisted; về draft để ẩn.\nTruyện premium phải có giá trước khi hiển thị. Chỉ admin được gỡ (taken_down) và khôi phục về draft.\nLần đầu truyện hiển thị cho người đọc, event book.published được phát ra.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Books"
+                ],
+                "summary": "Chuyển trạng thái xuất bản của truyện",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Trạng thái mới",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.PublicationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.BookResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Lấy danh sách thể loại",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/content-service_internal_transport_http_dto.CategoryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ admin. Slug bỏ trống sẽ được sinh tự động từ tên, chữ tiếng Việt được bỏ dấu\n(\"Kiếm Hiệp\" → \"kiem-hiep\"). parent_slug là thể loại cha, bỏ trống nếu là thể loại gốc.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Tạo thể loại",
+                "parameters": [
+                    {
+                        "description": "Thông tin thể loại",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.CategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.CategoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/tree": {
+            "get": {
+                "description": "Các thể loại gốc kèm thể loại con lồng nhau, sắp theo tên. book_count là số truyện đã xuất bản\ncủa thể loại và mọi thể loại con cháu. Dùng GET /books?category=\u003cslug\u003e để xem truyện của một nhánh.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Lấy cây thể loại",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/content-service_internal_transport_http_dto.CategoryNode"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Lấy thể loại theo ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.CategoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ admin. Không thể chọn chính thể loại hoặc thể loại con cháu của nó làm thể loại cha.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Cập nhật thể loại",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Thông tin thể loại",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.CategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.CategoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chỉ admin. Thể loại con được chuyển lên thể loại cha, các truyện được bỏ khỏi thể loại.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Xóa thể loại",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/progress": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Trả về tiến độ server đã ghi sau cursor, theo thứ tự ghi, tối đa 500 bản ghi mỗi lần.\nBỏ trống cursor để lấy toàn bộ. Lưu next_cursor và gửi lại làm cursor ở lần kéo sau; khi has_more = true thì kéo tiếp ngay.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reading"
+                ],
+                "summary": "Kéo tiến độ đọc đã thay đổi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "next_cursor của lần kéo trước",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ProgressChangesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Thiết bị gửi lên tiến độ của các chương (tối đa 100 item). Mỗi chương giữ bản có updated_at mới nhất\n(last-write-wins); bản cũ hơn bản trên server có status stale kèm bản của server để thiết bị cập nhật lại.\nupdated_at vượt giờ server quá 5 phút bị đưa về giờ server. Chương đã đọc xong (percentage = 100)\nluôn được giữ là đã đọc xong. Item không hợp lệ có status rejected, các item khác vẫn được ghi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reading"
+                ],
+                "summary": "Đồng bộ tiến độ đọc",
+                "parameters": [
+                    {
+                        "description": "Tiến độ các chương",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ProgressSyncRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ProgressSyncResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/shelf": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Các truyện user đang đọc, đọc gần nhất đứng trước, kèm chương đọc gần nhất và số chương đã đọc xong.\nTruyện đã đọc xong mọi chương chỉ xuất hiện khi include_finished=true.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reading"
+                ],
+                "summary": "Kệ \"đọc tiếp\"",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Gồm cả truyện đã đọc xong",
+                        "name": "include_finished",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Trang (mặc định 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số bản ghi mỗi trang (mặc định 20, tối đa 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ShelfResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "Tìm theo tên truyện, mô tả, tên thể loại, tên thẻ và nội dung chương; có dấu hay không dấu đều khớp.\nHỗ trợ cú pháp web: \"cụm từ chính xác\", -từ_loại_trừ, or. Chỉ tìm trong truyện và chương đã xuất bản; nội dung chương trả phí không được tìm.\nKết quả xếp theo mức độ liên quan, kèm đoạn trích đánh dấu \u003cmark\u003e và facet theo thể loại/premium.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Tìm kiếm truyện",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Từ khóa",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lọc theo thể loại (gồm cả thể loại con)",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Lọc truyện premium (true) hoặc miễn phí (false)",
+                        "name": "premium",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Trang (mặc định 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số bản ghi mỗi trang (mặc định 20, tối đa 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.SearchResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Chỉ gồm thẻ đang gắn cho truyện đã xuất bản, thẻ nhiều truyện nhất đứng trước.\nDùng GET /books?tag=\u003cslug\u003e để xem truyện của một thẻ.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Lấy danh sách thẻ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lọc thẻ có slug bắt đầu bằng từ khóa (có dấu hay không đều được)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Trang (mặc định 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Số bản ghi mỗi trang (mặc định 20, tối đa 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/content-service_internal_transport_http_dto.TagListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "content-service_internal_transport_http_dto.ApiResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "interface giúp chứa bất kỳ dto nào"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.BookCategoryRef": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.BookListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.BookResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.BookProgressResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "integer"
+                },
+                "chapters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.ProgressResponse"
+                    }
+                },
+                "completed_chapters": {
+                    "type": "integer"
+                },
+                "completion": {
+                    "type": "number"
+                },
+                "current": {
+                    "description": "Chương đọc gần nhất, null nếu chưa đọc",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.ProgressResponse"
+                        }
+                    ]
+                },
+                "total_chapters": {
+                    "type": "integer"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.BookResponse": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.BookCategoryRef"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_premium": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.TagRef"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.CategoryFacet": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.CategoryNode": {
+            "type": "object",
+            "properties": {
+                "book_count": {
+                    "description": "Số truyện đã xuất bản, tính cả thể loại con cháu",
+                    "type": "integer"
+                },
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.CategoryNode"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.CategoryRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "parent_slug": {
+                    "description": "Slug của thể loại cha, bỏ trống nếu là thể loại gốc",
+                    "type": "string"
+                },
+                "slug": {
+                    "description": "Bỏ trống để tự sinh từ Name (bỏ dấu tiếng Việt)",
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "parent_slug": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ChapterReadersResponse": {
+            "type": "object",
+            "properties": {
+                "chapter_number": {
+                    "type": "integer"
+                },
+                "completed": {
+                    "type": "integer"
+                },
+                "started": {
+                    "type": "integer"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ChapterRequest": {
+            "type": "object",
+            "properties": {
+                "change_note": {
+                    "description": "Ghi chú cho revision mới khi content_url thay đổi",
+                    "type": "string"
+                },
+                "chapter_number": {
+                    "type": "integer"
+                },
+                "content_url": {
+                    "description": "Link ngoài; bỏ trống nếu nội dung sẽ được tải lên qua API content",
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "publish": {
+                    "description": "Xuất bản ngay nội dung mới thay vì để làm bản nháp",
+                    "type": "boolean"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ChapterResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "integer"
+                },
+                "chapter_number": {
+                    "type": "integer"
+                },
+                "content_cid": {
+                    "description": "IPFS CID của nội dung đã tải lên, dùng để kiểm chứng nội dung; bỏ trống như content_url",
+                    "type": "string"
+                },
+                "content_size": {
+                    "description": "Kích thước nội dung đã tải lên (byte); bỏ trống như content_url",
+                    "type": "integer"
+                },
+                "content_url": {
+                    "description": "Bỏ trống khi chương bị khóa với người đọc hiện tại",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latest_revision": {
+                    "description": "Revision mới nhất, có thể chưa xuất bản",
+                    "type": "integer"
+                },
+                "locked": {
+                    "description": "Chương trả phí (truyện premium hoặc có giá lẻ)",
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "published_revision": {
+                    "description": "Revision người đọc đang thấy",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ChapterRevisionResponse": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer"
+                },
+                "change_note": {
+                    "type": "string"
+                },
+                "content_cid": {
+                    "description": "Hash nội dung, rỗng nếu revision chỉ là link ngoài",
+                    "type": "string"
+                },
+                "content_size": {
+                    "type": "integer"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "content_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "latest": {
+                    "description": "Revision mới nhất (bản nháp hiện tại)",
+                    "type": "boolean"
+                },
+                "published": {
+                    "description": "Revision đang được xuất bản cho người đọc",
+                    "type": "boolean"
+                },
+                "revision": {
+                    "type": "integer"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.CommentListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.CommentResponse"
+                    }
+                },
+                "next_cursor": {
+                    "description": "Rỗng khi đã hết",
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.CommentResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "Rỗng nếu bình luận đã xóa hoặc bị ẩn",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "depth": {
+                    "type": "integer"
+                },
+                "edited_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "reply_count": {
+                    "type": "integer"
+                },
+                "spoiler": {
+                    "description": "Client nên che nội dung cho tới khi người đọc chọn xem",
+                    "type": "boolean"
+                },
+                "status": {
+                    "description": "visible, hidden (bị admin ẩn), deleted",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.CreateBookRequest": {
+            "type": "object",
+            "properties": {
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "is_premium": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "description": "Tên thẻ tự do, thẻ chưa có sẽ được tạo",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.CreateCommentRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "Bình luận được trả lời, bỏ trống nếu là bình luận gốc",
+                    "type": "integer"
+                },
+                "spoiler": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.DiffOp": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "description": "equal, insert hoặc delete",
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ModerationRequest": {
+            "type": "object",
+            "properties": {
+                "hidden": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.PremiumFacet": {
+            "type": "object",
+            "properties": {
+                "free": {
+                    "type": "integer"
+                },
+                "premium": {
+                    "type": "integer"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ProgressChangesResponse": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.ProgressResponse"
+                    }
+                },
+                "next_cursor": {
+                    "description": "Gửi lại làm cursor ở lần kéo sau",
+                    "type": "integer"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ProgressResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "integer"
+                },
+                "chapter_number": {
+                    "type": "integer"
+                },
+                "completed": {
+                    "type": "boolean"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "synced_at": {
+                    "description": "Thời điểm server lưu",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Thời điểm ghi trên thiết bị",
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ProgressSyncItem": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "integer"
+                },
+                "chapter_number": {
+                    "type": "integer"
+                },
+                "percentage": {
+                    "description": "0-100, 100 = đọc xong chương",
+                    "type": "number"
+                },
+                "position": {
+                    "description": "Vị trí trong chương do client định nghĩa (vd. offset ký tự)",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "Thời điểm ghi trên thiết bị (RFC3339), bản mới hơn thắng",
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ProgressSyncRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.ProgressSyncItem"
+                    }
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ProgressSyncResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.ProgressSyncResult"
+                    }
+                },
+                "server_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ProgressSyncResult": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "integer"
+                },
+                "chapter_number": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "progress": {
+                    "$ref": "#/definitions/content-service_internal_transport_http_dto.ProgressResponse"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.PublicationRequest": {
+            "type": "object",
+            "properties": {
+                "publish_at": {
+                    "description": "Bắt buộc khi status = scheduled, phải ở tương lai",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "draft, scheduled, published, unlisted, taken_down (chỉ admin)",
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.RatingSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "description": "Làm tròn 2 chữ số, 0 khi chưa có đánh giá",
+                    "type": "number"
+                },
+                "book_id": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "histogram": {
+                    "description": "Số đánh giá theo số sao, khóa \"1\"-\"5\"",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ReadingStatsResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "integer"
+                },
+                "chapters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.ChapterReadersResponse"
+                    }
+                },
+                "completion_rate": {
+                    "description": "Phần trăm người đọc đã đọc xong",
+                    "type": "number"
+                },
+                "finished": {
+                    "description": "Số người đã đọc xong mọi chương",
+                    "type": "integer"
+                },
+                "readers": {
+                    "description": "Số người đã đọc ít nhất một chương",
+                    "type": "integer"
+                },
+                "total_chapters": {
+                    "description": "Số chương đang hiển thị",
+                    "type": "integer"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.RestoreRevisionRequest": {
+            "type": "object",
+            "properties": {
+                "change_note": {
+                    "type": "string"
+                },
+                "publish": {
+                    "description": "Xuất bản ngay revision được tạo ra từ bản khôi phục",
+                    "type": "boolean"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ReviewListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.ReviewResponse"
+                    }
+                },
+                "next_cursor": {
+                    "description": "Rỗng khi đã hết",
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ReviewRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "Nhận xét, có thể bỏ trống",
+                    "type": "string"
+                },
+                "rating": {
+                    "description": "1-5 sao",
+                    "type": "integer"
+                },
+                "spoiler": {
+                    "description": "Nhận xét tiết lộ nội dung truyện",
+                    "type": "boolean"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ReviewResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "book_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "edited_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "moderation_reason": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "spoiler": {
+                    "description": "Client nên che nội dung cho tới khi người đọc chọn xem",
+                    "type": "boolean"
+                },
+                "status": {
+                    "description": "visible, hidden (bị admin ẩn)",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.RevisionDiffResponse": {
+            "type": "object",
+            "properties": {
+                "deletions": {
+                    "description": "Số dòng/từ bị xóa",
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "insertions": {
+                    "description": "Số dòng/từ được thêm",
+                    "type": "integer"
+                },
+                "mode": {
+                    "description": "line hoặc word",
+                    "type": "string"
+                },
+                "ops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.DiffOp"
+                    }
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.SearchChapterHit": {
+            "type": "object",
+            "properties": {
+                "chapter_number": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "snippet": {
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.SearchFacets": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.CategoryFacet"
+                    }
+                },
+                "premium": {
+                    "$ref": "#/definitions/content-service_internal_transport_http_dto.PremiumFacet"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.SearchHit": {
+            "type": "object",
+            "properties": {
+                "book": {
+                    "$ref": "#/definitions/content-service_internal_transport_http_dto.BookResponse"
+                },
+                "chapters": {
+                    "description": "Tối đa 3 chương khớp nhất",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.SearchChapterHit"
+                    }
+                },
+                "description_match": {
+                    "description": "Đoạn mô tả khớp, cùng định dạng với title_highlight",
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "title_highlight": {
+                    "description": "HTML đã escape, từ khớp nằm trong \u003cmark\u003e",
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "facets": {
+                    "description": "Tính trên mọi truyện khớp từ khóa, không áp dụng bộ lọc category_id/premium",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/content-service_internal_transport_http_dto.SearchFacets"
+                        }
+                    ]
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.SearchHit"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ShelfItemResponse": {
+            "type": "object",
+            "properties": {
+                "book": {
+                    "$ref": "#/definitions/content-service_internal_transport_http_dto.BookResponse"
+                },
+                "chapter_number": {
+                    "description": "Chương đọc gần nhất",
+                    "type": "integer"
+                },
+                "completed_chapters": {
+                    "type": "integer"
+                },
+                "completion": {
+                    "description": "Phần trăm số chương đã đọc xong",
+                    "type": "number"
+                },
+                "last_read_at": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "total_chapters": {
+                    "type": "integer"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.ShelfResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.ShelfItemResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.TagListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/content-service_internal_transport_http_dto.TagResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.TagRef": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.TagResponse": {
+            "type": "object",
+            "properties": {
+                "book_count": {
+                    "description": "Số truyện đã xuất bản được gắn thẻ",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.UpdateBookRequest": {
+            "type": "object",
+            "properties": {
+                "category_ids": {
+                    "description": "Thay toàn bộ thể loại, [] để bỏ hết",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "is_premium": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "description": "Thay toàn bộ thẻ, [] để bỏ hết",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "content-service_internal_transport_http_dto.UpdateCommentRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "spoiler": {
+                    "type": "boolean"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    }
+}`
+
+// SwaggerInfo holds exported Swagger Info so clients can modify it
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0",
+	Host:             "localhost:8081",
+	BasePath:         "/",
+	Schemes:          []string{},
+	Title:            "Content Service API",
+	Description:      "API quản lý truyện, chương và thể loại (Gin + Swagger)",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+}
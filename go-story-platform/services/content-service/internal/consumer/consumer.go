@@ -0,0 +1,37 @@
+// Package consumer đăng ký các handler nhận domain event từ service khác qua event bus
+package consumer
+
+import (
+	"content-service/internal/entitlement"
+	"context"
+
+	"shared/eventbus"
+	"shared/events"
+)
+
+// Register đăng ký các consumer của content-service với broker
+func Register(ctx context.Context, broker eventbus.Broker, entitlements *entitlement.CachedChecker) error {
+	// Xóa kết quả đã cache để người vừa mua (hoặc vừa nhận token) đọc được ngay và người
+	// vừa bán token mất quyền đọc. Xóa cache nhiều lần không có hại nên không cần bảng processed_events.
+	// Cache nằm trong bộ nhớ của từng replica nên mỗi replica phải nhận đủ event (SubscribeLocal).
+	return broker.SubscribeLocal(ctx, "content-service.entitlement-cache",
+		[]string{events.TypeChapterPurchased, events.TypePurchaseTransferred},
+		func(_ context.Context, msg eventbus.Message) error {
+			if msg.Type == events.TypePurchaseTransferred {
+				var e events.PurchaseTransferred
+				if err := events.Decode(msg.Payload, &e); err != nil {
+					return err
+				}
+				entitlements.Invalidate(e.FromUserID, e.BookID)
+				entitlements.Invalidate(e.ToUserID, e.BookID)
+				return nil
+			}
+
+			var e events.ChapterPurchased
+			if err := events.Decode(msg.Payload, &e); err != nil {
+				return err
+			}
+			entitlements.Invalidate(e.UserID, e.BookID)
+			return nil
+		})
+}
@@ -0,0 +1,240 @@
+// Package diff so sánh hai văn bản theo dòng hoặc theo từ bằng thuật toán Myers (O(ND)),
+// bản chia để trị chỉ dùng bộ nhớ tuyến tính theo số token.
+package diff
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// Các loại thao tác trong kết quả so sánh
+const (
+	OpEqual  = "equal"
+	OpInsert = "insert"
+	OpDelete = "delete"
+)
+
+// maxEditDistance giới hạn số thao tác thêm/xóa Myers được phép tìm; vượt quá thì coi như
+// thay thế toàn bộ để thời gian O((N+M)D) không kéo dài với hai văn bản khác nhau hoàn toàn
+const maxEditDistance = 4000
+
+// MaxTokens là tổng số token tối đa của hai văn bản được so sánh
+const MaxTokens = 200000
+
+// ErrTooLarge được trả về khi hai văn bản có tổng số token vượt MaxTokens
+var ErrTooLarge = errors.New("văn bản có quá nhiều token để so sánh")
+
+// Op là một đoạn liên tiếp cùng loại thao tác. Ghép Text của các Op equal và delete cho ra văn bản cũ,
+// equal và insert cho ra văn bản mới.
+type Op struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// Lines so sánh theo dòng; mỗi dòng giữ lại ký tự xuống dòng của nó
+func Lines(a, b string) ([]Op, error) {
+	return Tokens(SplitLines(a), SplitLines(b))
+}
+
+// Words so sánh theo từ; khoảng trắng là token riêng nên ghép lại được đúng văn bản gốc
+func Words(a, b string) ([]Op, error) {
+	return Tokens(SplitWords(a), SplitWords(b))
+}
+
+// SplitLines tách văn bản thành các dòng, mỗi dòng kèm "\n" ở cuối (trừ dòng cuối nếu không có)
+func SplitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// SplitWords tách văn bản thành các cụm chữ/số, các cụm khoảng trắng và từng dấu câu
+func SplitWords(s string) []string {
+	var tokens []string
+	start := -1
+	kind := 0 // 1 = chữ/số, 2 = khoảng trắng
+	for i, r := range s {
+		k := 3
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r):
+			k = 1
+		case unicode.IsSpace(r):
+			k = 2
+		}
+		if start >= 0 && (k != kind || k == 3) {
+			tokens = append(tokens, s[start:i])
+			start = -1
+		}
+		if start < 0 {
+			start, kind = i, k
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, s[start:])
+	}
+	return tokens
+}
+
+// Tokens so sánh hai dãy token và gộp các token liền nhau cùng loại thao tác thành một Op.
+// Trả về ErrTooLarge nếu tổng số token vượt MaxTokens.
+func Tokens(a, b []string) ([]Op, error) {
+	if len(a)+len(b) > MaxTokens {
+		return nil, ErrTooLarge
+	}
+	m := newMyers(len(a) + len(b))
+	return merge(m.compare(a, b, maxEditDistance, nil)), nil
+}
+
+// Stats đếm số token thêm và xóa trong kết quả so sánh theo cách tách tương ứng
+func Stats(ops []Op, split func(string) []string) (insertions, deletions int) {
+	for _, op := range ops {
+		switch op.Op {
+		case OpInsert:
+			insertions += len(split(op.Text))
+		case OpDelete:
+			deletions += len(split(op.Text))
+		}
+	}
+	return insertions, deletions
+}
+
+// myers giữ hai mảng V (chiều xuôi và chiều ngược) dùng chung cho mọi lần tìm middle snake
+type myers struct {
+	offset int
+	vf, vb []int
+}
+
+// newMyers cấp phát mảng V đủ cho hai dãy có tổng size token
+func newMyers(size int) *myers {
+	offset := size/2 + 2
+	return &myers{offset: offset, vf: make([]int, 2*offset+1), vb: make([]int, 2*offset+1)}
+}
+
+// compare nối vào ops các thao tác biến a thành b. Phần đầu và phần cuối giống nhau được bỏ ra
+// trước, phần còn lại được chia đôi tại middle snake rồi so sánh đệ quy từng nửa.
+// limit > 0 giới hạn số thao tác thêm/xóa; vượt quá thì coi như thay thế toàn bộ.
+func (m *myers) compare(a, b []string, limit int, ops []Op) []Op {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	tail := a[len(a)-suffix:]
+	ops = appendOp(ops, OpEqual, a[:prefix])
+	a, b = a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	if len(a) > 0 && len(b) > 0 {
+		if x, y, u, v, ok := m.midSnake(a, b, limit); ok {
+			ops = m.compare(a[:x], b[:y], 0, ops)
+			ops = appendOp(ops, OpEqual, a[x:u])
+			ops = m.compare(a[u:], b[v:], 0, ops)
+			return appendOp(ops, OpEqual, tail)
+		}
+	}
+	ops = appendOp(appendOp(ops, OpDelete, a), OpInsert, b)
+	return appendOp(ops, OpEqual, tail)
+}
+
+// midSnake chạy Myers đồng thời từ hai đầu cho tới khi hai đường gặp nhau và trả về đoạn giống nhau
+// (x, y) → (u, v) nằm giữa một đường đi ngắn nhất. ok = false nếu số thao tác vượt limit (khi limit > 0).
+func (m *myers) midSnake(a, b []string, limit int) (x, y, u, v int, ok bool) {
+	n, mm := len(a), len(b)
+	delta := n - mm
+	odd := delta%2 != 0
+	off := m.offset
+	vf, vb := m.vf, m.vb
+	vf[off+1], vb[off+1] = 0, 0
+
+	for d := 0; d <= (n+mm+1)/2; d++ {
+		if limit > 0 && 2*d-1 > limit {
+			return 0, 0, 0, 0, false
+		}
+
+		// Chiều xuôi: vf[k] là x xa nhất trên đường chéo k = x - y sau d thao tác
+		for k := -d; k <= d; k += 2 {
+			if k == -d || (k != d && vf[off+k-1] < vf[off+k+1]) {
+				x = vf[off+k+1]
+			} else {
+				x = vf[off+k-1] + 1
+			}
+			y = x - k
+			u, v = x, y
+			for u < n && v < mm && a[u] == b[v] {
+				u++
+				v++
+			}
+			vf[off+k] = u
+			// Đường ngược tương ứng nằm trên đường chéo delta - k, tính từ cuối hai dãy
+			if odd && k >= delta-(d-1) && k <= delta+(d-1) && u+vb[off+delta-k] >= n {
+				return x, y, u, v, true
+			}
+		}
+
+		// Chiều ngược: vb[c] là số token đi được từ cuối trên đường chéo c của hai dãy đảo ngược
+		for c := -d; c <= d; c += 2 {
+			var bx int
+			if c == -d || (c != d && vb[off+c-1] < vb[off+c+1]) {
+				bx = vb[off+c+1]
+			} else {
+				bx = vb[off+c-1] + 1
+			}
+			by := bx - c
+			ex, ey := bx, by
+			for ex < n && ey < mm && a[n-1-ex] == b[mm-1-ey] {
+				ex++
+				ey++
+			}
+			vb[off+c] = ex
+			if k := delta - c; !odd && k >= -d && k <= d && vf[off+k]+ex >= n {
+				return n - ex, mm - ey, n - bx, mm - by, true
+			}
+		}
+	}
+	return 0, 0, 0, 0, false
+}
+
+func appendOp(ops []Op, kind string, tokens []string) []Op {
+	if len(tokens) == 0 {
+		return ops
+	}
+	return append(ops, Op{Op: kind, Text: strings.Join(tokens, "")})
+}
+
+// merge gộp các Op liền nhau cùng loại; trong một đoạn thay đổi, delete luôn đứng trước insert
+func merge(ops []Op) []Op {
+	var out []Op
+	for i := 0; i < len(ops); {
+		if ops[i].Op == OpEqual {
+			var text strings.Builder
+			for ; i < len(ops) && ops[i].Op == OpEqual; i++ {
+				text.WriteString(ops[i].Text)
+			}
+			out = append(out, Op{Op: OpEqual, Text: text.String()})
+			continue
+		}
+		var del, ins strings.Builder
+		for ; i < len(ops) && ops[i].Op != OpEqual; i++ {
+			if ops[i].Op == OpDelete {
+				del.WriteString(ops[i].Text)
+			} else {
+				ins.WriteString(ops[i].Text)
+			}
+		}
+		if del.Len() > 0 {
+			out = append(out, Op{Op: OpDelete, Text: del.String()})
+		}
+		if ins.Len() > 0 {
+			out = append(out, Op{Op: OpInsert, Text: ins.String()})
+		}
+	}
+	return out
+}
@@ -0,0 +1,172 @@
+package diff
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// rebuild ghép Text của các Op thuộc kinds
+func rebuild(ops []Op, kinds ...string) string {
+	var b strings.Builder
+	for _, op := range ops {
+		for _, kind := range kinds {
+			if op.Op == kind {
+				b.WriteString(op.Text)
+			}
+		}
+	}
+	return b.String()
+}
+
+// checkRebuild kiểm tra equal+delete cho ra văn bản cũ, equal+insert cho ra văn bản mới
+func checkRebuild(t *testing.T, ops []Op, a, b string) {
+	t.Helper()
+	if got := rebuild(ops, OpEqual, OpDelete); got != a {
+		t.Errorf("equal+delete = %q, muốn %q", got, a)
+	}
+	if got := rebuild(ops, OpEqual, OpInsert); got != b {
+		t.Errorf("equal+insert = %q, muốn %q", got, b)
+	}
+}
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []Op
+	}{
+		{"giống nhau", "a\nb\n", "a\nb\n", []Op{{OpEqual, "a\nb\n"}}},
+		{"cả hai rỗng", "", "", nil},
+		{"cũ rỗng", "", "a\nb", []Op{{OpInsert, "a\nb"}}},
+		{"mới rỗng", "a\nb", "", []Op{{OpDelete, "a\nb"}}},
+		{"sửa một dòng", "a\nb\nc\n", "a\nx\nc\n", []Op{{OpEqual, "a\n"}, {OpDelete, "b\n"}, {OpInsert, "x\n"}, {OpEqual, "c\n"}}},
+		{"thêm dòng cuối không có xuống dòng", "a\n", "a\nb", []Op{{OpEqual, "a\n"}, {OpInsert, "b"}}},
+		{"xóa dòng đầu", "a\nb\nc\n", "b\nc\n", []Op{{OpDelete, "a\n"}, {OpEqual, "b\nc\n"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := Lines(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("Lines: %v", err)
+			}
+			checkRebuild(t, ops, tt.a, tt.b)
+			if !equalOps(ops, tt.want) {
+				t.Errorf("ops = %q, muốn %q", ops, tt.want)
+			}
+		})
+	}
+}
+
+func TestWords(t *testing.T) {
+	a := "Trời hôm nay đẹp quá, đi chơi thôi!"
+	b := "Trời hôm qua đẹp lắm, ở nhà thôi!"
+	ops, err := Words(a, b)
+	if err != nil {
+		t.Fatalf("Words: %v", err)
+	}
+	checkRebuild(t, ops, a, b)
+
+	insertions, deletions := Stats(ops, SplitWords)
+	// nay→qua, quá→lắm, đi→ở, chơi→nhà; khoảng trắng giữa "đi chơi" và "ở nhà" được giữ nguyên
+	if insertions != 4 || deletions != 4 {
+		t.Errorf("Stats = +%d -%d, muốn +4 -4", insertions, deletions)
+	}
+}
+
+func TestSplitWords(t *testing.T) {
+	got := SplitWords("Xin chào,  thế giới!\n")
+	want := []string{"Xin", " ", "chào", ",", "  ", "thế", " ", "giới", "!", "\n"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("SplitWords = %q, muốn %q", got, want)
+	}
+}
+
+func TestTokensMinimal(t *testing.T) {
+	// Số token giữ nguyên phải bằng độ dài dãy con chung dài nhất
+	tests := []struct{ a, b string }{
+		{"abcabba", "cbabac"},
+		{"xaxbxcx", "abc"},
+		{"abcdef", "fedcba"},
+		{"aaaa", "aa"},
+	}
+	for _, tt := range tests {
+		a, b := strings.Split(tt.a, ""), strings.Split(tt.b, "")
+		ops, err := Tokens(a, b)
+		if err != nil {
+			t.Fatalf("Tokens: %v", err)
+		}
+		checkRebuild(t, ops, tt.a, tt.b)
+		if got, want := len(rebuild(ops, OpEqual)), lcsLength(a, b); got != want {
+			t.Errorf("Tokens(%q, %q) giữ %d token, muốn %d", tt.a, tt.b, got, want)
+		}
+	}
+}
+
+func TestEditDistanceLimit(t *testing.T) {
+	// Mọi dòng đều khác nhau: số thao tác vượt maxEditDistance nên trả về thay thế toàn bộ
+	var a, b strings.Builder
+	for i := 0; i < maxEditDistance; i++ {
+		a.WriteString("a" + strconv.Itoa(i) + "\n")
+		b.WriteString("b" + strconv.Itoa(i) + "\n")
+	}
+	ops, err := Lines(a.String(), b.String())
+	if err != nil {
+		t.Fatalf("Lines: %v", err)
+	}
+	want := []Op{{OpDelete, a.String()}, {OpInsert, b.String()}}
+	if !equalOps(ops, want) {
+		t.Errorf("có %d op, muốn thay thế toàn bộ", len(ops))
+	}
+
+	// Dưới giới hạn thì vẫn giữ được các dòng giống nhau xen giữa
+	c := strings.Replace(a.String(), "a1\n", "x1\n", 1)
+	ops, err = Lines(a.String(), c)
+	if err != nil {
+		t.Fatalf("Lines: %v", err)
+	}
+	checkRebuild(t, ops, a.String(), c)
+	if len(ops) != 4 {
+		t.Errorf("có %d op, muốn 4", len(ops))
+	}
+}
+
+func TestTooLarge(t *testing.T) {
+	a := strings.Repeat("x\n", MaxTokens/2+1)
+	if _, err := Lines(a, a); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("err = %v, muốn ErrTooLarge", err)
+	}
+}
+
+func equalOps(a, b []Op) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// lcsLength tính độ dài dãy con chung dài nhất bằng quy hoạch động
+func lcsLength(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	for i := range a {
+		cur := make([]int, len(b)+1)
+		for j := range b {
+			switch {
+			case a[i] == b[j]:
+				cur[j+1] = prev[j] + 1
+			case prev[j+1] > cur[j]:
+				cur[j+1] = prev[j+1]
+			default:
+				cur[j+1] = cur[j]
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,98 @@
+package entitlement
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxCacheEntries là ngưỡng bắt đầu dọn các entry đã hết hạn khỏi cache
+const maxCacheEntries = 10000
+
+type cacheKey struct {
+	userID, bookID, chapterID uint
+}
+
+type cacheEntry struct {
+	allowed   bool
+	expiresAt time.Time
+}
+
+// CachedChecker bọc một Checker khác và nhớ kết quả trong thời gian ngắn để mỗi lần mở
+// chương không phải gọi sang payment-service. Kết quả "chưa mua" được nhớ ngắn hơn
+// (tối đa 5 giây) để người vừa mua xong đọc được ngay. Lỗi không được cache.
+type CachedChecker struct {
+	next     Checker
+	allowTTL time.Duration
+	denyTTL  time.Duration
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+	now     func() time.Time
+}
+
+// NewCachedChecker tạo CachedChecker với thời gian nhớ ttl; ttl = 0 tắt cache
+func NewCachedChecker(next Checker, ttl time.Duration) *CachedChecker {
+	denyTTL := ttl
+	if denyTTL > 5*time.Second {
+		denyTTL = 5 * time.Second
+	}
+	return &CachedChecker{
+		next:     next,
+		allowTTL: ttl,
+		denyTTL:  denyTTL,
+		entries:  make(map[cacheKey]cacheEntry),
+		now:      time.Now,
+	}
+}
+
+// CanRead trả kết quả trong cache nếu còn hạn, ngược lại hỏi Checker bên trong
+func (c *CachedChecker) CanRead(ctx context.Context, req Request) (bool, error) {
+	key := cacheKey{userID: req.UserID, bookID: req.BookID, chapterID: req.ChapterID}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expiresAt) {
+		return entry.allowed, nil
+	}
+
+	allowed, err := c.next.CanRead(ctx, req)
+	if err != nil {
+		return false, err
+	}
+
+	ttl := c.denyTTL
+	if allowed {
+		ttl = c.allowTTL
+	}
+	if ttl > 0 {
+		c.store(key, cacheEntry{allowed: allowed, expiresAt: c.now().Add(ttl)})
+	}
+	return allowed, nil
+}
+
+// Invalidate xóa kết quả đã nhớ của user cho truyện (mọi chương), dùng khi biết user vừa mua
+func (c *CachedChecker) Invalidate(userID, bookID uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if key.userID == userID && key.bookID == bookID {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *CachedChecker) store(key cacheKey, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxCacheEntries {
+		now := c.now()
+		for k, e := range c.entries {
+			if !now.Before(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	c.entries[key] = entry
+}
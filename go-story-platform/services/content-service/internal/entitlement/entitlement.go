@@ -0,0 +1,107 @@
+// Package entitlement hỏi payment-service xem người đọc có quyền đọc chương premium không.
+// Handler chỉ phụ thuộc vào interface Checker nên có thể thay bằng Fake khi kiểm thử.
+package entitlement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"shared/logging"
+	"shared/rpc"
+)
+
+// Request mô tả câu hỏi "user UserID có được đọc chương ChapterID của truyện BookID không?".
+// AccessToken là token của chính người đọc, được chuyển tiếp sang payment-service.
+type Request struct {
+	UserID      uint
+	BookID      uint
+	ChapterID   uint
+	AccessToken string
+}
+
+// Checker là interface kiểm tra quyền đọc
+type Checker interface {
+	CanRead(ctx context.Context, req Request) (bool, error)
+}
+
+// HTTPChecker gọi GET /entitlements của payment-service
+type HTTPChecker struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPChecker tạo HTTPChecker trỏ tới baseURL của payment-service
+func NewHTTPChecker(baseURL string) *HTTPChecker {
+	return &HTTPChecker{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: 3 * time.Second},
+	}
+}
+
+// Config là cách kết nối tới payment-service. Có PAYMENT_GRPC_ADDR thì hỏi qua gRPC nội bộ,
+// ngược lại gọi HTTP API tại PAYMENT_SERVICE_URL. Kết quả được cache trong ENTITLEMENT_CACHE_TTL.
+type Config struct {
+	ServiceURL string        `env:"PAYMENT_SERVICE_URL" default:"http://localhost:8082"`
+	GRPCAddr   string        `env:"PAYMENT_GRPC_ADDR"`
+	CacheTTL   time.Duration `env:"ENTITLEMENT_CACHE_TTL" default:"30s"`
+}
+
+// New tạo CachedChecker theo cfg, tls là chứng chỉ dùng khi gọi gRPC
+func New(cfg Config, tls rpc.TLSConfig) (*CachedChecker, error) {
+	if cfg.GRPCAddr != "" {
+		conn, err := rpc.Dial(cfg.GRPCAddr, tls)
+		if err != nil {
+			return nil, err
+		}
+		return NewCachedChecker(NewGRPCChecker(conn), cfg.CacheTTL), nil
+	}
+	return NewCachedChecker(NewHTTPChecker(cfg.ServiceURL), cfg.CacheTTL), nil
+}
+
+// CanRead hỏi payment-service và trả về trường allowed trong response
+func (c *HTTPChecker) CanRead(ctx context.Context, req Request) (bool, error) {
+	query := url.Values{}
+	query.Set("book_id", strconv.FormatUint(uint64(req.BookID), 10))
+	if req.ChapterID != 0 {
+		query.Set("chapter_id", strconv.FormatUint(uint64(req.ChapterID), 10))
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/entitlements?"+query.Encode(), nil)
+	if err != nil {
+		return false, err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+req.AccessToken)
+	httpReq.Header.Set(logging.HeaderRequestID, logging.RequestID(ctx))
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("payment-service trả về mã %d", resp.StatusCode)
+	}
+
+	body := struct {
+		Data struct {
+			UserID  uint `json:"user_id"`
+			Allowed bool `json:"allowed"`
+		} `json:"data"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return false, err
+	}
+	// Token phải thuộc đúng user đang hỏi, nếu không thì kết quả không dùng được
+	if body.Data.UserID != req.UserID {
+		return false, errors.New("payment-service trả về quyền của user khác")
+	}
+	return body.Data.Allowed, nil
+}
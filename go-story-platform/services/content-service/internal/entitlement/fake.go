@@ -0,0 +1,48 @@
+package entitlement
+
+import (
+	"context"
+	"sync"
+)
+
+// Fake là Checker trong bộ nhớ cho kiểm thử và chạy content-service không cần payment-service.
+// Quyền được cấp qua Allow; đặt Err để giả lập payment-service lỗi.
+type Fake struct {
+	mu      sync.Mutex
+	allowed map[cacheKey]bool
+	calls   int
+	Err     error
+}
+
+// NewFake tạo Fake chưa cấp quyền cho ai
+func NewFake() *Fake {
+	return &Fake{allowed: make(map[cacheKey]bool)}
+}
+
+// Allow cấp quyền đọc; chapterID = 0 nghĩa là mua trọn bộ, mở mọi chương của truyện
+func (f *Fake) Allow(userID, bookID, chapterID uint) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.allowed[cacheKey{userID: userID, bookID: bookID, chapterID: chapterID}] = true
+}
+
+// Calls trả về số lần CanRead đã được gọi
+func (f *Fake) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+// CanRead theo cùng quy tắc với payment-service: mua trọn bộ hoặc mua lẻ đúng chương
+func (f *Fake) CanRead(_ context.Context, req Request) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.Err != nil {
+		return false, f.Err
+	}
+	if f.allowed[cacheKey{userID: req.UserID, bookID: req.BookID}] {
+		return true, nil
+	}
+	return req.ChapterID != 0 && f.allowed[cacheKey{userID: req.UserID, bookID: req.BookID, chapterID: req.ChapterID}], nil
+}
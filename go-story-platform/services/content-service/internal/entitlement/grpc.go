@@ -0,0 +1,34 @@
+package entitlement
+
+import (
+	"context"
+
+	"shared/rpc/paymentpb"
+
+	"google.golang.org/grpc"
+)
+
+// GRPCChecker hỏi PaymentService.CheckEntitlement qua gRPC nội bộ. Không chuyển tiếp token của
+// người đọc: payment-service chỉ nhận kết nối gRPC có chứng chỉ client do CA nội bộ cấp (rpc.TLSConfig
+// bắt buộc mTLS), nên chỉ các service nội bộ mới hỏi được quyền đọc thay cho user.
+type GRPCChecker struct {
+	client paymentpb.PaymentServiceClient
+}
+
+// NewGRPCChecker tạo GRPCChecker trên kết nối tới payment-service
+func NewGRPCChecker(conn grpc.ClientConnInterface) *GRPCChecker {
+	return &GRPCChecker{client: paymentpb.NewPaymentServiceClient(conn)}
+}
+
+// CanRead trả về trường allowed của PaymentService.CheckEntitlement
+func (c *GRPCChecker) CanRead(ctx context.Context, req Request) (bool, error) {
+	resp, err := c.client.CheckEntitlement(ctx, &paymentpb.CheckEntitlementRequest{
+		UserId:    uint64(req.UserID),
+		BookId:    uint64(req.BookID),
+		ChapterId: uint64(req.ChapterID),
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.GetAllowed(), nil
+}
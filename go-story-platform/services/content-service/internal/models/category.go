@@ -8,9 +8,11 @@ import (
 
 type Category struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
-	Name      string         `gorm:"unique;not null" json:"name"`
-	Slug      string         `gorm:"unique;index" json:"slug"`
-	Books     []Book         `gorm:"many2many:CategoryID;" json:"books"` // Quan hệ nhiều-nhiều với Book
+	ParentID  *uint          `gorm:"index" json:"parent_id"` // nil = thể loại gốc
+	Parent    *Category      `gorm:"foreignKey:ParentID" json:"parent,omitempty"`
+	Name      string         `gorm:"not null;uniqueIndex:idx_categories_name_active,where:deleted_at IS NULL" json:"name"`
+	Slug      string         `gorm:"uniqueIndex:idx_categories_slug_active,where:deleted_at IS NULL" json:"slug"`
+	Books     []Book         `gorm:"many2many:book_categories;" json:"books"` // Quan hệ nhiều-nhiều với Book
 	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
@@ -7,11 +7,20 @@ import (
 )
 
 type Chapter struct {
-	ID            uint           `gorm:"primaryKey" json:"id"`
-	BookID        uint           `gorm:"index" json:"book_id"`
-	ChapterNumber int            `gorm:"not null" json:"chapter_number"`
-	ContenrURL    string         `gorm:"not null" json:"content_url"` // Link S3/IPFS
-	CreatedAt     time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt     time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+	ID            uint   `gorm:"primaryKey" json:"id"`
+	BookID        uint   `gorm:"index;uniqueIndex:idx_chapters_book_number_active,where:deleted_at IS NULL" json:"book_id"`
+	ChapterNumber int    `gorm:"not null;uniqueIndex:idx_chapters_book_number_active,where:deleted_at IS NULL" json:"chapter_number"`
+	ContentURL    string `gorm:"not null;default:''" json:"content_url"`                            // Link S3/IPFS; nội dung tải lên có dạng ipfs://<cid>
+	ContentCID    string `gorm:"column:content_cid;size:64;not null;default:''" json:"content_cid"` // IPFS CID của nội dung tải lên, cũng là khóa trong BlobStore
+	ContentSize   int64  `gorm:"not null;default:0" json:"content_size"`
+	ContentType   string `gorm:"size:100;not null;default:''" json:"content_type"`
+	Price         int    `gorm:"not null;default:0" json:"price"` // Giá mua lẻ chương (xu), 0 = không bán lẻ
+	Publication
+	// Revision mới nhất (bản nháp của tác giả) và revision đang xuất bản cho người đọc, 0 = chưa có.
+	// Các cột Content* ở trên luôn là bản sao nội dung của revision đang xuất bản.
+	LatestRevision    int            `gorm:"not null;default:0" json:"latest_revision"`
+	PublishedRevision int            `gorm:"not null;default:0" json:"published_revision"`
+	CreatedAt         time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
 }
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// ChapterRevision là một phiên bản nội dung của chương, không bao giờ bị sửa hay xóa.
+// Mỗi lần đổi nội dung (tải lên, đổi link, khôi phục) tạo một revision mới với số tăng dần.
+type ChapterRevision struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	ChapterID   uint      `gorm:"not null;uniqueIndex:idx_chapter_revisions_chapter_revision" json:"chapter_id"`
+	Revision    int       `gorm:"not null;uniqueIndex:idx_chapter_revisions_chapter_revision" json:"revision"` // Số thứ tự revision trong chương, bắt đầu từ 1
+	AuthorID    uint      `gorm:"not null" json:"author_id"`                                                   // Người tạo revision
+	ContentURL  string    `gorm:"not null" json:"content_url"`
+	ContentCID  string    `gorm:"column:content_cid;size:64;not null;default:''" json:"content_cid"` // Hash nội dung (IPFS CID), rỗng nếu chỉ là link ngoài
+	ContentSize int64     `gorm:"not null;default:0" json:"content_size"`
+	ContentType string    `gorm:"size:100;not null;default:''" json:"content_type"`
+	ChangeNote  string    `gorm:"size:500;not null;default:''" json:"change_note"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
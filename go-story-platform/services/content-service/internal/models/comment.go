@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// ChapterComment là bình luận của người đọc trong một chương, trả lời nhau theo ParentID
+type ChapterComment struct {
+	ID         uint   `gorm:"primaryKey" json:"id"`
+	ChapterID  uint   `gorm:"not null" json:"chapter_id"`
+	BookID     uint   `gorm:"not null" json:"book_id"`
+	UserID     uint   `gorm:"not null" json:"user_id"`         // ID từ User Service (Soft link)
+	ParentID   *uint  `json:"parent_id"`                       // nil = bình luận gốc
+	Depth      int    `gorm:"not null;default:0" json:"depth"` // 0 = bình luận gốc
+	Body       string `gorm:"type:text;not null;default:''" json:"body"`
+	Spoiler    bool   `gorm:"not null;default:false" json:"spoiler"`
+	ReplyCount int64  `gorm:"not null;default:0" json:"reply_count"` // Số trả lời trực tiếp, kể cả đã xóa
+	Moderation
+	EditedAt  *time.Time `json:"edited_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
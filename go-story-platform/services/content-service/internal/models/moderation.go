@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Trạng thái hiển thị của đánh giá và bình luận
+const (
+	VisibilityVisible = "visible" // Mọi người đều thấy
+	VisibilityHidden  = "hidden"  // Bị admin ẩn, chỉ người viết và admin thấy nội dung
+	VisibilityDeleted = "deleted" // Người viết đã xóa bình luận, giữ lại để không làm gãy luồng trả lời
+)
+
+// Moderation là các cột kiểm duyệt dùng chung cho BookReview và ChapterComment
+type Moderation struct {
+	Status           string     `gorm:"size:20;not null;default:visible" json:"status"`
+	ModeratedBy      *uint      `json:"moderated_by"` // Admin ẩn/hiện lần gần nhất
+	ModeratedAt      *time.Time `json:"moderated_at"`
+	ModerationReason string     `gorm:"not null;default:''" json:"moderation_reason"`
+}
+
+// IsVisible cho biết nội dung có hiển thị cho mọi người không
+func (m Moderation) IsVisible() bool {
+	return m.Status == VisibilityVisible
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Trạng thái xuất bản của Book và Chapter
+const (
+	StatusDraft     = "draft"      // Chỉ tác giả/admin thấy
+	StatusScheduled = "scheduled"  // Tự động xuất bản khi tới PublishAt
+	StatusPublished = "published"  // Hiển thị cho mọi người đọc
+	StatusUnlisted  = "unlisted"   // Đọc được qua link nhưng không xuất hiện trong danh sách/tìm kiếm
+	StatusTakenDown = "taken_down" // Bị admin gỡ, chỉ admin khôi phục được
+)
+
+// Publication là các cột trạng thái xuất bản dùng chung cho Book và Chapter
+type Publication struct {
+	Status      string     `gorm:"size:20;not null;default:draft;index" json:"status"`
+	PublishAt   *time.Time `json:"publish_at"`   // Thời điểm hẹn xuất bản khi Status = scheduled
+	PublishedAt *time.Time `json:"published_at"` // Lần đầu được xuất bản
+}
+
+// IsVisible cho biết người đọc có truy cập được trực tiếp (published hoặc unlisted) không
+func (p Publication) IsVisible() bool {
+	return p.Status == StatusPublished || p.Status == StatusUnlisted
+}
+
+// IsListed cho biết nội dung có xuất hiện trong danh sách cho người đọc không
+func (p Publication) IsListed() bool {
+	return p.Status == StatusPublished
+}
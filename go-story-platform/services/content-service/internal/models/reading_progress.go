@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// ReadingProgress là vị trí đọc của một người đọc trong một chương
+type ReadingProgress struct {
+	UserID     uint    `gorm:"primaryKey;autoIncrement:false" json:"user_id"` // ID từ User Service (Soft link)
+	ChapterID  uint    `gorm:"primaryKey;autoIncrement:false" json:"chapter_id"`
+	BookID     uint    `gorm:"not null;index" json:"book_id"`
+	Position   int64   `gorm:"not null;default:0" json:"position"`   // Vị trí trong chương do client định nghĩa (vd. offset ký tự)
+	Percentage float64 `gorm:"not null;default:0" json:"percentage"` // Phần trăm đã đọc của chương, 0-100
+	Completed  bool    `gorm:"not null;default:false" json:"completed"`
+	// ClientUpdatedAt là thời điểm thiết bị ghi tiến độ, bản ghi có ClientUpdatedAt mới hơn thắng khi đồng bộ
+	ClientUpdatedAt time.Time `gorm:"not null" json:"client_updated_at"`
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	// Version do DB cấp (sequence) mỗi lần dòng được ghi, là con trỏ khi thiết bị kéo thay đổi về
+	Version int64 `gorm:"->" json:"version"`
+}
+
+// TableName giữ tên bảng số ít như trong migration
+func (ReadingProgress) TableName() string {
+	return "reading_progress"
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BookReview là đánh giá của một người đọc cho một truyện, mỗi người một đánh giá
+type BookReview struct {
+	ID      uint   `gorm:"primaryKey" json:"id"`
+	BookID  uint   `gorm:"not null;index" json:"book_id"`
+	UserID  uint   `gorm:"not null" json:"user_id"`                   // ID từ User Service (Soft link)
+	Rating  int    `gorm:"not null" json:"rating"`                    // 1-5 sao
+	Body    string `gorm:"type:text;not null;default:''" json:"body"` // Nhận xét, có thể rỗng
+	Spoiler bool   `gorm:"not null;default:false" json:"spoiler"`
+	Moderation
+	EditedAt  *time.Time     `json:"edited_at"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// BookRatingStats là điểm tổng hợp của truyện trên các đánh giá đang hiển thị
+type BookRatingStats struct {
+	BookID      uint      `gorm:"primaryKey;autoIncrement:false" json:"book_id"`
+	RatingCount int64     `gorm:"not null;default:0" json:"rating_count"`
+	RatingSum   int64     `gorm:"not null;default:0" json:"rating_sum"`
+	Count1      int64     `gorm:"column:count_1;not null;default:0" json:"count_1"`
+	Count2      int64     `gorm:"column:count_2;not null;default:0" json:"count_2"`
+	Count3      int64     `gorm:"column:count_3;not null;default:0" json:"count_3"`
+	Count4      int64     `gorm:"column:count_4;not null;default:0" json:"count_4"`
+	Count5      int64     `gorm:"column:count_5;not null;default:0" json:"count_5"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName giữ tên bảng như trong migration
+func (BookRatingStats) TableName() string {
+	return "book_rating_stats"
+}
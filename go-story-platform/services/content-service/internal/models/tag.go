@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Tag là thẻ tự do tác giả gắn cho truyện, dùng chung giữa các truyện theo Slug
+type Tag struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"not null" json:"name"`
+	Slug      string    `gorm:"not null;uniqueIndex" json:"slug"`
+	Books     []Book    `gorm:"many2many:book_tags;" json:"books"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,104 @@
+// Package publishing quản lý vòng đời xuất bản của truyện và chương:
+// draft → scheduled → published ⇄ unlisted, và taken_down do admin gỡ.
+package publishing
+
+import (
+	"content-service/internal/models"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidStatus        = errors.New("trạng thái không hợp lệ")
+	ErrInvalidTransition    = errors.New("không thể chuyển sang trạng thái này")
+	ErrModeratorOnly        = errors.New("chỉ admin được gỡ hoặc khôi phục nội dung bị gỡ")
+	ErrPublishAtRequired    = errors.New("cần publish_at ở tương lai để hẹn giờ xuất bản")
+	ErrBookPriceRequired    = errors.New("truyện premium phải có giá trước khi xuất bản")
+	ErrChapterPriceRequired = errors.New("chương của truyện premium phải có giá trước khi xuất bản")
+	ErrChapterNoContent     = errors.New("chương chưa có nội dung để xuất bản")
+)
+
+// transitions liệt kê các trạng thái đích hợp lệ từ mỗi trạng thái (trừ taken_down do admin quyết định)
+var transitions = map[string][]string{
+	models.StatusDraft:     {models.StatusScheduled, models.StatusPublished, models.StatusUnlisted},
+	models.StatusScheduled: {models.StatusDraft, models.StatusScheduled, models.StatusPublished, models.StatusUnlisted},
+	models.StatusPublished: {models.StatusDraft, models.StatusUnlisted},
+	models.StatusUnlisted:  {models.StatusDraft, models.StatusPublished},
+	models.StatusTakenDown: {models.StatusDraft},
+}
+
+// CheckTransition kiểm tra có được chuyển từ from sang to không. Chỉ moderator được
+// gỡ nội dung (taken_down) và đưa nội dung bị gỡ về draft.
+func CheckTransition(from, to string, moderator bool) error {
+	if _, ok := transitions[to]; !ok {
+		return ErrInvalidStatus
+	}
+	if to == models.StatusTakenDown || from == models.StatusTakenDown {
+		if !moderator {
+			return ErrModeratorOnly
+		}
+		if to == models.StatusTakenDown && from != models.StatusTakenDown {
+			return nil
+		}
+	}
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return ErrInvalidTransition
+}
+
+// CheckBook kiểm tra truyện đủ điều kiện để người đọc nhìn thấy
+func CheckBook(book *models.Book) error {
+	if book.IsPremium && book.Price <= 0 {
+		return ErrBookPriceRequired
+	}
+	return nil
+}
+
+// CheckChapter kiểm tra chương đủ điều kiện để người đọc nhìn thấy: phải có revision đã xuất bản,
+// và chương của truyện premium phải có giá bán lẻ
+func CheckChapter(book *models.Book, chapter *models.Chapter) error {
+	if chapter.PublishedRevision == 0 {
+		return ErrChapterNoContent
+	}
+	if book.IsPremium && chapter.Price <= 0 {
+		return ErrChapterPriceRequired
+	}
+	return nil
+}
+
+// Apply chuyển p sang trạng thái to. publishAt chỉ dùng cho scheduled và phải ở sau now.
+// Trả về true nếu đây là lần đầu nội dung hiển thị cho người đọc.
+func Apply(p *models.Publication, to string, publishAt *time.Time, now time.Time) (bool, error) {
+	p.PublishAt = nil
+	if to == models.StatusScheduled {
+		if publishAt == nil || !publishAt.After(now) {
+			return false, ErrPublishAtRequired
+		}
+		at := publishAt.UTC()
+		p.PublishAt = &at
+	}
+
+	p.Status = to
+	if p.IsVisible() && p.PublishedAt == nil {
+		at := now.UTC()
+		p.PublishedAt = &at
+		return true, nil
+	}
+	return false, nil
+}
+
+// IsValidationError cho biết err là lỗi do dữ liệu/trạng thái (trả 4xx cho client) chứ không phải lỗi hệ thống
+func IsValidationError(err error) bool {
+	for _, target := range []error{
+		ErrInvalidStatus, ErrInvalidTransition, ErrPublishAtRequired,
+		ErrBookPriceRequired, ErrChapterPriceRequired, ErrChapterNoContent,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
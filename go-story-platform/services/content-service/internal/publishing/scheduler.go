@@ -0,0 +1,109 @@
+package publishing
+
+import (
+	"content-service/internal/models"
+	"content-service/internal/repository"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// batchSize là số truyện/chương tối đa được xuất bản trong một lần quét
+const batchSize = 100
+
+// Scheduler định kỳ xuất bản các truyện và chương có trạng thái scheduled đã tới PublishAt.
+// Chạy nhiều instance cùng lúc vẫn an toàn vì mỗi dòng được khóa khi xuất bản.
+type Scheduler struct {
+	books    *repository.BookRepository
+	chapters *repository.ChapterRepository
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewScheduler tạo Scheduler quét mỗi interval
+func NewScheduler(books *repository.BookRepository, chapters *repository.ChapterRepository, interval time.Duration) *Scheduler {
+	return &Scheduler{books: books, chapters: chapters, interval: interval, now: time.Now}
+}
+
+// Run quét cho tới khi ctx bị hủy
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Sync(ctx); err != nil {
+			slog.Error("Lỗi khi xuất bản nội dung hẹn giờ", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync xuất bản một lượt các truyện rồi tới các chương đã tới giờ. Nội dung không còn đủ
+// điều kiện (ví dụ truyện premium bị bỏ giá sau khi hẹn) được đưa về nháp.
+func (s *Scheduler) Sync(ctx context.Context) error {
+	now := s.now()
+
+	bookIDs, err := s.books.ListDueBooks(now, batchSize)
+	if err != nil {
+		return fmt.Errorf("lấy truyện tới giờ xuất bản: %w", err)
+	}
+	for _, id := range bookIDs {
+		if ctx.Err() != nil {
+			return nil
+		}
+		published, err := s.books.PublishScheduled(id, now, func(book *models.Book) (bool, error) {
+			if err := CheckBook(book); err != nil {
+				return false, err
+			}
+			return Apply(&book.Publication, models.StatusPublished, nil, now)
+		})
+		switch {
+		case IsValidationError(err):
+			slog.Warn("Truyện không đủ điều kiện xuất bản, chuyển về nháp", "book_id", id, "error", err)
+		case err != nil:
+			return fmt.Errorf("xuất bản truyện %d: %w", id, err)
+		case published:
+			slog.Info("Đã xuất bản truyện theo lịch", "book_id", id)
+		}
+	}
+
+	due, err := s.chapters.ListDueChapters(now, batchSize)
+	if err != nil {
+		return fmt.Errorf("lấy chương tới giờ xuất bản: %w", err)
+	}
+	for _, item := range due {
+		if ctx.Err() != nil {
+			return nil
+		}
+		book, err := s.books.GetBookByID(item.BookID)
+		if err != nil {
+			return fmt.Errorf("lấy truyện %d: %w", item.BookID, err)
+		}
+		if book == nil {
+			// Truyện vừa bị xóa sau khi lấy danh sách, lượt sau ListDueChapters sẽ bỏ qua chương này
+			continue
+		}
+
+		published, err := s.chapters.PublishScheduled(item.ID, now, func(chapter *models.Chapter) error {
+			if err := CheckChapter(book, chapter); err != nil {
+				return err
+			}
+			_, err := Apply(&chapter.Publication, models.StatusPublished, nil, now)
+			return err
+		})
+		switch {
+		case IsValidationError(err):
+			slog.Warn("Chương không đủ điều kiện xuất bản, chuyển về nháp", "chapter_id", item.ID, "error", err)
+		case err != nil:
+			return fmt.Errorf("xuất bản chương %d: %w", item.ID, err)
+		case published:
+			slog.Info("Đã xuất bản chương theo lịch", "chapter_id", item.ID)
+		}
+	}
+	return nil
+}
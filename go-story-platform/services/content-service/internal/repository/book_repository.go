@@ -0,0 +1,207 @@
+// Package repository cho book, chapter và category trong content-service
+package repository
+
+import (
+	"content-service/internal/models"
+	"time"
+
+	"shared/events"
+	"shared/outbox"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BookFilter là các điều kiện lọc khi lấy danh sách truyện
+type BookFilter struct {
+	AuthorID   uint
+	CategoryID uint // Gồm cả truyện của các thể loại con cháu
+	TagSlug    string
+	Statuses   []string // Rỗng = mọi trạng thái
+	Page       int
+	Limit      int
+}
+
+// BookRepository giữ kết nối với db
+type BookRepository struct {
+	db *gorm.DB
+}
+
+// NewBookRepository dùng để tạo BookRepository và gắn kết nối DB vào nó
+func NewBookRepository(db *gorm.DB) *BookRepository {
+	return &BookRepository{db: db}
+}
+
+// CreateBook thêm truyện mới ở trạng thái nháp cùng thể loại và thẻ của nó.
+// Thẻ chưa có (theo slug) được tạo mới.
+func (r *BookRepository) CreateBook(book *models.Book) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(book).Error; err != nil {
+			return err
+		}
+		return replaceTaxonomy(tx, book)
+	})
+}
+
+// GetBookByID lấy truyện theo ID, trả về nil nếu không tồn tại
+func (r *BookRepository) GetBookByID(id uint) (*models.Book, error) {
+	var book models.Book
+
+	err := withTaxonomy(r.db).First(&book, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &book, nil
+}
+
+// GetBooksByIDs lấy các truyện theo danh sách ID kèm thể loại và thẻ, bỏ qua ID không tồn tại
+func (r *BookRepository) GetBooksByIDs(ids []uint) ([]models.Book, error) {
+	var books []models.Book
+	if len(ids) == 0 {
+		return books, nil
+	}
+	err := withTaxonomy(r.db).Where("id IN ?", ids).Find(&books).Error
+	return books, err
+}
+
+// ListBooks lấy danh sách truyện theo bộ lọc, phân trang theo page/limit, kèm tổng số bản ghi
+func (r *BookRepository) ListBooks(filter BookFilter) ([]models.Book, int64, error) {
+	query := r.db.Model(&models.Book{})
+	if filter.AuthorID != 0 {
+		query = query.Where("author_id = ?", filter.AuthorID)
+	}
+	if filter.CategoryID != 0 {
+		query = query.Where(inCategoryTreeSQL, filter.CategoryID)
+	}
+	if filter.TagSlug != "" {
+		query = query.Where("id IN (SELECT bt.book_id FROM book_tags bt JOIN tags t ON t.id = bt.tag_id WHERE t.slug = ?)", filter.TagSlug)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var books []models.Book
+	err := withTaxonomy(query).Order("id DESC").
+		Offset((filter.Page - 1) * filter.Limit).
+		Limit(filter.Limit).
+		Find(&books).Error
+	return books, total, err
+}
+
+// UpdateBook lưu toàn bộ thay đổi của truyện, thay thể loại và thẻ bằng book.Categories/book.Tags
+func (r *BookRepository) UpdateBook(book *models.Book) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(book).Error; err != nil {
+			return err
+		}
+		return replaceTaxonomy(tx, book)
+	})
+}
+
+// DeleteBook xóa mềm truyện cùng các chương của nó trong một transaction
+func (r *BookRepository) DeleteBook(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("book_id = ?", id).Delete(&models.Chapter{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Book{}, id).Error
+	})
+}
+
+// SavePublication lưu trạng thái xuất bản của truyện. Khi truyện lần đầu hiển thị cho người đọc
+// (firstVisible), event BookPublished được ghi vào outbox trong cùng transaction.
+func (r *BookRepository) SavePublication(book *models.Book, firstVisible bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return savePublication(tx, book, firstVisible)
+	})
+}
+
+// ListDueBooks lấy ID các truyện đã tới giờ hẹn xuất bản
+func (r *BookRepository) ListDueBooks(now time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.Book{}).
+		Where("status = ? AND publish_at <= ?", models.StatusScheduled, now).
+		Order("publish_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// PublishScheduled xuất bản truyện đang hẹn giờ nếu đã tới giờ. Dòng truyện được khóa nên chỉ
+// một instance xuất bản được. Nếu publish trả lỗi thì truyện được đưa về nháp và lỗi đó được trả về.
+func (r *BookRepository) PublishScheduled(id uint, now time.Time, publish func(*models.Book) (bool, error)) (bool, error) {
+	var published bool
+	var rejected error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var book models.Book
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND publish_at <= ?", models.StatusScheduled, now).
+			First(&book, id).Error
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		firstVisible, err := publish(&book)
+		if err != nil {
+			rejected = err
+			book.Status = models.StatusDraft
+			book.PublishAt = nil
+			return tx.Save(&book).Error
+		}
+		published = true
+		return savePublication(tx, &book, firstVisible)
+	})
+	if err != nil {
+		return false, err
+	}
+	return published, rejected
+}
+
+func savePublication(tx *gorm.DB, book *models.Book, firstVisible bool) error {
+	if err := tx.Omit(clause.Associations).Save(book).Error; err != nil {
+		return err
+	}
+	if !firstVisible {
+		return nil
+	}
+	return outbox.Enqueue(tx, events.BookPublished{
+		BookID:      book.ID,
+		AuthorID:    book.AuthorID,
+		Title:       book.Title,
+		IsPremium:   book.IsPremium,
+		Price:       book.Price,
+		PublishedAt: *book.PublishedAt,
+	})
+}
+
+// withTaxonomy nạp kèm thể loại (theo tên) và thẻ (theo slug) của truyện
+func withTaxonomy(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Categories", func(db *gorm.DB) *gorm.DB { return db.Order("name ASC") }).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB { return db.Order("slug ASC") })
+}
+
+// replaceTaxonomy thay thể loại và thẻ của truyện bằng book.Categories/book.Tags.
+// Thể loại phải đã tồn tại; thẻ chưa có được tạo mới.
+func replaceTaxonomy(tx *gorm.DB, book *models.Book) error {
+	if err := ensureTags(tx, book.Tags); err != nil {
+		return err
+	}
+	// Omit("X.*") chỉ ghi bảng nối, không ghi lại bản ghi thể loại/thẻ. Danh sách rỗng xóa hết liên kết.
+	if err := tx.Model(book).Omit("Categories.*").Association("Categories").Replace(book.Categories); err != nil {
+		return err
+	}
+	return tx.Model(book).Omit("Tags.*").Association("Tags").Replace(book.Tags)
+}
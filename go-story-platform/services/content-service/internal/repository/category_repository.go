@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"content-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// categorySubtreeSQL trả về ID của thể loại và mọi thể loại con cháu của nó. Tham số: ID thể loại gốc.
+const categorySubtreeSQL = `
+WITH RECURSIVE subtree AS (
+    SELECT id FROM categories WHERE id = ? AND deleted_at IS NULL
+    UNION
+    SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id WHERE c.deleted_at IS NULL
+)
+SELECT id FROM subtree`
+
+// inCategoryTreeSQL là điều kiện truyện (cột id) thuộc thể loại hoặc thể loại con cháu của nó
+const inCategoryTreeSQL = `id IN (SELECT book_id FROM book_categories WHERE category_id IN (` + categorySubtreeSQL + `))`
+
+// CategoryBookCount là số truyện đã xuất bản thuộc một thể loại, tính cả thể loại con cháu
+type CategoryBookCount struct {
+	CategoryID uint
+	Count      int64
+}
+
+// CategoryRepository giữ kết nối với db
+type CategoryRepository struct {
+	db *gorm.DB
+}
+
+// NewCategoryRepository dùng để tạo CategoryRepository và gắn kết nối DB vào nó
+func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+// CreateCategory thêm thể loại mới
+func (r *CategoryRepository) CreateCategory(category *models.Category) error {
+	return r.db.Omit(clause.Associations).Create(category).Error
+}
+
+// GetAllCategories lấy toàn bộ thể loại theo tên, kèm thể loại cha
+func (r *CategoryRepository) GetAllCategories() ([]models.Category, error) {
+	var categories []models.Category
+	err := r.db.Preload("Parent").Order("name ASC").Find(&categories).Error
+	return categories, err
+}
+
+// GetCategoryByID lấy thể loại theo ID kèm thể loại cha, trả về nil nếu không tồn tại
+func (r *CategoryRepository) GetCategoryByID(id uint) (*models.Category, error) {
+	var category models.Category
+
+	err := r.db.Preload("Parent").First(&category, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// GetCategoriesByIDs lấy các thể loại theo danh sách ID, bỏ qua ID không tồn tại
+func (r *CategoryRepository) GetCategoriesByIDs(ids []uint) ([]models.Category, error) {
+	var categories []models.Category
+	if len(ids) == 0 {
+		return categories, nil
+	}
+	err := r.db.Where("id IN ?", ids).Order("name ASC").Find(&categories).Error
+	return categories, err
+}
+
+// GetDescendantIDs lấy ID của thể loại cùng mọi thể loại con cháu của nó
+func (r *CategoryRepository) GetDescendantIDs(id uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Raw(categorySubtreeSQL, id).Scan(&ids).Error
+	return ids, err
+}
+
+// CountPublishedBooks đếm truyện đã xuất bản của từng thể loại, tính cả truyện của thể loại con cháu
+// (truyện thuộc nhiều nhánh con chỉ được đếm một lần). Thể loại không có truyện không có trong kết quả.
+func (r *CategoryRepository) CountPublishedBooks() ([]CategoryBookCount, error) {
+	var counts []CategoryBookCount
+	err := r.db.Raw(`
+        WITH RECURSIVE tree AS (
+            SELECT id, id AS root FROM categories WHERE deleted_at IS NULL
+            UNION
+            SELECT c.id, t.root FROM categories c JOIN tree t ON c.parent_id = t.id WHERE c.deleted_at IS NULL
+        )
+        SELECT t.root AS category_id, count(DISTINCT b.id) AS count
+        FROM tree t
+        JOIN book_categories bc ON bc.category_id = t.id
+        JOIN books b ON b.id = bc.book_id AND b.status = ? AND b.deleted_at IS NULL
+        GROUP BY t.root`, models.StatusPublished).
+		Scan(&counts).Error
+	return counts, err
+}
+
+// GetCategoryBySlug lấy thể loại theo slug, trả về nil nếu không tồn tại
+func (r *CategoryRepository) GetCategoryBySlug(slug string) (*models.Category, error) {
+	var category models.Category
+
+	err := r.db.Where("slug = ?", slug).First(&category).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// UpdateCategory lưu thay đổi của thể loại
+func (r *CategoryRepository) UpdateCategory(category *models.Category) error {
+	return r.db.Omit(clause.Associations).Save(category).Error
+}
+
+// DeleteCategory xóa mềm thể loại. Thể loại con được chuyển lên thể loại cha của nó và
+// các truyện được bỏ khỏi thể loại, trong cùng một transaction.
+func (r *CategoryRepository) DeleteCategory(category *models.Category) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Category{}).
+			Where("parent_id = ?", category.ID).
+			Update("parent_id", category.ParentID).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Exec(`DELETE FROM book_categories WHERE category_id = ?`, category.ID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Category{}, category.ID).Error
+	})
+}
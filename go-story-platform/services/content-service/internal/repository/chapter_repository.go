@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"content-service/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ChapterRepository giữ kết nối với db
+type ChapterRepository struct {
+	db *gorm.DB
+}
+
+// NewChapterRepository dùng để tạo ChapterRepository và gắn kết nối DB vào nó
+func NewChapterRepository(db *gorm.DB) *ChapterRepository {
+	return &ChapterRepository{db: db}
+}
+
+// CreateChapter thêm chương mới cho truyện
+func (r *ChapterRepository) CreateChapter(chapter *models.Chapter) error {
+	return r.db.Create(chapter).Error
+}
+
+// ListChaptersByBook lấy các chương của truyện theo thứ tự ChapterNumber tăng dần,
+// chỉ lấy các trạng thái trong statuses nếu được truyền vào
+func (r *ChapterRepository) ListChaptersByBook(bookID uint, statuses ...string) ([]models.Chapter, error) {
+	query := r.db.Where("book_id = ?", bookID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+
+	var chapters []models.Chapter
+	err := query.Order("chapter_number ASC").
+		Find(&chapters).Error
+	return chapters, err
+}
+
+// GetChapterByNumber lấy chương theo số thứ tự trong truyện, trả về nil nếu không tồn tại
+func (r *ChapterRepository) GetChapterByNumber(bookID uint, number int) (*models.Chapter, error) {
+	var chapter models.Chapter
+
+	err := r.db.Where("book_id = ? AND chapter_number = ?", bookID, number).
+		First(&chapter).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &chapter, nil
+}
+
+// GetVisibleChapter lấy chương người đọc truy cập được (cả truyện và chương đều đang hiển thị)
+// theo số thứ tự trong truyện, trả về nil nếu không có
+func (r *ChapterRepository) GetVisibleChapter(bookID uint, number int) (*models.Chapter, error) {
+	var chapter models.Chapter
+
+	err := r.db.Joins("JOIN books ON books.id = chapters.book_id AND books.deleted_at IS NULL AND books.status IN ?", visibleStatuses).
+		Where("chapters.book_id = ? AND chapters.chapter_number = ? AND chapters.status IN ?", bookID, number, visibleStatuses).
+		First(&chapter).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &chapter, nil
+}
+
+// GetVisibleChapterByID giống GetVisibleChapter nhưng tìm theo ID chương, trả về nil nếu không có
+func (r *ChapterRepository) GetVisibleChapterByID(bookID, chapterID uint) (*models.Chapter, error) {
+	var chapter models.Chapter
+
+	err := r.db.Joins("JOIN books ON books.id = chapters.book_id AND books.deleted_at IS NULL AND books.status IN ?", visibleStatuses).
+		Where("chapters.book_id = ? AND chapters.id = ? AND chapters.status IN ?", bookID, chapterID, visibleStatuses).
+		First(&chapter).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &chapter, nil
+}
+
+// UpdateChapter lưu thay đổi của chương, trừ số revision và các cột Content* vốn chỉ được ghi
+// qua AddRevision/PublishRevision, để bản chương tải trước đó không ghi đè revision mới xuất bản
+func (r *ChapterRepository) UpdateChapter(chapter *models.Chapter) error {
+	return r.db.Omit(revisionColumns...).Save(chapter).Error
+}
+
+// DeleteChapter xóa mềm chương theo ID
+func (r *ChapterRepository) DeleteChapter(id uint) error {
+	return r.db.Delete(&models.Chapter{}, id).Error
+}
+
+// ListDueChapters lấy các chương đã tới giờ hẹn xuất bản (chỉ có ID và BookID). Chương của truyện
+// đã bị xóa bị bỏ qua, để chúng không chiếm chỗ trong mỗi lượt quét và chặn các chương phía sau.
+func (r *ChapterRepository) ListDueChapters(now time.Time, limit int) ([]models.Chapter, error) {
+	var chapters []models.Chapter
+	err := r.db.Select("id", "book_id").
+		Where("status = ? AND publish_at <= ?", models.StatusScheduled, now).
+		Where("EXISTS (SELECT 1 FROM books b WHERE b.id = chapters.book_id AND b.deleted_at IS NULL)").
+		Order("publish_at ASC").
+		Limit(limit).
+		Find(&chapters).Error
+	return chapters, err
+}
+
+// PublishScheduled xuất bản chương đang hẹn giờ nếu đã tới giờ, tương tự BookRepository.PublishScheduled
+func (r *ChapterRepository) PublishScheduled(id uint, now time.Time, publish func(*models.Chapter) error) (bool, error) {
+	var published bool
+	var rejected error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var chapter models.Chapter
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND publish_at <= ?", models.StatusScheduled, now).
+			First(&chapter, id).Error
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := publish(&chapter); err != nil {
+			rejected = err
+			chapter.Status = models.StatusDraft
+			chapter.PublishAt = nil
+		} else {
+			published = true
+		}
+		return tx.Save(&chapter).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return published, rejected
+}
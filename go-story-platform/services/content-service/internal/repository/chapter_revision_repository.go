@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"content-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateChapterWithRevision thêm chương mới; nếu rev khác nil thì nội dung ban đầu được lưu
+// thành revision 1 và xuất bản ngay, trong cùng transaction
+func (r *ChapterRepository) CreateChapterWithRevision(chapter *models.Chapter, rev *models.ChapterRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(chapter).Error; err != nil {
+			return err
+		}
+		if rev == nil {
+			return nil
+		}
+		return addRevision(tx, chapter, rev, true)
+	})
+}
+
+// AddRevision lưu revision mới cho chương với số revision kế tiếp. Revision được xuất bản
+// nếu publish = true hoặc chương chưa có revision nào được xuất bản; ngược lại nó là bản nháp.
+func (r *ChapterRepository) AddRevision(chapter *models.Chapter, rev *models.ChapterRevision, publish bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return addRevision(tx, chapter, rev, publish)
+	})
+}
+
+// PublishRevision ghim revision làm nội dung người đọc nhìn thấy. Dòng chương được khóa và
+// chỉ các cột revision/nội dung được ghi, nên tên, giá hay trạng thái do request khác đổi
+// đồng thời không bị bản chương đã tải trước đó ghi đè.
+func (r *ChapterRepository) PublishRevision(chapter *models.Chapter, rev *models.ChapterRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		current, err := lockChapter(tx, chapter.ID)
+		if err != nil {
+			return err
+		}
+
+		chapter.LatestRevision = current.LatestRevision
+		applyPublishedRevision(chapter, rev)
+		return saveRevisionColumns(tx, chapter)
+	})
+}
+
+// ListRevisions lấy các revision của chương, mới nhất trước
+func (r *ChapterRepository) ListRevisions(chapterID uint) ([]models.ChapterRevision, error) {
+	var revisions []models.ChapterRevision
+	err := r.db.Where("chapter_id = ?", chapterID).
+		Order("revision DESC").
+		Find(&revisions).Error
+	return revisions, err
+}
+
+// GetRevision lấy revision theo số thứ tự trong chương, trả về nil nếu không tồn tại
+func (r *ChapterRepository) GetRevision(chapterID uint, revision int) (*models.ChapterRevision, error) {
+	var rev models.ChapterRevision
+
+	err := r.db.Where("chapter_id = ? AND revision = ?", chapterID, revision).
+		First(&rev).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &rev, nil
+}
+
+// addRevision khóa dòng chương để cấp số revision không trùng khi có nhiều request đồng thời
+func addRevision(tx *gorm.DB, chapter *models.Chapter, rev *models.ChapterRevision, publish bool) error {
+	current, err := lockChapter(tx, chapter.ID)
+	if err != nil {
+		return err
+	}
+
+	rev.ChapterID = chapter.ID
+	rev.Revision = current.LatestRevision + 1
+	if err := tx.Create(rev).Error; err != nil {
+		return err
+	}
+
+	chapter.LatestRevision = rev.Revision
+	chapter.PublishedRevision = current.PublishedRevision
+	if publish || chapter.PublishedRevision == 0 {
+		applyPublishedRevision(chapter, rev)
+	}
+	return saveRevisionColumns(tx, chapter)
+}
+
+// revisionColumns là các cột của chương chỉ được ghi khi thêm hoặc xuất bản revision
+var revisionColumns = []string{"latest_revision", "published_revision", "content_url", "content_cid", "content_size", "content_type"}
+
+// saveRevisionColumns chỉ ghi số revision và các cột Content* của chương, các cột khác giữ nguyên trong DB
+func saveRevisionColumns(tx *gorm.DB, chapter *models.Chapter) error {
+	return tx.Model(&models.Chapter{}).Where("id = ?", chapter.ID).
+		Updates(map[string]interface{}{
+			"latest_revision":    chapter.LatestRevision,
+			"published_revision": chapter.PublishedRevision,
+			"content_url":        chapter.ContentURL,
+			"content_cid":        chapter.ContentCID,
+			"content_size":       chapter.ContentSize,
+			"content_type":       chapter.ContentType,
+		}).Error
+}
+
+// lockChapter khóa dòng chương (SELECT ... FOR UPDATE) và đọc số revision hiện tại của nó
+func lockChapter(tx *gorm.DB, chapterID uint) (*models.Chapter, error) {
+	var current models.Chapter
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "latest_revision", "published_revision").
+		First(&current, chapterID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &current, nil
+}
+
+// applyPublishedRevision chép nội dung của revision vào các cột Content* của chương
+func applyPublishedRevision(chapter *models.Chapter, rev *models.ChapterRevision) {
+	chapter.PublishedRevision = rev.Revision
+	chapter.ContentURL = rev.ContentURL
+	chapter.ContentCID = rev.ContentCID
+	chapter.ContentSize = rev.ContentSize
+	chapter.ContentType = rev.ContentType
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"content-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CommentRepository giữ kết nối với db
+type CommentRepository struct {
+	db *gorm.DB
+}
+
+// NewCommentRepository dùng để tạo CommentRepository và gắn kết nối DB vào nó
+func NewCommentRepository(db *gorm.DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// CreateComment thêm bình luận; nếu là trả lời thì tăng reply_count của bình luận cha trong cùng transaction
+func (r *CommentRepository) CreateComment(comment *models.ChapterComment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(comment).Error; err != nil {
+			return err
+		}
+		if comment.ParentID == nil {
+			return nil
+		}
+		return tx.Model(&models.ChapterComment{}).
+			Where("id = ?", *comment.ParentID).
+			Update("reply_count", gorm.Expr("reply_count + 1")).Error
+	})
+}
+
+// GetCommentByID lấy bình luận theo ID, trả về nil nếu không tồn tại
+func (r *CommentRepository) GetCommentByID(id uint) (*models.ChapterComment, error) {
+	var comment models.ChapterComment
+
+	err := r.db.First(&comment, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// ListComments lấy các bình luận gốc của chương, mới nhất trước. before khác 0 thì chỉ lấy
+// bình luận có ID nhỏ hơn (con trỏ của trang trước).
+func (r *CommentRepository) ListComments(chapterID, before uint, limit int) ([]models.ChapterComment, error) {
+	query := r.db.Where("chapter_id = ? AND parent_id IS NULL", chapterID)
+	if before != 0 {
+		query = query.Where("id < ?", before)
+	}
+
+	var comments []models.ChapterComment
+	err := query.Order("id DESC").Limit(limit).Find(&comments).Error
+	return comments, err
+}
+
+// ListReplies lấy các trả lời trực tiếp của bình luận, cũ nhất trước để đọc theo mạch hội thoại.
+// after khác 0 thì chỉ lấy trả lời có ID lớn hơn.
+func (r *CommentRepository) ListReplies(parentID, after uint, limit int) ([]models.ChapterComment, error) {
+	query := r.db.Where("parent_id = ?", parentID)
+	if after != 0 {
+		query = query.Where("id > ?", after)
+	}
+
+	var comments []models.ChapterComment
+	err := query.Order("id ASC").Limit(limit).Find(&comments).Error
+	return comments, err
+}
+
+// UpdateComment lưu toàn bộ thay đổi của bình luận
+func (r *CommentRepository) UpdateComment(comment *models.ChapterComment) error {
+	return r.db.Save(comment).Error
+}
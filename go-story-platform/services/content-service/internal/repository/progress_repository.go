@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"content-service/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// visibleStatuses là các trạng thái người đọc truy cập được (xem models.Publication.IsVisible)
+var visibleStatuses = []string{models.StatusPublished, models.StatusUnlisted}
+
+// ProgressEntry là tiến độ của một chương kèm số thứ tự chương
+type ProgressEntry struct {
+	models.ReadingProgress
+	ChapterNumber int
+}
+
+// ShelfItem là một truyện trên kệ "đọc tiếp": chương đọc gần nhất và mức độ hoàn thành truyện
+type ShelfItem struct {
+	BookID            uint
+	ChapterID         uint
+	ChapterNumber     int
+	Position          int64
+	Percentage        float64
+	ClientUpdatedAt   time.Time
+	CompletedChapters int64
+	TotalChapters     int64
+}
+
+// ChapterReaders là số người đọc đã bắt đầu/đọc xong một chương
+type ChapterReaders struct {
+	ChapterNumber int
+	Started       int64
+	Completed     int64
+}
+
+// ReadingStats là thống kê hoàn thành của một truyện
+type ReadingStats struct {
+	Readers       int64 // Số người đã đọc ít nhất một chương
+	Finished      int64 // Số người đã đọc xong mọi chương đang xuất bản
+	TotalChapters int64
+	Chapters      []ChapterReaders
+}
+
+// ProgressRepository giữ kết nối với db
+type ProgressRepository struct {
+	db *gorm.DB
+}
+
+// NewProgressRepository dùng để tạo ProgressRepository và gắn kết nối DB vào nó
+func NewProgressRepository(db *gorm.DB) *ProgressRepository {
+	return &ProgressRepository{db: db}
+}
+
+// SaveProgress ghi tiến độ theo last-write-wins: chỉ ghi đè khi progress.ClientUpdatedAt mới hơn
+// bản đang lưu. Đã đọc xong chương thì giữ Completed kể cả khi bản ghi mới hơn chưa đọc xong
+// (đọc lại từ đầu). Sau khi gọi, progress là bản đang lưu; trả về false nếu bản gửi lên cũ hơn.
+func (r *ProgressRepository) SaveProgress(progress *models.ReadingProgress) (bool, error) {
+	var applied bool
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Ghi tuần tự theo user: version được cấp sau khi có khóa nên các lần ghi của một user commit
+		// theo đúng thứ tự version, thiết bị kéo theo version không bỏ sót lần ghi commit muộn
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext('reading_progress'), ?)", int32(progress.UserID)).Error; err != nil {
+			return err
+		}
+
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(progress)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 1 {
+			applied = true
+			return nil
+		}
+
+		var current models.ReadingProgress
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND chapter_id = ?", progress.UserID, progress.ChapterID).
+			First(&current).Error
+		if err != nil {
+			return err
+		}
+
+		completed := current.Completed || progress.Completed
+		if progress.ClientUpdatedAt.After(current.ClientUpdatedAt) {
+			applied = true
+			progress.Completed = completed
+			progress.CreatedAt = current.CreatedAt
+			return tx.Save(progress).Error
+		}
+
+		*progress = current
+		if completed == current.Completed {
+			return nil
+		}
+		progress.Completed = true
+		return tx.Save(progress).Error
+	})
+	return applied, err
+}
+
+// ListChanges lấy tiến độ của user có version lớn hơn after, theo thứ tự ghi, tối đa limit bản ghi
+func (r *ProgressRepository) ListChanges(userID uint, after int64, limit int) ([]ProgressEntry, error) {
+	var entries []ProgressEntry
+	err := r.db.Table("reading_progress rp").
+		Select("rp.*, c.chapter_number").
+		Joins("JOIN chapters c ON c.id = rp.chapter_id AND c.deleted_at IS NULL").
+		Where("rp.user_id = ? AND rp.version > ?", userID, after).
+		Order("rp.version ASC").
+		Limit(limit).
+		Scan(&entries).Error
+	return entries, err
+}
+
+// ListBookProgress lấy tiến độ của user ở các chương đang hiển thị của truyện, theo số thứ tự chương
+func (r *ProgressRepository) ListBookProgress(userID, bookID uint) ([]ProgressEntry, error) {
+	var entries []ProgressEntry
+	err := r.db.Table("reading_progress rp").
+		Select("rp.*, c.chapter_number").
+		Joins("JOIN chapters c ON c.id = rp.chapter_id AND c.deleted_at IS NULL AND c.status IN ?", visibleStatuses).
+		Where("rp.user_id = ? AND rp.book_id = ?", userID, bookID).
+		Order("c.chapter_number ASC").
+		Scan(&entries).Error
+	return entries, err
+}
+
+// CountVisibleChapters đếm số chương người đọc truy cập được của truyện
+func (r *ProgressRepository) CountVisibleChapters(bookID uint) (int64, error) {
+	var total int64
+	err := r.db.Model(&models.Chapter{}).
+		Where("book_id = ? AND status IN ?", bookID, visibleStatuses).
+		Count(&total).Error
+	return total, err
+}
+
+// shelfSQL lấy chương đọc gần nhất của mỗi truyện đang hiển thị mà user đã đọc, kèm số chương đã
+// đọc xong và tổng số chương đang hiển thị. Tham số có tên: @user (user ID), @visible (visibleStatuses).
+const shelfSQL = `
+WITH latest AS (
+    SELECT DISTINCT ON (rp.book_id) rp.book_id, rp.chapter_id, rp.position, rp.percentage, rp.client_updated_at
+    FROM reading_progress rp
+    WHERE rp.user_id = @user
+    ORDER BY rp.book_id, rp.client_updated_at DESC
+),
+done AS (
+    SELECT rp.book_id, count(*) AS completed_chapters
+    FROM reading_progress rp
+    JOIN chapters c ON c.id = rp.chapter_id AND c.deleted_at IS NULL AND c.status IN @visible
+    WHERE rp.user_id = @user AND rp.completed
+    GROUP BY rp.book_id
+),
+total AS (
+    SELECT c.book_id, count(*) AS total_chapters
+    FROM chapters c
+    WHERE c.book_id IN (SELECT book_id FROM latest) AND c.deleted_at IS NULL AND c.status IN @visible
+    GROUP BY c.book_id
+)
+SELECT latest.book_id, latest.chapter_id, c.chapter_number, latest.position, latest.percentage, latest.client_updated_at,
+    coalesce(done.completed_chapters, 0) AS completed_chapters,
+    coalesce(total.total_chapters, 0) AS total_chapters
+FROM latest
+JOIN books b ON b.id = latest.book_id AND b.deleted_at IS NULL AND b.status IN @visible
+JOIN chapters c ON c.id = latest.chapter_id
+LEFT JOIN done ON done.book_id = latest.book_id
+LEFT JOIN total ON total.book_id = latest.book_id`
+
+// ListShelf lấy kệ "đọc tiếp" của user, truyện đọc gần nhất đứng trước. Truyện đã đọc xong mọi
+// chương chỉ có trong kết quả khi includeFinished.
+func (r *ProgressRepository) ListShelf(userID uint, includeFinished bool, page, limit int) ([]ShelfItem, int64, error) {
+	shelf := r.db.Table("(?) AS shelf", r.db.Raw(shelfSQL, map[string]interface{}{
+		"user":    userID,
+		"visible": visibleStatuses,
+	}))
+	if !includeFinished {
+		shelf = shelf.Where("shelf.completed_chapters < shelf.total_chapters")
+	}
+	shelf = shelf.Session(&gorm.Session{})
+
+	var total int64
+	if err := shelf.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []ShelfItem
+	err := shelf.Select("shelf.*").
+		Order("shelf.client_updated_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Scan(&items).Error
+	return items, total, err
+}
+
+// GetReadingStats tính thống kê hoàn thành của truyện trên các chương đang hiển thị
+func (r *ProgressRepository) GetReadingStats(bookID uint) (*ReadingStats, error) {
+	stats := &ReadingStats{}
+	err := r.db.Table("reading_progress rp").
+		Select("c.chapter_number, count(*) AS started, count(*) FILTER (WHERE rp.completed) AS completed").
+		Joins("JOIN chapters c ON c.id = rp.chapter_id AND c.deleted_at IS NULL AND c.status IN ?", visibleStatuses).
+		Where("rp.book_id = ?", bookID).
+		Group("c.chapter_number").
+		Order("c.chapter_number ASC").
+		Scan(&stats.Chapters).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if stats.TotalChapters, err = r.CountVisibleChapters(bookID); err != nil {
+		return nil, err
+	}
+
+	var readers struct {
+		Readers  int64
+		Finished int64
+	}
+	err = r.db.Raw(`SELECT count(*) AS readers, count(*) FILTER (WHERE completed_chapters >= ? AND ? > 0) AS finished
+        FROM (
+            SELECT rp.user_id, count(*) FILTER (WHERE rp.completed AND c.status IN ?) AS completed_chapters
+            FROM reading_progress rp
+            JOIN chapters c ON c.id = rp.chapter_id AND c.deleted_at IS NULL
+            WHERE rp.book_id = ?
+            GROUP BY rp.user_id
+        ) per_user`, stats.TotalChapters, stats.TotalChapters, visibleStatuses, bookID).
+		Scan(&readers).Error
+	if err != nil {
+		return nil, err
+	}
+	stats.Readers = readers.Readers
+	stats.Finished = readers.Finished
+	return stats, nil
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"content-service/internal/models"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReviewFilter là điều kiện lấy danh sách đánh giá của truyện, phân trang theo con trỏ ID giảm dần
+type ReviewFilter struct {
+	BookID uint
+	Rating int  // 0 = mọi số sao
+	Before uint // Chỉ lấy đánh giá có ID nhỏ hơn, 0 = từ mới nhất
+	Limit  int
+}
+
+// ReviewRepository giữ kết nối với db
+type ReviewRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewRepository dùng để tạo ReviewRepository và gắn kết nối DB vào nó
+func NewReviewRepository(db *gorm.DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+// GetReviewByID lấy đánh giá theo ID, trả về nil nếu không tồn tại
+func (r *ReviewRepository) GetReviewByID(id uint) (*models.BookReview, error) {
+	var review models.BookReview
+
+	err := r.db.First(&review, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// GetUserReview lấy đánh giá của user cho truyện, trả về nil nếu user chưa đánh giá
+func (r *ReviewRepository) GetUserReview(bookID, userID uint) (*models.BookReview, error) {
+	var review models.BookReview
+
+	err := r.db.Where("book_id = ? AND user_id = ?", bookID, userID).First(&review).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// ListReviews lấy các đánh giá đang hiển thị của truyện, mới nhất trước
+func (r *ReviewRepository) ListReviews(filter ReviewFilter) ([]models.BookReview, error) {
+	query := r.db.Where("book_id = ? AND status = ?", filter.BookID, models.VisibilityVisible)
+	if filter.Rating != 0 {
+		query = query.Where("rating = ?", filter.Rating)
+	}
+	if filter.Before != 0 {
+		query = query.Where("id < ?", filter.Before)
+	}
+
+	var reviews []models.BookReview
+	err := query.Order("id DESC").Limit(filter.Limit).Find(&reviews).Error
+	return reviews, err
+}
+
+// SaveReview tạo hoặc cập nhật đánh giá và cập nhật điểm tổng hợp của truyện trong cùng transaction.
+// Khi cập nhật, chỉ số sao, nhận xét, spoiler và thời điểm sửa được chép lên dòng đang khóa, nên
+// trạng thái kiểm duyệt do admin đổi đồng thời không bị ghi đè; review nhận lại bản ghi đã lưu.
+func (r *ReviewRepository) SaveReview(review *models.BookReview) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if review.ID == 0 {
+			if err := tx.Create(review).Error; err != nil {
+				return err
+			}
+			return applyRatingChange(tx, review.BookID, 0, ratingContribution(review))
+		}
+
+		return updateLockedReview(tx, review, func(current *models.BookReview) {
+			current.Rating = review.Rating
+			current.Body = review.Body
+			current.Spoiler = review.Spoiler
+			current.EditedAt = review.EditedAt
+		})
+	})
+}
+
+// ModerateReview ghi trạng thái kiểm duyệt của đánh giá lên dòng đang khóa và cập nhật điểm tổng hợp;
+// nội dung do người viết sửa đồng thời được giữ nguyên. review nhận lại bản ghi đã lưu.
+func (r *ReviewRepository) ModerateReview(review *models.BookReview) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return updateLockedReview(tx, review, func(current *models.BookReview) {
+			current.Moderation = review.Moderation
+		})
+	})
+}
+
+// DeleteReview xóa mềm đánh giá và trừ điểm của nó khỏi điểm tổng hợp
+func (r *ReviewRepository) DeleteReview(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		previous, err := lockReview(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.BookReview{}, id).Error; err != nil {
+			return err
+		}
+		return applyRatingChange(tx, previous.BookID, ratingContribution(previous), 0)
+	})
+}
+
+// GetRatingStats lấy điểm tổng hợp của truyện, truyện chưa có đánh giá trả về bản ghi rỗng
+func (r *ReviewRepository) GetRatingStats(bookID uint) (*models.BookRatingStats, error) {
+	stats := models.BookRatingStats{BookID: bookID}
+	err := r.db.Where("book_id = ?", bookID).Limit(1).Find(&stats).Error
+	return &stats, err
+}
+
+// lockReview khóa và đọc trạng thái hiện tại của đánh giá trong transaction
+func lockReview(tx *gorm.DB, id uint) (*models.BookReview, error) {
+	var review models.BookReview
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, id).Error
+	return &review, err
+}
+
+// updateLockedReview khóa đánh giá, áp dụng apply lên bản ghi hiện tại rồi lưu lại và cập nhật
+// điểm tổng hợp theo thay đổi đóng góp của nó
+func updateLockedReview(tx *gorm.DB, review *models.BookReview, apply func(current *models.BookReview)) error {
+	current, err := lockReview(tx, review.ID)
+	if err != nil {
+		return err
+	}
+	previous := ratingContribution(current)
+	apply(current)
+	if err := tx.Save(current).Error; err != nil {
+		return err
+	}
+	*review = *current
+	return applyRatingChange(tx, current.BookID, previous, ratingContribution(current))
+}
+
+// ratingContribution là số sao đánh giá đóng góp vào điểm tổng hợp, 0 nếu đánh giá bị ẩn
+func ratingContribution(review *models.BookReview) int {
+	if !review.IsVisible() {
+		return 0
+	}
+	return review.Rating
+}
+
+// applyRatingChange cộng/trừ điểm tổng hợp khi đóng góp của một đánh giá đổi từ removed sang added sao
+// (0 = không đóng góp)
+func applyRatingChange(tx *gorm.DB, bookID uint, removed, added int) error {
+	if removed == added {
+		return nil
+	}
+
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.BookRatingStats{BookID: bookID}).Error
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{"updated_at": time.Now()}
+	var count, sum int
+	if removed > 0 {
+		updates[histogramColumn(removed)] = gorm.Expr(histogramColumn(removed) + " - 1")
+		count--
+		sum -= removed
+	}
+	if added > 0 {
+		updates[histogramColumn(added)] = gorm.Expr(histogramColumn(added) + " + 1")
+		count++
+		sum += added
+	}
+	updates["rating_count"] = gorm.Expr("rating_count + ?", count)
+	updates["rating_sum"] = gorm.Expr("rating_sum + ?", sum)
+
+	return tx.Model(&models.BookRatingStats{}).Where("book_id = ?", bookID).Updates(updates).Error
+}
+
+// histogramColumn là cột đếm số đánh giá rating sao trong book_rating_stats
+func histogramColumn(rating int) string {
+	return "count_" + strconv.Itoa(rating)
+}
@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"content-service/internal/models"
+	"html"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// searchConfig là cấu hình text search bỏ dấu tiếng Việt, được tạo trong migration full_text_search
+const searchConfig = "vn_unaccent"
+
+// headlineMarks cho ts_headline đánh dấu từ khớp bằng ký tự điều khiển STX/ETX thay vì thẻ HTML,
+// để highlight() escape được văn bản gốc trước khi chèn <mark>
+const headlineMarks = `'StartSel=' || chr(2) || ', StopSel=' || chr(3)`
+
+// SearchParams là điều kiện tìm kiếm
+type SearchParams struct {
+	Query      string
+	CategoryID uint  // Gồm cả truyện của các thể loại con cháu
+	Premium    *bool // nil = không lọc
+	Page       int
+	Limit      int
+}
+
+// SearchHit là một truyện khớp từ khóa, kèm đoạn trích đã escape HTML và đánh dấu từ khớp bằng <mark>
+type SearchHit struct {
+	Book             models.Book
+	Rank             float64
+	TitleHighlight   string
+	DescriptionMatch string
+	Chapters         []ChapterHit
+}
+
+// ChapterHit là chương khớp từ khóa trong một truyện
+type ChapterHit struct {
+	BookID        uint
+	ChapterNumber int
+	Rank          float64
+	Snippet       string
+}
+
+// FacetCount là số truyện khớp theo một giá trị facet
+type FacetCount struct {
+	Value uint
+	Name  string
+	Count int64
+}
+
+// SearchResult là một trang kết quả cùng facet tính trên toàn bộ truyện khớp từ khóa
+// (không áp dụng bộ lọc thể loại/premium, để client hiển thị được số lượng của các lựa chọn khác).
+// Truyện thuộc nhiều thể loại được đếm ở mỗi thể loại của nó.
+type SearchResult struct {
+	Hits       []SearchHit
+	Total      int64
+	Categories []FacetCount
+	Premium    map[bool]int64
+}
+
+// SearchRepository tìm kiếm toàn văn trên truyện và chương
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchRepository dùng để tạo SearchRepository và gắn kết nối DB vào nó
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// hitsSQL trả về mỗi truyện đang xuất bản khớp từ khóa một dòng (id, is_premium, rank).
+// Điểm của truyện gộp điểm của chính truyện (tên > thể loại > mô tả theo trọng số A/B/C) với
+// 0.4 lần điểm của chương khớp nhất (tên thể loại và tên thẻ cùng trọng số B). Chương bị khóa
+// (truyện premium hoặc có giá lẻ) không được tính, để không ai dò được một đoạn văn có nằm
+// trong nội dung trả phí hay không. Tham số: từ khóa.
+const hitsSQL = `
+WITH q AS (SELECT websearch_to_tsquery('` + searchConfig + `', ?) AS query),
+chapter_hits AS (
+    SELECT c.book_id, max(ts_rank(c.search_vector, q.query)) AS rank
+    FROM chapters c
+    JOIN books cb ON cb.id = c.book_id
+    CROSS JOIN q
+    WHERE c.search_vector @@ q.query AND c.status = 'published' AND c.deleted_at IS NULL
+        AND NOT (cb.is_premium OR c.price > 0)
+    GROUP BY c.book_id
+)
+SELECT b.id, b.is_premium,
+    coalesce(ts_rank(b.search_vector, q.query), 0) + coalesce(ch.rank, 0) * 0.4 AS rank
+FROM books b
+CROSS JOIN q
+LEFT JOIN chapter_hits ch ON ch.book_id = b.id
+WHERE b.status = 'published' AND b.deleted_at IS NULL
+    AND (b.search_vector @@ q.query OR ch.book_id IS NOT NULL)`
+
+// Search tìm truyện theo từ khóa (cú pháp web: "cụm từ", -loại trừ, or), xếp theo điểm giảm dần
+func (r *SearchRepository) Search(params SearchParams) (*SearchResult, error) {
+	result := &SearchResult{Premium: map[bool]int64{}}
+
+	var premium []struct {
+		IsPremium bool
+		Count     int64
+	}
+	err := r.db.Raw(`SELECT is_premium, count(*) AS count FROM (`+hitsSQL+`) hits GROUP BY is_premium`, params.Query).
+		Scan(&premium).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range premium {
+		result.Premium[p.IsPremium] = p.Count
+	}
+
+	err = r.db.Raw(`SELECT cat.id AS value, cat.name, count(*) AS count
+        FROM (`+hitsSQL+`) hits
+        JOIN book_categories bc ON bc.book_id = hits.id
+        JOIN categories cat ON cat.id = bc.category_id AND cat.deleted_at IS NULL
+        GROUP BY cat.id, cat.name
+        ORDER BY count DESC, cat.id`, params.Query).
+		Scan(&result.Categories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := r.db.Table("(?) AS hits", r.db.Raw(hitsSQL, params.Query))
+	if params.CategoryID != 0 {
+		filtered = filtered.Where("hits."+inCategoryTreeSQL, params.CategoryID)
+	}
+	if params.Premium != nil {
+		filtered = filtered.Where("hits.is_premium = ?", *params.Premium)
+	}
+	filtered = filtered.Session(&gorm.Session{})
+	if err := filtered.Count(&result.Total).Error; err != nil {
+		return nil, err
+	}
+
+	var ranked []struct {
+		ID   uint
+		Rank float64
+	}
+	err = filtered.Select("hits.id, hits.rank").
+		Order("hits.rank DESC, hits.id DESC").
+		Offset((params.Page - 1) * params.Limit).
+		Limit(params.Limit).
+		Scan(&ranked).Error
+	if err != nil || len(ranked) == 0 {
+		return result, err
+	}
+
+	ids := make([]uint, 0, len(ranked))
+	for _, row := range ranked {
+		ids = append(ids, row.ID)
+	}
+	hits, err := r.highlightBooks(params.Query, ids)
+	if err != nil {
+		return nil, err
+	}
+	chapters, err := r.chapterHits(params.Query, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range ranked {
+		hit, ok := hits[row.ID]
+		if !ok {
+			continue
+		}
+		hit.Rank = row.Rank
+		hit.Chapters = chapters[row.ID]
+		result.Hits = append(result.Hits, *hit)
+	}
+	return result, nil
+}
+
+// highlightBooks lấy truyện theo ID kèm tên và đoạn mô tả có đánh dấu từ khóa
+func (r *SearchRepository) highlightBooks(query string, ids []uint) (map[uint]*SearchHit, error) {
+	var books []models.Book
+	if err := withTaxonomy(r.db).Where("id IN ?", ids).Find(&books).Error; err != nil {
+		return nil, err
+	}
+
+	var highlights []struct {
+		ID          uint
+		Title       string
+		Description string
+	}
+	err := r.db.Raw(`SELECT b.id,
+            ts_headline('`+searchConfig+`', b.title, q.query, `+headlineMarks+` || ', HighlightAll=true') AS title,
+            CASE WHEN to_tsvector('`+searchConfig+`', b.description) @@ q.query
+                THEN ts_headline('`+searchConfig+`', b.description, q.query, `+headlineMarks+` || ', MaxWords=35, MinWords=15, MaxFragments=2')
+                ELSE '' END AS description
+        FROM books b, (SELECT websearch_to_tsquery('`+searchConfig+`', ?) AS query) q
+        WHERE b.id IN ?`, query, ids).
+		Scan(&highlights).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make(map[uint]*SearchHit, len(books))
+	for i := range books {
+		hits[books[i].ID] = &SearchHit{Book: books[i], TitleHighlight: html.EscapeString(books[i].Title)}
+	}
+	for _, h := range highlights {
+		if hit, ok := hits[h.ID]; ok {
+			hit.TitleHighlight = highlight(h.Title)
+			hit.DescriptionMatch = highlight(h.Description)
+		}
+	}
+	return hits, nil
+}
+
+// chapterHits lấy tối đa 3 chương khớp nhất của mỗi truyện. Chương bị khóa (truyện premium
+// hoặc có giá lẻ) không bao giờ là kết quả, giống hitsSQL.
+func (r *SearchRepository) chapterHits(query string, bookIDs []uint) (map[uint][]ChapterHit, error) {
+	var rows []ChapterHit
+	err := r.db.Raw(`SELECT book_id, chapter_number, rank,
+            ts_headline('`+searchConfig+`', search_text, query,
+                `+headlineMarks+` || ', MaxWords=30, MinWords=10, MaxFragments=1') AS snippet
+        FROM (
+            SELECT c.book_id, c.chapter_number, c.search_text, q.query,
+                ts_rank(c.search_vector, q.query) AS rank,
+                row_number() OVER (PARTITION BY c.book_id ORDER BY ts_rank(c.search_vector, q.query) DESC, c.chapter_number) AS position
+            FROM chapters c
+            JOIN books b ON b.id = c.book_id
+            CROSS JOIN (SELECT websearch_to_tsquery('`+searchConfig+`', ?) AS query) q
+            WHERE c.book_id IN ? AND c.search_vector @@ q.query
+                AND c.status = 'published' AND c.deleted_at IS NULL
+                AND NOT (b.is_premium OR c.price > 0)
+        ) matched
+        WHERE position <= 3
+        ORDER BY book_id, rank DESC`, query, bookIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byBook := make(map[uint][]ChapterHit)
+	for _, row := range rows {
+		row.Snippet = highlight(row.Snippet)
+		byBook[row.BookID] = append(byBook[row.BookID], row)
+	}
+	return byBook, nil
+}
+
+// ListChaptersToIndex lấy các chương có nội dung xuất bản chưa được index (content_cid khác search_cid)
+func (r *SearchRepository) ListChaptersToIndex(limit int) ([]models.Chapter, error) {
+	var chapters []models.Chapter
+	err := r.db.Unscoped().
+		Select("id", "content_cid", "content_type").
+		Where("content_cid <> search_cid").
+		Order("id").
+		Limit(limit).
+		Find(&chapters).Error
+	return chapters, err
+}
+
+// SetChapterSearchText ghi văn bản đã trích của nội dung cid. Không ghi nếu nội dung chương
+// đã đổi trong lúc index, lần quét sau sẽ index lại.
+func (r *SearchRepository) SetChapterSearchText(chapterID uint, cid, text string) error {
+	return r.db.Exec(`UPDATE chapters SET search_text = ?, search_cid = ? WHERE id = ? AND content_cid = ?`,
+		text, cid, chapterID, cid).Error
+}
+
+// markReplacer đổi ký tự đánh dấu của ts_headline thành thẻ <mark>
+var markReplacer = strings.NewReplacer("\x02", "<mark>", "\x03", "</mark>")
+
+// highlight escape HTML của đoạn trích rồi mới chèn <mark>, nên nội dung truyện không chèn được thẻ HTML
+func highlight(headline string) string {
+	return markReplacer.Replace(html.EscapeString(headline))
+}
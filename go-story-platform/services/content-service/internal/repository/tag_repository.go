@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"content-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagCount là một thẻ kèm số truyện đã xuất bản được gắn thẻ đó
+type TagCount struct {
+	models.Tag
+	BookCount int64
+}
+
+// TagRepository giữ kết nối với db
+type TagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository dùng để tạo TagRepository và gắn kết nối DB vào nó
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// ListTags lấy các thẻ đang được gắn cho truyện đã xuất bản, thẻ nhiều truyện nhất đứng trước.
+// prefix (đã là slug, không chứa % hay _) khác rỗng thì chỉ lấy thẻ có slug bắt đầu bằng prefix.
+func (r *TagRepository) ListTags(prefix string, page, limit int) ([]TagCount, int64, error) {
+	query := r.db.Table("tags").
+		Joins("JOIN book_tags bt ON bt.tag_id = tags.id").
+		Joins("JOIN books b ON b.id = bt.book_id AND b.status = ? AND b.deleted_at IS NULL", models.StatusPublished).
+		Group("tags.id")
+	if prefix != "" {
+		query = query.Where("tags.slug LIKE ?", prefix+"%")
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := r.db.Table("(?) AS used", query.Select("tags.id")).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var tags []TagCount
+	err := query.Select("tags.*, count(*) AS book_count").
+		Order("book_count DESC, tags.slug ASC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Scan(&tags).Error
+	return tags, total, err
+}
+
+// ensureTags tạo các thẻ chưa có (theo Slug) và gán ID cho tags. Thẻ đã có giữ tên cũ.
+func ensureTags(tx *gorm.DB, tags []models.Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "slug"}}, DoNothing: true}).
+		Create(&tags).Error
+	if err != nil {
+		return err
+	}
+
+	slugs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		slugs = append(slugs, tag.Slug)
+	}
+	var existing []models.Tag
+	if err := tx.Where("slug IN ?", slugs).Find(&existing).Error; err != nil {
+		return err
+	}
+	bySlug := make(map[string]models.Tag, len(existing))
+	for _, tag := range existing {
+		bySlug[tag.Slug] = tag
+	}
+	for i := range tags {
+		tags[i] = bySlug[tags[i].Slug]
+	}
+	return nil
+}
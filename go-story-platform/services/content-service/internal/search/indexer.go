@@ -0,0 +1,131 @@
+// Package search đưa văn bản của nội dung chương vào chỉ mục tìm kiếm toàn văn.
+// Chỉ mục của truyện (tên, mô tả, thể loại) do trigger trong database tự cập nhật.
+package search
+
+import (
+	"content-service/internal/repository"
+	"content-service/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log/slog"
+	"mime"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxIndexedText là số byte văn bản tối đa được index cho mỗi chương
+const maxIndexedText = 512 << 10
+
+// batchSize là số chương tối đa được index trong một lần quét
+const batchSize = 50
+
+// Indexer định kỳ tìm các chương có nội dung xuất bản mới (content_cid khác search_cid),
+// đọc nội dung từ BlobStore và ghi văn bản vào chỉ mục
+type Indexer struct {
+	repo     *repository.SearchRepository
+	store    storage.BlobStore
+	interval time.Duration
+}
+
+// NewIndexer tạo Indexer quét mỗi interval
+func NewIndexer(repo *repository.SearchRepository, store storage.BlobStore, interval time.Duration) *Indexer {
+	return &Indexer{repo: repo, store: store, interval: interval}
+}
+
+// Run quét cho tới khi ctx bị hủy
+func (ix *Indexer) Run(ctx context.Context) {
+	ticker := time.NewTicker(ix.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := ix.Sync(ctx); err != nil {
+			slog.Error("Lỗi khi index nội dung chương", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync index một lượt các chương đang chờ. Nội dung không phải văn bản được index rỗng
+// để không bị quét lại mãi.
+func (ix *Indexer) Sync(ctx context.Context) error {
+	chapters, err := ix.repo.ListChaptersToIndex(batchSize)
+	if err != nil {
+		return fmt.Errorf("lấy chương cần index: %w", err)
+	}
+
+	for _, chapter := range chapters {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		var text string
+		if chapter.ContentCID != "" {
+			text, err = ix.extract(ctx, chapter.ContentCID, chapter.ContentType)
+			if errors.Is(err, storage.ErrNotFound) {
+				slog.Warn("Chương trỏ tới blob không tồn tại, bỏ qua khi index", "chapter_id", chapter.ID, "cid", chapter.ContentCID)
+			} else if err != nil {
+				return fmt.Errorf("đọc nội dung chương %d: %w", chapter.ID, err)
+			}
+		}
+		if err := ix.repo.SetChapterSearchText(chapter.ID, chapter.ContentCID, text); err != nil {
+			return fmt.Errorf("ghi chỉ mục chương %d: %w", chapter.ID, err)
+		}
+	}
+	return nil
+}
+
+// extract đọc tối đa maxIndexedText byte đầu của blob và trả về văn bản thuần
+func (ix *Indexer) extract(ctx context.Context, cid, contentType string) (string, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "" && !strings.HasPrefix(mediaType, "text/") && mediaType != "application/octet-stream" {
+		return "", nil
+	}
+
+	obj, err := ix.store.Open(ctx, cid)
+	if err != nil {
+		return "", err
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(io.LimitReader(obj, maxIndexedText))
+	if err != nil {
+		return "", err
+	}
+	return PlainText(data, mediaType), nil
+}
+
+var (
+	htmlTag   = regexp.MustCompile(`(?s)<script.*?</script>|<style.*?</style>|<[^>]*>`)
+	nulOrCtrl = regexp.MustCompile(`[\x00-\x08\x0b\x0c\x0e-\x1f]`)
+)
+
+// PlainText chuyển nội dung chương thành văn bản để index: bỏ thẻ HTML, ký tự điều khiển,
+// và phần ký tự UTF-8 bị cắt dở ở cuối. Nội dung không phải UTF-8 hợp lệ trả về rỗng.
+func PlainText(data []byte, mediaType string) string {
+	// Bỏ rune cuối bị cắt ngang khi đọc giới hạn số byte
+	for i := 0; i < utf8.UTFMax && len(data) > 0; i++ {
+		r, size := utf8.DecodeLastRune(data)
+		if r != utf8.RuneError || size != 1 {
+			break
+		}
+		data = data[:len(data)-1]
+	}
+	if !utf8.Valid(data) {
+		return ""
+	}
+
+	text := string(data)
+	if mediaType == "text/html" {
+		text = html.UnescapeString(htmlTag.ReplaceAllString(text, " "))
+	}
+	return nulOrCtrl.ReplaceAllString(text, " ")
+}
@@ -0,0 +1,54 @@
+// Package slug sinh slug ASCII cho URL, chuyển chữ tiếng Việt có dấu về chữ không dấu.
+package slug
+
+import (
+	"strings"
+	"unicode"
+)
+
+// vietnamese ánh xạ các nguyên âm có dấu (dạng dựng sẵn, chữ thường) và đ về chữ Latin gốc
+var vietnamese = buildTable(map[rune]string{
+	'a': "àáảãạăằắẳẵặâầấẩẫậ",
+	'e': "èéẻẽẹêềếểễệ",
+	'i': "ìíỉĩị",
+	'o': "òóỏõọôồốổỗộơờớởỡợ",
+	'u': "ùúủũụưừứửữự",
+	'y': "ỳýỷỹỵ",
+	'd': "đ",
+})
+
+func buildTable(groups map[rune]string) map[rune]rune {
+	table := make(map[rune]rune)
+	for base, variants := range groups {
+		for _, r := range variants {
+			table[r] = base
+		}
+	}
+	return table
+}
+
+// Make chuyển s thành slug: chữ thường không dấu, chữ/số giữ nguyên, các ký tự khác thành một dấu "-".
+// Ví dụ "Kiếm Hiệp & Tiên Hiệp" → "kiem-hiep-tien-hiep". Trả về rỗng nếu s không có chữ/số nào.
+func Make(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		// Dấu thanh ở dạng tổ hợp (NFD) được bỏ qua, chữ gốc đứng trước vẫn được giữ
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if base, ok := vietnamese[r]; ok {
+			r = base
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
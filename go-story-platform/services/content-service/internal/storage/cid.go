@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"hash"
+	"strings"
+)
+
+// Tham số giống "ipfs add --cid-version=1" mặc định: chia khối 256 KiB, lá dạng raw,
+// cây cân bằng tối đa 174 liên kết mỗi nút
+const (
+	cidChunkSize = 256 * 1024
+	cidMaxLinks  = 174
+
+	codecRaw    = 0x55
+	codecDagPB  = 0x70
+	hashSha256  = 0x12
+	cidVersion1 = 0x01
+)
+
+var cidBase32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// cidLink là một nút con trong cây UnixFS: CID nhị phân, kích thước dữ liệu file nó chứa
+// và tổng kích thước các khối (Tsize)
+type cidLink struct {
+	cid      []byte
+	fileSize uint64
+	tsize    uint64
+}
+
+// CIDBuilder tính IPFS CID (CIDv1, base32) của nội dung được ghi vào, trùng với CID mà
+// "ipfs add --cid-version=1" trả về cho cùng file. Dùng như io.Writer để tính trong lúc
+// stream nội dung, rồi gọi Sum.
+type CIDBuilder struct {
+	buf    []byte
+	leaves []cidLink
+	size   int64
+}
+
+// NewCIDBuilder tạo CIDBuilder rỗng
+func NewCIDBuilder() *CIDBuilder {
+	return &CIDBuilder{buf: make([]byte, 0, cidChunkSize)}
+}
+
+// Write nhận thêm nội dung; mỗi khi đủ một khối 256 KiB thì băm thành một lá
+func (b *CIDBuilder) Write(p []byte) (int, error) {
+	n := len(p)
+	b.size += int64(n)
+	for len(p) > 0 {
+		take := min(cidChunkSize-len(b.buf), len(p))
+		b.buf = append(b.buf, p[:take]...)
+		p = p[take:]
+		if len(b.buf) == cidChunkSize {
+			b.flushLeaf()
+		}
+	}
+	return n, nil
+}
+
+// Size trả về số byte đã ghi
+func (b *CIDBuilder) Size() int64 {
+	return b.size
+}
+
+// Sum trả về CID của toàn bộ nội dung đã ghi
+func (b *CIDBuilder) Sum() string {
+	if len(b.buf) > 0 || len(b.leaves) == 0 {
+		b.flushLeaf()
+	}
+
+	level := b.leaves
+	for len(level) > 1 {
+		var parents []cidLink
+		for start := 0; start < len(level); start += cidMaxLinks {
+			parents = append(parents, dagNode(level[start:min(start+cidMaxLinks, len(level))]))
+		}
+		level = parents
+	}
+	return encodeCID(level[0].cid)
+}
+
+func (b *CIDBuilder) flushLeaf() {
+	b.leaves = append(b.leaves, cidLink{
+		cid:      newCID(codecRaw, sha256.New(), b.buf),
+		fileSize: uint64(len(b.buf)),
+		tsize:    uint64(len(b.buf)),
+	})
+	b.buf = b.buf[:0]
+}
+
+// dagNode tạo nút dag-pb UnixFS (kiểu File) trỏ tới các nút con
+func dagNode(children []cidLink) cidLink {
+	var fileSize, tsize uint64
+	var data []byte
+	data = appendVarintField(data, 1, 2) // Type = File
+	for _, child := range children {
+		fileSize += child.fileSize
+	}
+	data = appendVarintField(data, 3, fileSize)
+	for _, child := range children {
+		data = appendVarintField(data, 4, child.fileSize) // blocksizes
+	}
+
+	// DAG-PB luôn mã hóa Links (field 2) trước Data (field 1)
+	var node []byte
+	for _, child := range children {
+		var link []byte
+		link = appendBytesField(link, 1, child.cid)
+		link = appendBytesField(link, 2, nil) // Name rỗng
+		link = appendVarintField(link, 3, child.tsize)
+		node = appendBytesField(node, 2, link)
+		tsize += child.tsize
+	}
+	node = appendBytesField(node, 1, data)
+
+	return cidLink{
+		cid:      newCID(codecDagPB, sha256.New(), node),
+		fileSize: fileSize,
+		tsize:    tsize + uint64(len(node)),
+	}
+}
+
+// newCID tạo CIDv1 nhị phân: version, codec, multihash sha2-256 của block
+func newCID(codec uint64, h hash.Hash, block []byte) []byte {
+	h.Write(block)
+	digest := h.Sum(nil)
+
+	out := binary.AppendUvarint(nil, cidVersion1)
+	out = binary.AppendUvarint(out, codec)
+	out = binary.AppendUvarint(out, hashSha256)
+	out = binary.AppendUvarint(out, uint64(len(digest)))
+	return append(out, digest...)
+}
+
+// encodeCID mã hóa CID nhị phân bằng multibase base32 chữ thường (tiền tố "b")
+func encodeCID(cid []byte) string {
+	return "b" + strings.ToLower(cidBase32.EncodeToString(cid))
+}
+
+// IsCID kiểm tra chuỗi có dạng CIDv1 base32 do CIDBuilder sinh ra không
+func IsCID(s string) bool {
+	if len(s) < 2 || s[0] != 'b' {
+		return false
+	}
+	raw, err := cidBase32.DecodeString(strings.ToUpper(s[1:]))
+	return err == nil && len(raw) == 36 && raw[0] == cidVersion1
+}
+
+func appendVarintField(buf []byte, field int, v uint64) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field)<<3)
+	return binary.AppendUvarint(buf, v)
+}
+
+func appendBytesField(buf []byte, field int, v []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field)<<3|2)
+	buf = binary.AppendUvarint(buf, uint64(len(v)))
+	return append(buf, v...)
+}
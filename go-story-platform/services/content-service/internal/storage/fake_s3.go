@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeS3 là server giả lập S3 (kiểu MinIO) lưu trong bộ nhớ, hỗ trợ PUT/GET/HEAD/DELETE
+// Object theo địa chỉ path và header Range, đủ để chạy S3Store mà không cần MinIO thật:
+//
+//	srv := httptest.NewServer(storage.NewFakeS3())
+//	store, _ := storage.NewS3Store(storage.S3Config{Endpoint: srv.URL, Bucket: "chapters", ...})
+//
+// Chữ ký không được kiểm tra, chỉ yêu cầu có header Authorization dạng SigV4.
+type FakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]fakeObject
+}
+
+type fakeObject struct {
+	data        []byte
+	contentType string
+	modTime     time.Time
+}
+
+// NewFakeS3 tạo FakeS3 rỗng; mọi bucket đều được coi là đã tồn tại
+func NewFakeS3() *FakeS3 {
+	return &FakeS3{objects: make(map[string]fakeObject)}
+}
+
+// ServeHTTP xử lý request S3 dạng /bucket/key
+func (f *FakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	if !strings.Contains(path, "/") {
+		http.Error(w, "InvalidRequest", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "IncompleteBody", http.StatusBadRequest)
+			return
+		}
+		f.mu.Lock()
+		f.objects[path] = fakeObject{data: data, contentType: r.Header.Get("Content-Type"), modTime: time.Now().UTC()}
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		f.mu.Lock()
+		obj, ok := f.objects[path]
+		f.mu.Unlock()
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		if obj.contentType != "" {
+			w.Header().Set("Content-Type", obj.contentType)
+		}
+		http.ServeContent(w, r, "", obj.modTime, bytes.NewReader(obj.data))
+	case http.MethodDelete:
+		f.mu.Lock()
+		delete(f.objects, path)
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "MethodNotAllowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FileStore lưu blob thành file trên đĩa, chia thư mục con theo 2 ký tự cuối của khóa
+// để một thư mục không chứa quá nhiều file
+type FileStore struct {
+	root string
+}
+
+// NewFileStore tạo FileStore tại thư mục root (tự tạo nếu chưa có)
+func NewFileStore(root string) (*FileStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("không tạo được thư mục lưu trữ: %w", err)
+	}
+	return &FileStore{root: root}, nil
+}
+
+// Put ghi blob vào file tạm rồi đổi tên, để người đọc không bao giờ thấy file ghi dở
+func (s *FileStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("blob %s: đã ghi %d byte, cần %d", key, written, size)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Open mở file của blob
+func (s *FileStore) Open(ctx context.Context, key string) (Object, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, mapFSError(err)
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &fileObject{File: f, info: fileInfo(key, stat)}, nil
+}
+
+// Stat lấy kích thước và thời điểm ghi của blob
+func (s *FileStore) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return ObjectInfo{}, mapFSError(err)
+	}
+	return fileInfo(key, stat), nil
+}
+
+// Delete xóa file của blob
+func (s *FileStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *FileStore) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	shard := key
+	if len(key) > 2 {
+		shard = key[len(key)-2:]
+	}
+	return filepath.Join(s.root, shard, filepath.FromSlash(key)), nil
+}
+
+type fileObject struct {
+	*os.File
+	info ObjectInfo
+}
+
+func (o *fileObject) Info() ObjectInfo {
+	return o.info
+}
+
+// fileInfo tạo ObjectInfo từ file; hệ thống file không lưu content type nên để trống
+func fileInfo(key string, stat fs.FileInfo) ObjectInfo {
+	return ObjectInfo{Key: key, Size: stat.Size(), ModTime: stat.ModTime()}
+}
+
+func mapFSError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,70 @@
+// Package storage lưu nội dung chương theo địa chỉ nội dung (content-addressed): khóa của
+// mỗi blob là IPFS CID của chính nó, nên nội dung lấy ra luôn kiểm chứng được bằng cách tính lại CID.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrNotFound được trả về khi blob không tồn tại
+var ErrNotFound = errors.New("blob không tồn tại")
+
+// ObjectInfo là thông tin của một blob đã lưu
+type ObjectInfo struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// Object là blob đang mở để đọc. Seek cho phép phục vụ HTTP Range mà không đọc cả blob.
+type Object interface {
+	io.ReadSeekCloser
+	Info() ObjectInfo
+}
+
+// BlobStore là nơi lưu blob theo khóa
+type BlobStore interface {
+	// Put ghi blob dài size byte với khóa key; ghi lại cùng khóa sẽ thay thế blob cũ
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Open mở blob để đọc, ErrNotFound nếu không tồn tại
+	Open(ctx context.Context, key string) (Object, error)
+	// Stat lấy thông tin blob, ErrNotFound nếu không tồn tại
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// Delete xóa blob; xóa blob không tồn tại không phải lỗi
+	Delete(ctx context.Context, key string) error
+}
+
+// Config chọn nơi lưu nội dung chương qua STORAGE_DRIVER:
+//   - "fs" (mặc định): lưu trên đĩa tại STORAGE_FS_ROOT
+//   - "s3": S3 hoặc dịch vụ tương thích (MinIO), xem S3Config
+type Config struct {
+	Driver string `env:"STORAGE_DRIVER" default:"fs"`
+	FSRoot string `env:"STORAGE_FS_ROOT" default:"./data/blobs"`
+	S3     S3Config
+}
+
+// New tạo BlobStore theo cfg
+func New(cfg Config) (BlobStore, error) {
+	switch cfg.Driver {
+	case "fs":
+		return NewFileStore(cfg.FSRoot)
+	case "s3":
+		return NewS3Store(cfg.S3)
+	default:
+		return nil, fmt.Errorf("STORAGE_DRIVER không hỗ trợ: %q", cfg.Driver)
+	}
+}
+
+// validateKey chặn khóa rỗng hoặc có thể thoát ra ngoài thư mục/bucket
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "..") || strings.Contains(key, "\\") {
+		return fmt.Errorf("khóa blob không hợp lệ: %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Package grpc phục vụ API gRPC nội bộ của content-service cho các service khác
+// (hợp đồng ở shared/rpc/contentpb)
+package grpc
+
+import (
+	"content-service/internal/repository"
+	"context"
+
+	"shared/rpc/contentpb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ContentServer implement contentpb.ContentServiceServer
+type ContentServer struct {
+	contentpb.UnimplementedContentServiceServer
+	bookRepo    *repository.BookRepository
+	chapterRepo *repository.ChapterRepository
+}
+
+// NewContentServer tạo ContentServer với các repo được truyền vào
+func NewContentServer(bookRepo *repository.BookRepository, chapterRepo *repository.ChapterRepository) *ContentServer {
+	return &ContentServer{bookRepo: bookRepo, chapterRepo: chapterRepo}
+}
+
+// GetBook lấy thông tin bán của truyện đang hiển thị với người đọc
+func (s *ContentServer) GetBook(ctx context.Context, req *contentpb.GetBookRequest) (*contentpb.Book, error) {
+	book, err := s.bookRepo.GetBookByID(uint(req.GetId()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Lỗi server")
+	}
+	// Giống API công khai: truyện nháp, hẹn giờ hoặc bị gỡ coi như không tồn tại
+	if book == nil || !book.IsVisible() {
+		return nil, status.Error(codes.NotFound, "Truyện không tồn tại")
+	}
+
+	return &contentpb.Book{
+		Id:        uint64(book.ID),
+		Title:     book.Title,
+		AuthorId:  uint64(book.AuthorID),
+		IsPremium: book.IsPremium,
+		Price:     int64(book.Price),
+		Status:    book.Status,
+	}, nil
+}
+
+// GetChapter lấy thông tin bán của chương đang hiển thị với người đọc
+func (s *ContentServer) GetChapter(ctx context.Context, req *contentpb.GetChapterRequest) (*contentpb.Chapter, error) {
+	chapter, err := s.chapterRepo.GetVisibleChapterByID(uint(req.GetBookId()), uint(req.GetChapterId()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Lỗi server")
+	}
+	if chapter == nil {
+		return nil, status.Error(codes.NotFound, "Chương không tồn tại")
+	}
+
+	return &contentpb.Chapter{
+		Id:            uint64(chapter.ID),
+		BookId:        uint64(chapter.BookID),
+		ChapterNumber: int32(chapter.ChapterNumber),
+		Price:         int64(chapter.Price),
+		Status:        chapter.Status,
+	}, nil
+}
@@ -0,0 +1,457 @@
+// Package http cho book, chapter và category trong content-service
+package http
+
+import (
+	"content-service/internal/entitlement"
+	"content-service/internal/models"
+	"content-service/internal/publishing"
+	"content-service/internal/repository"
+	"content-service/internal/slug"
+	"content-service/internal/storage"
+	"content-service/internal/transport/http/dto"
+	"content-service/internal/transport/http/middleware"
+	"content-service/pkg/auth"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	maxBookCategories = 5  // Số thể loại tối đa của một truyện
+	maxBookTags       = 20 // Số thẻ tối đa của một truyện
+	maxTagLength      = 50 // Độ dài tối đa của tên thẻ (ký tự)
+)
+
+// BookHandler xử lý các request liên quan đến truyện và chương
+type BookHandler struct {
+	bookRepo     *repository.BookRepository
+	chapterRepo  *repository.ChapterRepository
+	categoryRepo *repository.CategoryRepository
+	entitlements entitlement.Checker
+	store        storage.BlobStore
+	maxContent   int64 // Kích thước tối đa của nội dung chương tải lên (byte)
+}
+
+// NewBookHandler tạo BookHandler với các repo, bộ kiểm tra quyền đọc, nơi lưu nội dung chương
+// và giới hạn kích thước nội dung chương (byte) được truyền vào
+func NewBookHandler(bookRepo *repository.BookRepository, chapterRepo *repository.ChapterRepository, categoryRepo *repository.CategoryRepository, entitlements entitlement.Checker, store storage.BlobStore, maxContent int64) *BookHandler {
+	return &BookHandler{
+		bookRepo:     bookRepo,
+		chapterRepo:  chapterRepo,
+		categoryRepo: categoryRepo,
+		entitlements: entitlements,
+		store:        store,
+		maxContent:   maxContent,
+	}
+}
+
+// CreateBook godoc
+// @Summary Tạo truyện mới
+// @Description User đang đăng nhập (vai trò author/admin) trở thành tác giả của truyện.
+// @Description Truyện mới ở trạng thái draft, dùng PUT /books/{id}/status để xuất bản.
+// @Description Tối đa 5 thể loại và 20 thẻ; thẻ chưa có được tạo mới, slug của thẻ được bỏ dấu tiếng Việt.
+// @Tags Books
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param book body dto.CreateBookRequest true "Thông tin truyện"
+// @Success 201 {object} dto.ApiResponse{data=dto.BookResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Router /books [post]
+func (h *BookHandler) CreateBook(c *gin.Context) {
+	var input dto.CreateBookRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+
+	input.Title = strings.TrimSpace(input.Title)
+	if input.Title == "" {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Tên truyện không được để trống"})
+		return
+	}
+	if input.Price < 0 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Giá không được âm"})
+		return
+	}
+	categories, ok := h.loadCategories(c, input.CategoryIDs)
+	if !ok {
+		return
+	}
+	tags, ok := parseTags(c, input.Tags)
+	if !ok {
+		return
+	}
+
+	book := models.Book{
+		Title:       input.Title,
+		AuthorID:    middleware.CurrentUserID(c),
+		Categories:  categories,
+		Tags:        tags,
+		Description: input.Description,
+		IsPremium:   input.IsPremium,
+		Price:       input.Price,
+		Publication: models.Publication{Status: models.StatusDraft},
+	}
+	if err := h.bookRepo.CreateBook(&book); err != nil {
+		serverError(c, err, "Không thể tạo truyện")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Tạo truyện thành công",
+		Data:    toBookResponse(&book),
+	})
+}
+
+// ListBooks godoc
+// @Summary Lấy danh sách truyện
+// @Description Người đọc chỉ thấy truyện published. Tác giả xem truyện của mình (author_id = chính mình)
+// @Description hoặc admin được lọc theo status, mặc định là mọi trạng thái.
+// @Tags Books
+// @Produce json
+// @Param author_id query int false "Lọc theo tác giả"
+// @Param category_id query int false "Lọc theo thể loại (gồm cả thể loại con cháu)"
+// @Param category query string false "Lọc theo slug thể loại (gồm cả thể loại con cháu)"
+// @Param tag query string false "Lọc theo thẻ (tên hoặc slug)"
+// @Param status query string false "Lọc theo trạng thái (chỉ tác giả/admin)"
+// @Param page query int false "Trang (mặc định 1)"
+// @Param limit query int false "Số bản ghi mỗi trang (mặc định 20, tối đa 100)"
+// @Success 200 {object} dto.ApiResponse{data=dto.BookListResponse}
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books [get]
+func (h *BookHandler) ListBooks(c *gin.Context) {
+	page, limit := parsePagination(c)
+	authorID, _ := strconv.ParseUint(c.Query("author_id"), 10, 64)
+	categoryID, _ := strconv.ParseUint(c.Query("category_id"), 10, 64)
+	if categorySlug := c.Query("category"); categorySlug != "" {
+		category, err := h.categoryRepo.GetCategoryBySlug(slug.Make(categorySlug))
+		if err != nil {
+			serverError(c, err, "Lỗi server")
+			return
+		}
+		if category == nil {
+			c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Thể loại không tồn tại"})
+			return
+		}
+		categoryID = uint64(category.ID)
+	}
+
+	statuses := []string{models.StatusPublished}
+	userID := middleware.CurrentUserID(c)
+	if (userID != 0 && uint(authorID) == userID) || auth.HasPermission(c.GetString("userRole"), auth.PermBookManageAny) || isModerator(c) {
+		statuses = nil
+		if status := c.Query("status"); status != "" {
+			statuses = []string{status}
+		}
+	}
+
+	books, total, err := h.bookRepo.ListBooks(repository.BookFilter{
+		AuthorID:   uint(authorID),
+		CategoryID: uint(categoryID),
+		TagSlug:    slug.Make(c.Query("tag")),
+		Statuses:   statuses,
+		Page:       page,
+		Limit:      limit,
+	})
+	if err != nil {
+		serverError(c, err, "Không thể lấy danh sách truyện")
+		return
+	}
+
+	items := make([]dto.BookResponse, 0, len(books))
+	for i := range books {
+		items = append(items, toBookResponse(&books[i]))
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data: dto.BookListResponse{
+			Items: items,
+			Total: total,
+			Page:  page,
+			Limit: limit,
+		},
+	})
+}
+
+// GetBook godoc
+// @Summary Lấy truyện theo ID
+// @Tags Books
+// @Produce json
+// @Param id path int true "Book ID"
+// @Success 200 {object} dto.ApiResponse{data=dto.BookResponse}
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id} [get]
+func (h *BookHandler) GetBook(c *gin.Context) {
+	book := h.loadBook(c)
+	if book == nil {
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    toBookResponse(book),
+	})
+}
+
+// UpdateBook godoc
+// @Summary Cập nhật truyện
+// @Description Chỉ tác giả của truyện hoặc admin được sửa. category_ids/tags thay toàn bộ danh sách cũ.
+// @Tags Books
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param book body dto.UpdateBookRequest true "Các trường cần sửa"
+// @Success 200 {object} dto.ApiResponse{data=dto.BookResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id} [put]
+func (h *BookHandler) UpdateBook(c *gin.Context) {
+	book := h.loadOwnedBook(c)
+	if book == nil {
+		return
+	}
+
+	var input dto.UpdateBookRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+
+	if input.Title != nil {
+		title := strings.TrimSpace(*input.Title)
+		if title == "" {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Tên truyện không được để trống"})
+			return
+		}
+		book.Title = title
+	}
+	if input.CategoryIDs != nil {
+		categories, ok := h.loadCategories(c, *input.CategoryIDs)
+		if !ok {
+			return
+		}
+		book.Categories = categories
+	}
+	if input.Tags != nil {
+		tags, ok := parseTags(c, *input.Tags)
+		if !ok {
+			return
+		}
+		book.Tags = tags
+	}
+	if input.Description != nil {
+		book.Description = *input.Description
+	}
+	if input.IsPremium != nil {
+		book.IsPremium = *input.IsPremium
+	}
+	if input.Price != nil {
+		if *input.Price < 0 {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Giá không được âm"})
+			return
+		}
+		book.Price = *input.Price
+	}
+	// Truyện đang hiển thị hoặc hẹn giờ phải luôn thỏa điều kiện xuất bản
+	if requiresPublishCheck(book.Status) && !writePublicationError(c, publishing.CheckBook(book)) {
+		return
+	}
+
+	if err := h.bookRepo.UpdateBook(book); err != nil {
+		serverError(c, err, "Cập nhật thất bại")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Cập nhật thành công",
+		Data:    toBookResponse(book),
+	})
+}
+
+// DeleteBook godoc
+// @Summary Xóa truyện
+// @Description Xóa mềm truyện cùng toàn bộ chương. Chỉ tác giả hoặc admin được xóa.
+// @Tags Books
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id} [delete]
+func (h *BookHandler) DeleteBook(c *gin.Context) {
+	book := h.loadOwnedBook(c)
+	if book == nil {
+		return
+	}
+
+	if err := h.bookRepo.DeleteBook(book.ID); err != nil {
+		serverError(c, err, "Xóa thất bại")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Đã xóa truyện ID " + strconv.Itoa(int(book.ID)),
+	})
+}
+
+// loadBook đọc path param :id và lấy truyện tương ứng; truyện chưa hiển thị chỉ trả về cho
+// tác giả/admin. Trả về nil khi có lỗi, lúc đó response lỗi đã được ghi.
+func (h *BookHandler) loadBook(c *gin.Context) *models.Book {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "ID không hợp lệ"})
+		return nil
+	}
+
+	book, err := h.bookRepo.GetBookByID(uint(id))
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return nil
+	}
+	// Người đọc không thấy truyện nháp, hẹn giờ hoặc bị gỡ
+	if book == nil || (!book.IsVisible() && !canManageBook(c, book) && !isModerator(c)) {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Truyện không tồn tại"})
+		return nil
+	}
+
+	return book
+}
+
+// loadOwnedBook giống loadBook nhưng chỉ trả về truyện khi user hiện tại là tác giả
+// (Book.AuthorID) hoặc có quyền quản lý mọi truyện, ngược lại trả 403
+func (h *BookHandler) loadOwnedBook(c *gin.Context) *models.Book {
+	book := h.loadBook(c)
+	if book == nil {
+		return nil
+	}
+
+	if !canManageBook(c, book) {
+		c.JSON(http.StatusForbidden, dto.ApiResponse{
+			Success: false,
+			Message: "Chỉ tác giả của truyện mới được thực hiện thao tác này",
+		})
+		return nil
+	}
+
+	return book
+}
+
+// canManageBook kiểm tra user hiện tại là tác giả của truyện hoặc có quyền quản lý mọi truyện
+func canManageBook(c *gin.Context, book *models.Book) bool {
+	userID := middleware.CurrentUserID(c)
+	return (userID != 0 && book.AuthorID == userID) ||
+		auth.HasPermission(c.GetString("userRole"), auth.PermBookManageAny)
+}
+
+// loadCategories lấy các thể loại theo ID (bỏ trùng), ghi lỗi và trả về false nếu có ID không tồn tại
+func (h *BookHandler) loadCategories(c *gin.Context, ids []uint) ([]models.Category, bool) {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) > maxBookCategories {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Truyện có tối đa " + strconv.Itoa(maxBookCategories) + " thể loại"})
+		return nil, false
+	}
+
+	categories, err := h.categoryRepo.GetCategoriesByIDs(unique)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return nil, false
+	}
+	if len(categories) != len(unique) {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Thể loại không tồn tại"})
+		return nil, false
+	}
+
+	return categories, true
+}
+
+// parseTags chuẩn hóa tên thẻ (bỏ khoảng trắng thừa) và sinh slug, thẻ trùng slug chỉ giữ lần đầu.
+// Ghi lỗi và trả về false nếu có thẻ không hợp lệ.
+func parseTags(c *gin.Context, names []string) ([]models.Tag, bool) {
+	seen := make(map[string]bool, len(names))
+	tags := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		name = strings.Join(strings.Fields(name), " ")
+		tagSlug := slug.Make(name)
+		if tagSlug == "" || utf8.RuneCountInString(name) > maxTagLength {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{
+				Success: false,
+				Message: "Thẻ \"" + name + "\" không hợp lệ (tối đa " + strconv.Itoa(maxTagLength) + " ký tự, phải có chữ hoặc số)",
+			})
+			return nil, false
+		}
+		if seen[tagSlug] {
+			continue
+		}
+		seen[tagSlug] = true
+		tags = append(tags, models.Tag{Name: name, Slug: tagSlug})
+	}
+	if len(tags) > maxBookTags {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Truyện có tối đa " + strconv.Itoa(maxBookTags) + " thẻ"})
+		return nil, false
+	}
+
+	return tags, true
+}
+
+// parsePagination đọc page/limit từ query, mặc định trang 1, 20 bản ghi, tối đa 100
+func parsePagination(c *gin.Context) (page, limit int) {
+	page, _ = strconv.Atoi(c.Query("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ = strconv.Atoi(c.Query("limit"))
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return page, limit
+}
+
+// toBookResponse chuyển models.Book sang DTO trả về cho client
+func toBookResponse(book *models.Book) dto.BookResponse {
+	resp := dto.BookResponse{
+		ID:          book.ID,
+		Title:       book.Title,
+		AuthorID:    book.AuthorID,
+		Categories:  make([]dto.BookCategoryRef, 0, len(book.Categories)),
+		Tags:        make([]dto.TagRef, 0, len(book.Tags)),
+		Description: book.Description,
+		IsPremium:   book.IsPremium,
+		Price:       book.Price,
+		Status:      book.Status,
+		PublishAt:   book.PublishAt,
+		PublishedAt: book.PublishedAt,
+		CreatedAt:   book.CreatedAt,
+		UpdatedAt:   book.UpdatedAt,
+	}
+	for _, category := range book.Categories {
+		resp.Categories = append(resp.Categories, dto.BookCategoryRef{ID: category.ID, Name: category.Name, Slug: category.Slug})
+	}
+	for _, tag := range book.Tags {
+		resp.Tags = append(resp.Tags, dto.TagRef{Name: tag.Name, Slug: tag.Slug})
+	}
+	return resp
+}
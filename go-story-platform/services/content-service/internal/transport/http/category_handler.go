@@ -0,0 +1,348 @@
+package http
+
+import (
+	"content-service/internal/models"
+	"content-service/internal/repository"
+	"content-service/internal/slug"
+	"content-service/internal/transport/http/dto"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CategoryHandler xử lý các request liên quan đến thể loại
+type CategoryHandler struct {
+	repo *repository.CategoryRepository
+}
+
+// NewCategoryHandler tạo CategoryHandler với repo được truyền vào
+func NewCategoryHandler(repo *repository.CategoryRepository) *CategoryHandler {
+	return &CategoryHandler{repo: repo}
+}
+
+// ListCategories godoc
+// @Summary Lấy danh sách thể loại
+// @Tags Categories
+// @Produce json
+// @Success 200 {object} dto.ApiResponse{data=[]dto.CategoryResponse}
+// @Router /categories [get]
+func (h *CategoryHandler) ListCategories(c *gin.Context) {
+	categories, err := h.repo.GetAllCategories()
+	if err != nil {
+		serverError(c, err, "Không thể lấy danh sách thể loại")
+		return
+	}
+
+	items := make([]dto.CategoryResponse, 0, len(categories))
+	for i := range categories {
+		items = append(items, toCategoryResponse(&categories[i]))
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    items,
+	})
+}
+
+// GetCategoryTree godoc
+// @Summary Lấy cây thể loại
+// @Description Các thể loại gốc kèm thể loại con lồng nhau, sắp theo tên. book_count là số truyện đã xuất bản
+// @Description của thể loại và mọi thể loại con cháu. Dùng GET /books?category=<slug> để xem truyện của một nhánh.
+// @Tags Categories
+// @Produce json
+// @Success 200 {object} dto.ApiResponse{data=[]dto.CategoryNode}
+// @Router /categories/tree [get]
+func (h *CategoryHandler) GetCategoryTree(c *gin.Context) {
+	categories, err := h.repo.GetAllCategories()
+	if err != nil {
+		serverError(c, err, "Không thể lấy danh sách thể loại")
+		return
+	}
+	counts, err := h.repo.CountPublishedBooks()
+	if err != nil {
+		serverError(c, err, "Không thể lấy danh sách thể loại")
+		return
+	}
+
+	bookCounts := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		bookCounts[count.CategoryID] = count.Count
+	}
+	// categories đã sắp theo tên nên con của mỗi nút cũng theo tên
+	children := make(map[uint][]*models.Category)
+	var roots []*models.Category
+	for i := range categories {
+		category := &categories[i]
+		if category.ParentID == nil {
+			roots = append(roots, category)
+			continue
+		}
+		children[*category.ParentID] = append(children[*category.ParentID], category)
+	}
+
+	var build func(nodes []*models.Category) []dto.CategoryNode
+	build = func(nodes []*models.Category) []dto.CategoryNode {
+		items := make([]dto.CategoryNode, 0, len(nodes))
+		for _, node := range nodes {
+			items = append(items, dto.CategoryNode{
+				ID:        node.ID,
+				Name:      node.Name,
+				Slug:      node.Slug,
+				BookCount: bookCounts[node.ID],
+				Children:  build(children[node.ID]),
+			})
+		}
+		return items
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    build(roots),
+	})
+}
+
+// GetCategory godoc
+// @Summary Lấy thể loại theo ID
+// @Tags Categories
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} dto.ApiResponse{data=dto.CategoryResponse}
+// @Failure 404 {object} dto.ApiResponse
+// @Router /categories/{id} [get]
+func (h *CategoryHandler) GetCategory(c *gin.Context) {
+	category := h.loadCategory(c)
+	if category == nil {
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    toCategoryResponse(category),
+	})
+}
+
+// CreateCategory godoc
+// @Summary Tạo thể loại
+// @Description Chỉ admin. Slug bỏ trống sẽ được sinh tự động từ tên, chữ tiếng Việt được bỏ dấu
+// @Description ("Kiếm Hiệp" → "kiem-hiep"). parent_slug là thể loại cha, bỏ trống nếu là thể loại gốc.
+// @Tags Categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param category body dto.CategoryRequest true "Thông tin thể loại"
+// @Success 201 {object} dto.ApiResponse{data=dto.CategoryResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 409 {object} dto.ApiResponse
+// @Router /categories [post]
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var input dto.CategoryRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+
+	category := models.Category{}
+	if !h.applyCategoryInput(c, &category, &input) {
+		return
+	}
+
+	if err := h.repo.CreateCategory(&category); err != nil {
+		serverError(c, err, "Không thể tạo thể loại")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Tạo thể loại thành công",
+		Data:    toCategoryResponse(&category),
+	})
+}
+
+// UpdateCategory godoc
+// @Summary Cập nhật thể loại
+// @Description Chỉ admin. Không thể chọn chính thể loại hoặc thể loại con cháu của nó làm thể loại cha.
+// @Tags Categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param category body dto.CategoryRequest true "Thông tin thể loại"
+// @Success 200 {object} dto.ApiResponse{data=dto.CategoryResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Failure 409 {object} dto.ApiResponse
+// @Router /categories/{id} [put]
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	category := h.loadCategory(c)
+	if category == nil {
+		return
+	}
+
+	var input dto.CategoryRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+	if !h.applyCategoryInput(c, category, &input) {
+		return
+	}
+
+	if err := h.repo.UpdateCategory(category); err != nil {
+		serverError(c, err, "Cập nhật thất bại")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Cập nhật thành công",
+		Data:    toCategoryResponse(category),
+	})
+}
+
+// DeleteCategory godoc
+// @Summary Xóa thể loại
+// @Description Chỉ admin. Thể loại con được chuyển lên thể loại cha, các truyện được bỏ khỏi thể loại.
+// @Tags Categories
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Success 200 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /categories/{id} [delete]
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	category := h.loadCategory(c)
+	if category == nil {
+		return
+	}
+
+	if err := h.repo.DeleteCategory(category); err != nil {
+		serverError(c, err, "Xóa thất bại")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Đã xóa thể loại ID " + strconv.Itoa(int(category.ID)),
+	})
+}
+
+// loadCategory đọc path param :id và lấy thể loại tương ứng.
+// Trả về nil khi có lỗi, lúc đó response lỗi đã được ghi.
+func (h *CategoryHandler) loadCategory(c *gin.Context) *models.Category {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "ID không hợp lệ"})
+		return nil
+	}
+
+	category, err := h.repo.GetCategoryByID(uint(id))
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return nil
+	}
+	if category == nil {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Thể loại không tồn tại"})
+		return nil
+	}
+
+	return category
+}
+
+// applyCategoryInput kiểm tra tên/slug/thể loại cha rồi gán vào category. Slug phải là duy nhất.
+func (h *CategoryHandler) applyCategoryInput(c *gin.Context, category *models.Category, input *dto.CategoryRequest) bool {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Tên thể loại không được để trống"})
+		return false
+	}
+
+	categorySlug := slug.Make(input.Slug)
+	if categorySlug == "" {
+		categorySlug = slug.Make(name)
+	}
+	if categorySlug == "" {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Slug không hợp lệ"})
+		return false
+	}
+
+	existing, err := h.repo.GetCategoryBySlug(categorySlug)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return false
+	}
+	if existing != nil && existing.ID != category.ID {
+		c.JSON(http.StatusConflict, dto.ApiResponse{Success: false, Message: "Slug đã tồn tại"})
+		return false
+	}
+
+	parent, ok := h.resolveParent(c, category, strings.TrimSpace(input.ParentSlug))
+	if !ok {
+		return false
+	}
+
+	category.Name = name
+	category.Slug = categorySlug
+	category.Parent = parent
+	category.ParentID = nil
+	if parent != nil {
+		category.ParentID = &parent.ID
+	}
+	return true
+}
+
+// resolveParent lấy thể loại cha theo slug (rỗng = thể loại gốc) và chặn việc tạo vòng:
+// thể loại cha không được là chính category hoặc thể loại con cháu của nó
+func (h *CategoryHandler) resolveParent(c *gin.Context, category *models.Category, parentSlug string) (*models.Category, bool) {
+	if parentSlug == "" {
+		return nil, true
+	}
+
+	parent, err := h.repo.GetCategoryBySlug(parentSlug)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return nil, false
+	}
+	if parent == nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Thể loại cha không tồn tại"})
+		return nil, false
+	}
+	if category.ID == 0 {
+		return parent, true
+	}
+
+	subtree, err := h.repo.GetDescendantIDs(category.ID)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return nil, false
+	}
+	for _, id := range subtree {
+		if id == parent.ID {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Không thể chọn chính thể loại hoặc thể loại con của nó làm thể loại cha"})
+			return nil, false
+		}
+	}
+
+	return parent, true
+}
+
+// toCategoryResponse chuyển models.Category sang DTO trả về cho client
+func toCategoryResponse(category *models.Category) dto.CategoryResponse {
+	resp := dto.CategoryResponse{
+		ID:       category.ID,
+		Name:     category.Name,
+		Slug:     category.Slug,
+		ParentID: category.ParentID,
+	}
+	if category.Parent != nil {
+		resp.ParentSlug = category.Parent.Slug
+	}
+	return resp
+}
@@ -0,0 +1,229 @@
+package http
+
+import (
+	"content-service/internal/models"
+	"content-service/internal/storage"
+	"content-service/internal/transport/http/dto"
+	"content-service/internal/transport/http/middleware"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UploadChapterContent godoc
+// @Summary Tải lên nội dung chương
+// @Description Chỉ tác giả của truyện hoặc admin được tải lên. Nội dung gửi dạng body thô hoặc multipart (field "file").
+// @Description Nội dung được lưu theo IPFS CID (CIDv1, raw/dag-pb, sha2-256), content_url trở thành ipfs://<cid>.
+// @Description Mỗi lần tải lên tạo một revision mới. Revision chỉ được xuất bản ngay khi publish=true
+// @Description hoặc chương chưa có nội dung xuất bản; ngược lại nó là bản nháp cho tới khi được xuất bản.
+// @Description Tải lại nội dung giống hệt revision mới nhất không tạo revision hay blob mới.
+// @Tags Chapters
+// @Accept octet-stream,mpfd
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Param file formData file false "File nội dung chương (khi gửi multipart)"
+// @Param note query string false "Ghi chú thay đổi"
+// @Param publish query bool false "Xuất bản ngay revision mới"
+// @Success 200 {object} dto.ApiResponse{data=dto.ChapterResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Failure 413 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number}/content [post]
+func (h *BookHandler) UploadChapterContent(c *gin.Context) {
+	book := h.loadOwnedBook(c)
+	if book == nil {
+		return
+	}
+	chapter := h.loadChapter(c, book)
+	if chapter == nil {
+		return
+	}
+	note := strings.TrimSpace(c.Query("note"))
+	if !validateChangeNote(c, note) {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxContent)
+	body, contentType, err := contentBody(c.Request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Không đọc được nội dung tải lên"})
+		return
+	}
+
+	// Ghi ra file tạm đồng thời tính CID, sau đó mới đưa vào BlobStore với khóa là CID
+	tmp, err := os.CreateTemp("", "chapter-content-*")
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	cid := storage.NewCIDBuilder()
+	if _, err := io.Copy(io.MultiWriter(tmp, cid), body); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, dto.ApiResponse{
+				Success: false,
+				Message: "Nội dung chương vượt quá " + strconv.FormatInt(h.maxContent, 10) + " byte",
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Không đọc được nội dung tải lên"})
+		return
+	}
+	if cid.Size() == 0 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Nội dung chương không được để trống"})
+		return
+	}
+
+	key := cid.Sum()
+	ctx := c.Request.Context()
+	if _, err := h.store.Stat(ctx, key); errors.Is(err, storage.ErrNotFound) {
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			serverError(c, err, "Lỗi server")
+			return
+		}
+		if err := h.store.Put(ctx, key, tmp, cid.Size(), contentType); err != nil {
+			serverError(c, fmt.Errorf("lưu blob %s: %w", key, err), "Không thể lưu nội dung chương")
+			return
+		}
+	} else if err != nil {
+		serverError(c, fmt.Errorf("kiểm tra blob %s: %w", key, err), "Không thể lưu nội dung chương")
+		return
+	}
+
+	rev := &models.ChapterRevision{
+		AuthorID:    middleware.CurrentUserID(c),
+		ContentURL:  "ipfs://" + key,
+		ContentCID:  key,
+		ContentSize: cid.Size(),
+		ContentType: contentType,
+		ChangeNote:  note,
+	}
+	if !h.saveRevision(c, chapter, rev, c.Query("publish") == "true") {
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Tải lên nội dung chương thành công",
+		Data:    toChapterResponse(book, chapter),
+	})
+}
+
+// DownloadChapterContent godoc
+// @Summary Đọc nội dung chương đã tải lên
+// @Description Trả nội dung dạng stream, hỗ trợ header Range (206 Partial Content) và If-None-Match theo ETag là CID.
+// @Description Chương trả phí cần đã mua chương hoặc trọn bộ truyện, hoặc là tác giả/admin.
+// @Tags Chapters
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Param Range header string false "Ví dụ bytes=0-1023"
+// @Success 200 {file} file
+// @Success 206 {file} file
+// @Failure 401 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Failure 416 {string} string
+// @Failure 503 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number}/content [get]
+func (h *BookHandler) DownloadChapterContent(c *gin.Context) {
+	book := h.loadBook(c)
+	if book == nil {
+		return
+	}
+	chapter := h.loadChapter(c, book)
+	if chapter == nil {
+		return
+	}
+
+	locked := isChapterLocked(book, chapter)
+	if locked && !h.checkChapterAccess(c, book, chapter) {
+		return
+	}
+	if chapter.ContentCID == "" {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Chương chưa có nội dung được tải lên"})
+		return
+	}
+
+	h.serveContent(c, chapter.ContentCID, chapter.ContentType, locked)
+}
+
+// serveContent stream blob có khóa cid về client, hỗ trợ Range và If-None-Match.
+// private = true với nội dung trả phí để proxy dùng chung không lưu lại.
+func (h *BookHandler) serveContent(c *gin.Context, cid, contentType string, private bool) {
+	obj, err := h.store.Open(c.Request.Context(), cid)
+	if errors.Is(err, storage.ErrNotFound) {
+		slog.ErrorContext(c.Request.Context(), "Blob của chương không tồn tại", "cid", cid)
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Không tìm thấy nội dung chương"})
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Không mở được blob", "cid", cid, "error", err)
+		c.JSON(http.StatusServiceUnavailable, dto.ApiResponse{Success: false, Message: "Không thể đọc nội dung chương, vui lòng thử lại sau"})
+		return
+	}
+	defer obj.Close()
+
+	// Nội dung thay đổi khi tải lại nên client phải hỏi lại server; ETag (CID) giúp trả 304 khi không đổi
+	cacheControl := "no-cache"
+	if private {
+		cacheControl = "private, no-cache"
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header := c.Writer.Header()
+	header.Set("Cache-Control", cacheControl)
+	header.Set("Content-Type", contentType)
+	header.Set("ETag", `"`+cid+`"`)
+	header.Set("X-Content-CID", cid)
+
+	http.ServeContent(c.Writer, c.Request, "", obj.Info().ModTime, obj)
+}
+
+// contentBody trả về luồng nội dung và content type của request tải lên: phần "file" nếu là
+// multipart, ngược lại là toàn bộ body
+func contentBody(r *http.Request) (io.Reader, string, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return r.Body, normalizeContentType(r.Header.Get("Content-Type")), nil
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, "", err
+	}
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, "", err
+		}
+		if part.FormName() == "file" {
+			return part, normalizeContentType(part.Header.Get("Content-Type")), nil
+		}
+	}
+}
+
+// normalizeContentType giữ content type client gửi nếu hợp lệ, mặc định là application/octet-stream
+func normalizeContentType(contentType string) string {
+	contentType = strings.TrimSpace(contentType)
+	if _, _, err := mime.ParseMediaType(contentType); err != nil || len(contentType) > 100 {
+		return "application/octet-stream"
+	}
+	return contentType
+}
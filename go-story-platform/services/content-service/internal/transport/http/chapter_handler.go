@@ -0,0 +1,373 @@
+package http
+
+import (
+	"content-service/internal/entitlement"
+	"content-service/internal/models"
+	"content-service/internal/publishing"
+	"content-service/internal/transport/http/dto"
+	"content-service/internal/transport/http/middleware"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListChapters godoc
+// @Summary Lấy danh sách chương của truyện
+// @Description Các chương được sắp xếp theo ChapterNumber tăng dần. Người đọc chỉ thấy chương published.
+// @Description content_url của chương bị khóa không được trả ở đây (trừ tác giả/admin), hãy lấy qua API từng chương.
+// @Tags Chapters
+// @Produce json
+// @Param id path int true "Book ID"
+// @Success 200 {object} dto.ApiResponse{data=[]dto.ChapterResponse}
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/chapters [get]
+func (h *BookHandler) ListChapters(c *gin.Context) {
+	book := h.loadBook(c)
+	if book == nil {
+		return
+	}
+
+	manager := canManageBook(c, book)
+	var statuses []string
+	if !manager {
+		statuses = []string{models.StatusPublished}
+	}
+	chapters, err := h.chapterRepo.ListChaptersByBook(book.ID, statuses...)
+	if err != nil {
+		serverError(c, err, "Không thể lấy danh sách chương")
+		return
+	}
+
+	items := make([]dto.ChapterResponse, 0, len(chapters))
+	for i := range chapters {
+		item := toChapterResponse(book, &chapters[i])
+		// Chương bị khóa không lộ địa chỉ nội dung: nội dung tải lên có content_url là ipfs://<cid>
+		if item.Locked && !manager {
+			item.ContentURL = ""
+			item.ContentCID = ""
+			item.ContentSize = 0
+		}
+		items = append(items, item)
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    items,
+	})
+}
+
+// GetChapter godoc
+// @Summary Lấy một chương theo số thứ tự
+// @Description Chương trả phí chỉ trả content_url khi người đọc đã mua chương hoặc trọn bộ truyện
+// @Description (kiểm tra qua payment-service), hoặc là tác giả/admin.
+// @Tags Chapters
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Success 200 {object} dto.ApiResponse{data=dto.ChapterResponse}
+// @Failure 401 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Failure 503 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number} [get]
+func (h *BookHandler) GetChapter(c *gin.Context) {
+	book := h.loadBook(c)
+	if book == nil {
+		return
+	}
+	chapter := h.loadChapter(c, book)
+	if chapter == nil {
+		return
+	}
+
+	data := toChapterResponse(book, chapter)
+	if data.Locked && !h.checkChapterAccess(c, book, chapter) {
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    data,
+	})
+}
+
+// CreateChapter godoc
+// @Summary Thêm chương mới
+// @Description Chỉ tác giả của truyện hoặc admin được thêm chương. Số thứ tự chương không được trùng.
+// @Description content_url (nếu có) được lưu thành revision 1. Chương mới ở trạng thái draft.
+// @Tags Chapters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param chapter body dto.ChapterRequest true "Thông tin chương"
+// @Success 201 {object} dto.ApiResponse{data=dto.ChapterResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 409 {object} dto.ApiResponse
+// @Router /books/{id}/chapters [post]
+func (h *BookHandler) CreateChapter(c *gin.Context) {
+	book := h.loadOwnedBook(c)
+	if book == nil {
+		return
+	}
+
+	var input dto.ChapterRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+	if !validateChapterInput(c, &input) {
+		return
+	}
+
+	existing, err := h.chapterRepo.GetChapterByNumber(book.ID, input.ChapterNumber)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	if existing != nil {
+		c.JSON(http.StatusConflict, dto.ApiResponse{Success: false, Message: "Số thứ tự chương đã tồn tại"})
+		return
+	}
+
+	chapter := models.Chapter{
+		BookID:        book.ID,
+		ChapterNumber: input.ChapterNumber,
+		Price:         input.Price,
+		Publication:   models.Publication{Status: models.StatusDraft},
+	}
+	// Link nội dung ban đầu trở thành revision 1
+	var rev *models.ChapterRevision
+	if input.ContentURL != "" {
+		rev = &models.ChapterRevision{
+			AuthorID:   middleware.CurrentUserID(c),
+			ContentURL: input.ContentURL,
+			ChangeNote: input.ChangeNote,
+		}
+	}
+	if err := h.chapterRepo.CreateChapterWithRevision(&chapter, rev); err != nil {
+		serverError(c, err, "Không thể tạo chương")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Tạo chương thành công",
+		Data:    toChapterResponse(book, &chapter),
+	})
+}
+
+// UpdateChapter godoc
+// @Summary Cập nhật chương
+// @Description Chỉ tác giả của truyện hoặc admin được sửa. Có thể đổi số thứ tự nếu số mới chưa được dùng.
+// @Description content_url mới được lưu thành revision mới, là bản nháp trừ khi publish=true.
+// @Tags Chapters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Param chapter body dto.ChapterRequest true "Thông tin chương"
+// @Success 200 {object} dto.ApiResponse{data=dto.ChapterResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Failure 409 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number} [put]
+func (h *BookHandler) UpdateChapter(c *gin.Context) {
+	book := h.loadOwnedBook(c)
+	if book == nil {
+		return
+	}
+	chapter := h.loadChapter(c, book)
+	if chapter == nil {
+		return
+	}
+
+	var input dto.ChapterRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+	if !validateChapterInput(c, &input) {
+		return
+	}
+
+	if input.ChapterNumber != chapter.ChapterNumber {
+		existing, err := h.chapterRepo.GetChapterByNumber(book.ID, input.ChapterNumber)
+		if err != nil {
+			serverError(c, err, "Lỗi server")
+			return
+		}
+		if existing != nil {
+			c.JSON(http.StatusConflict, dto.ApiResponse{Success: false, Message: "Số thứ tự chương đã tồn tại"})
+			return
+		}
+	}
+
+	chapter.ChapterNumber = input.ChapterNumber
+	chapter.Price = input.Price
+	if requiresPublishCheck(chapter.Status) && !writePublicationError(c, publishing.CheckChapter(book, chapter)) {
+		return
+	}
+	if err := h.chapterRepo.UpdateChapter(chapter); err != nil {
+		serverError(c, err, "Cập nhật thất bại")
+		return
+	}
+	// Bỏ trống content_url thì giữ nguyên nội dung; link mới được lưu thành revision mới
+	if input.ContentURL != "" {
+		rev := &models.ChapterRevision{
+			AuthorID:   middleware.CurrentUserID(c),
+			ContentURL: input.ContentURL,
+			ChangeNote: input.ChangeNote,
+		}
+		if !h.saveRevision(c, chapter, rev, input.Publish) {
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Cập nhật thành công",
+		Data:    toChapterResponse(book, chapter),
+	})
+}
+
+// DeleteChapter godoc
+// @Summary Xóa chương
+// @Description Chỉ tác giả của truyện hoặc admin được xóa
+// @Tags Chapters
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Success 200 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number} [delete]
+func (h *BookHandler) DeleteChapter(c *gin.Context) {
+	book := h.loadOwnedBook(c)
+	if book == nil {
+		return
+	}
+	chapter := h.loadChapter(c, book)
+	if chapter == nil {
+		return
+	}
+
+	if err := h.chapterRepo.DeleteChapter(chapter.ID); err != nil {
+		serverError(c, err, "Xóa thất bại")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Đã xóa chương " + strconv.Itoa(chapter.ChapterNumber),
+	})
+}
+
+// loadChapter đọc path param :number và lấy chương tương ứng của truyện; chương chưa hiển thị
+// chỉ trả về cho tác giả/admin. Trả về nil khi có lỗi, lúc đó response lỗi đã được ghi.
+func (h *BookHandler) loadChapter(c *gin.Context, book *models.Book) *models.Chapter {
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Số thứ tự chương không hợp lệ"})
+		return nil
+	}
+
+	chapter, err := h.chapterRepo.GetChapterByNumber(book.ID, number)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return nil
+	}
+	if chapter == nil || (!chapter.IsVisible() && !canManageBook(c, book) && !isModerator(c)) {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Chương không tồn tại"})
+		return nil
+	}
+
+	return chapter
+}
+
+// isChapterLocked cho biết chương có cần mua mới đọc được không: mọi chương của truyện premium
+// và các chương có giá bán lẻ
+func isChapterLocked(book *models.Book, chapter *models.Chapter) bool {
+	return book.IsPremium || chapter.Price > 0
+}
+
+// checkChapterAccess kiểm tra người đọc hiện tại được đọc chương bị khóa không.
+// Trả về false khi không được, lúc đó response lỗi đã được ghi. Nếu payment-service
+// không trả lời được thì từ chối (503) thay vì để lộ nội dung.
+func (h *BookHandler) checkChapterAccess(c *gin.Context, book *models.Book, chapter *models.Chapter) bool {
+	if canManageBook(c, book) {
+		return true
+	}
+
+	userID := middleware.CurrentUserID(c)
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, dto.ApiResponse{Success: false, Message: "Chương trả phí, bạn cần đăng nhập để đọc"})
+		return false
+	}
+
+	allowed, err := h.entitlements.CanRead(c.Request.Context(), entitlement.Request{
+		UserID:      userID,
+		BookID:      book.ID,
+		ChapterID:   chapter.ID,
+		AccessToken: c.GetString("accessToken"),
+	})
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Không kiểm tra được quyền đọc chương", "chapter_id", chapter.ID, "error", err)
+		c.JSON(http.StatusServiceUnavailable, dto.ApiResponse{Success: false, Message: "Không thể kiểm tra quyền đọc, vui lòng thử lại sau"})
+		return false
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, dto.ApiResponse{Success: false, Message: "Bạn cần mua chương này hoặc trọn bộ truyện để đọc"})
+		return false
+	}
+	return true
+}
+
+// validateChapterInput kiểm tra số thứ tự, giá và ghi chú thay đổi, ghi lỗi và trả về false nếu không hợp lệ
+func validateChapterInput(c *gin.Context, input *dto.ChapterRequest) bool {
+	if input.ChapterNumber < 1 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Số thứ tự chương phải lớn hơn 0"})
+		return false
+	}
+	input.ContentURL = strings.TrimSpace(input.ContentURL)
+	input.ChangeNote = strings.TrimSpace(input.ChangeNote)
+	if !validateChangeNote(c, input.ChangeNote) {
+		return false
+	}
+	if input.Price < 0 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Giá không được âm"})
+		return false
+	}
+	return true
+}
+
+// toChapterResponse chuyển models.Chapter sang DTO trả về cho client
+func toChapterResponse(book *models.Book, chapter *models.Chapter) dto.ChapterResponse {
+	return dto.ChapterResponse{
+		ID:                chapter.ID,
+		BookID:            chapter.BookID,
+		ChapterNumber:     chapter.ChapterNumber,
+		ContentURL:        chapter.ContentURL,
+		ContentCID:        chapter.ContentCID,
+		ContentSize:       chapter.ContentSize,
+		Price:             chapter.Price,
+		Locked:            isChapterLocked(book, chapter),
+		LatestRevision:    chapter.LatestRevision,
+		PublishedRevision: chapter.PublishedRevision,
+		Status:            chapter.Status,
+		PublishAt:         chapter.PublishAt,
+		PublishedAt:       chapter.PublishedAt,
+		CreatedAt:         chapter.CreatedAt,
+		UpdatedAt:         chapter.UpdatedAt,
+	}
+}
@@ -0,0 +1,456 @@
+package http
+
+import (
+	"content-service/internal/diff"
+	"content-service/internal/models"
+	"content-service/internal/transport/http/dto"
+	"content-service/internal/transport/http/middleware"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxChangeNoteLength là độ dài tối đa (ký tự) của ghi chú thay đổi
+const maxChangeNoteLength = 500
+
+// maxDiffContent là kích thước tối đa (byte) của mỗi revision khi so sánh
+const maxDiffContent = 2 << 20
+
+var (
+	errDiffTooLarge = errors.New("nội dung quá lớn để so sánh")
+	errDiffNotText  = errors.New("nội dung không phải văn bản UTF-8")
+)
+
+// ListChapterRevisions godoc
+// @Summary Lấy lịch sử revision của chương
+// @Description Chỉ tác giả của truyện hoặc admin được xem. Revision mới nhất đứng trước.
+// @Tags Chapter Revisions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Success 200 {object} dto.ApiResponse{data=[]dto.ChapterRevisionResponse}
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number}/revisions [get]
+func (h *BookHandler) ListChapterRevisions(c *gin.Context) {
+	book := h.loadOwnedBook(c)
+	if book == nil {
+		return
+	}
+	chapter := h.loadChapter(c, book)
+	if chapter == nil {
+		return
+	}
+
+	revisions, err := h.chapterRepo.ListRevisions(chapter.ID)
+	if err != nil {
+		serverError(c, err, "Không thể lấy lịch sử revision")
+		return
+	}
+
+	items := make([]dto.ChapterRevisionResponse, 0, len(revisions))
+	for i := range revisions {
+		items = append(items, toRevisionResponse(chapter, &revisions[i]))
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    items,
+	})
+}
+
+// GetChapterRevision godoc
+// @Summary Lấy một revision của chương
+// @Description Chỉ tác giả của truyện hoặc admin được xem
+// @Tags Chapter Revisions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Param revision path int true "Số revision"
+// @Success 200 {object} dto.ApiResponse{data=dto.ChapterRevisionResponse}
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number}/revisions/{revision} [get]
+func (h *BookHandler) GetChapterRevision(c *gin.Context) {
+	chapter, rev := h.loadOwnedRevision(c)
+	if rev == nil {
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    toRevisionResponse(chapter, rev),
+	})
+}
+
+// DownloadChapterRevision godoc
+// @Summary Đọc nội dung của một revision
+// @Description Chỉ tác giả của truyện hoặc admin được đọc, kể cả revision chưa xuất bản. Hỗ trợ header Range.
+// @Tags Chapter Revisions
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Param revision path int true "Số revision"
+// @Param Range header string false "Ví dụ bytes=0-1023"
+// @Success 200 {file} file
+// @Success 206 {file} file
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number}/revisions/{revision}/content [get]
+func (h *BookHandler) DownloadChapterRevision(c *gin.Context) {
+	_, rev := h.loadOwnedRevision(c)
+	if rev == nil {
+		return
+	}
+	if rev.ContentCID == "" {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Revision này chỉ là link ngoài: " + rev.ContentURL})
+		return
+	}
+
+	h.serveContent(c, rev.ContentCID, rev.ContentType, true)
+}
+
+// DiffChapterRevisions godoc
+// @Summary So sánh hai revision của chương
+// @Description So sánh revision from (mặc định là revision liền trước) với revision trong path, theo dòng hoặc theo từ.
+// @Description Ghép text của các op equal+delete ra nội dung cũ, equal+insert ra nội dung mới.
+// @Description Revision chỉ có link ngoài được so sánh theo link. Nội dung quá 2 MB hoặc tổng cộng quá 200.000 dòng/từ trả về 422.
+// @Tags Chapter Revisions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Param revision path int true "Số revision"
+// @Param from query int false "Revision gốc để so sánh"
+// @Param mode query string false "line (mặc định) hoặc word"
+// @Success 200 {object} dto.ApiResponse{data=dto.RevisionDiffResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Failure 422 {object} dto.ApiResponse
+// @Failure 503 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number}/revisions/{revision}/diff [get]
+func (h *BookHandler) DiffChapterRevisions(c *gin.Context) {
+	chapter, to := h.loadOwnedRevision(c)
+	if to == nil {
+		return
+	}
+
+	mode := c.DefaultQuery("mode", "line")
+	if mode != "line" && mode != "word" {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "mode phải là line hoặc word"})
+		return
+	}
+	fromNumber := to.Revision - 1
+	if v := c.Query("from"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Revision from không hợp lệ"})
+			return
+		}
+		fromNumber = n
+	}
+	if fromNumber < 1 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Không có revision trước để so sánh"})
+		return
+	}
+
+	from, err := h.chapterRepo.GetRevision(chapter.ID, fromNumber)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	if from == nil {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Revision from không tồn tại"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	oldText, err := h.revisionText(ctx, from)
+	var newText string
+	if err == nil {
+		newText, err = h.revisionText(ctx, to)
+	}
+	var result dto.RevisionDiffResponse
+	if err == nil {
+		result, err = buildDiff(from.Revision, to.Revision, mode, oldText, newText)
+	}
+	if errors.Is(err, errDiffTooLarge) || errors.Is(err, errDiffNotText) || errors.Is(err, diff.ErrTooLarge) {
+		c.JSON(http.StatusUnprocessableEntity, dto.ApiResponse{Success: false, Message: "Không thể so sánh: " + err.Error()})
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Không đọc được nội dung revision", "chapter_id", chapter.ID, "error", err)
+		c.JSON(http.StatusServiceUnavailable, dto.ApiResponse{Success: false, Message: "Không thể đọc nội dung revision, vui lòng thử lại sau"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "So sánh thành công",
+		Data:    result,
+	})
+}
+
+// RestoreChapterRevision godoc
+// @Summary Khôi phục một revision cũ
+// @Description Tạo revision mới có nội dung giống revision được chọn; lịch sử không bị sửa.
+// @Description Revision mới là bản nháp trừ khi publish=true.
+// @Tags Chapter Revisions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Param revision path int true "Số revision"
+// @Param body body dto.RestoreRevisionRequest false "Ghi chú và tùy chọn xuất bản"
+// @Success 200 {object} dto.ApiResponse{data=dto.ChapterResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number}/revisions/{revision}/restore [post]
+func (h *BookHandler) RestoreChapterRevision(c *gin.Context) {
+	book := h.loadOwnedBook(c)
+	if book == nil {
+		return
+	}
+	chapter := h.loadChapter(c, book)
+	if chapter == nil {
+		return
+	}
+	source := h.loadRevision(c, chapter)
+	if source == nil {
+		return
+	}
+
+	var input dto.RestoreRevisionRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+			return
+		}
+	}
+	note := strings.TrimSpace(input.ChangeNote)
+	if !validateChangeNote(c, note) {
+		return
+	}
+	if note == "" {
+		note = "Khôi phục từ revision " + strconv.Itoa(source.Revision)
+	}
+
+	rev := &models.ChapterRevision{
+		AuthorID:    middleware.CurrentUserID(c),
+		ContentURL:  source.ContentURL,
+		ContentCID:  source.ContentCID,
+		ContentSize: source.ContentSize,
+		ContentType: source.ContentType,
+		ChangeNote:  note,
+	}
+	if err := h.chapterRepo.AddRevision(chapter, rev, input.Publish); err != nil {
+		serverError(c, err, "Không thể khôi phục revision")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Đã khôi phục revision " + strconv.Itoa(source.Revision) + " thành revision " + strconv.Itoa(rev.Revision),
+		Data:    toChapterResponse(book, chapter),
+	})
+}
+
+// PublishChapterRevision godoc
+// @Summary Xuất bản một revision
+// @Description Ghim revision làm nội dung người đọc nhìn thấy; có thể ghim lại revision cũ hơn.
+// @Tags Chapter Revisions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Param revision path int true "Số revision"
+// @Success 200 {object} dto.ApiResponse{data=dto.ChapterResponse}
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number}/revisions/{revision}/publish [post]
+func (h *BookHandler) PublishChapterRevision(c *gin.Context) {
+	book := h.loadOwnedBook(c)
+	if book == nil {
+		return
+	}
+	chapter := h.loadChapter(c, book)
+	if chapter == nil {
+		return
+	}
+	rev := h.loadRevision(c, chapter)
+	if rev == nil {
+		return
+	}
+
+	if err := h.chapterRepo.PublishRevision(chapter, rev); err != nil {
+		serverError(c, err, "Xuất bản thất bại")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Đã xuất bản revision " + strconv.Itoa(rev.Revision),
+		Data:    toChapterResponse(book, chapter),
+	})
+}
+
+// saveRevision lưu nội dung mới thành revision của chương. Nếu nội dung giống hệt revision mới nhất
+// thì không tạo revision mới, chỉ xuất bản nó khi được yêu cầu.
+// Trả về false khi có lỗi, lúc đó response lỗi đã được ghi.
+func (h *BookHandler) saveRevision(c *gin.Context, chapter *models.Chapter, rev *models.ChapterRevision, publish bool) bool {
+	if chapter.LatestRevision > 0 {
+		latest, err := h.chapterRepo.GetRevision(chapter.ID, chapter.LatestRevision)
+		if err != nil {
+			serverError(c, err, "Lỗi server")
+			return false
+		}
+		if latest != nil && latest.ContentURL == rev.ContentURL && latest.ContentCID == rev.ContentCID {
+			if !publish || chapter.PublishedRevision == latest.Revision {
+				return true
+			}
+			if err := h.chapterRepo.PublishRevision(chapter, latest); err != nil {
+				serverError(c, err, "Cập nhật thất bại")
+				return false
+			}
+			return true
+		}
+	}
+
+	if err := h.chapterRepo.AddRevision(chapter, rev, publish); err != nil {
+		serverError(c, err, "Không thể lưu revision")
+		return false
+	}
+	return true
+}
+
+// validateChangeNote kiểm tra độ dài ghi chú thay đổi, ghi lỗi và trả về false nếu quá dài
+func validateChangeNote(c *gin.Context, note string) bool {
+	if utf8.RuneCountInString(note) > maxChangeNoteLength {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Ghi chú thay đổi tối đa " + strconv.Itoa(maxChangeNoteLength) + " ký tự"})
+		return false
+	}
+	return true
+}
+
+// loadOwnedRevision lấy truyện (kiểm tra quyền sửa), chương và revision theo path param.
+// Trả về revision nil khi có lỗi, lúc đó response lỗi đã được ghi.
+func (h *BookHandler) loadOwnedRevision(c *gin.Context) (*models.Chapter, *models.ChapterRevision) {
+	book := h.loadOwnedBook(c)
+	if book == nil {
+		return nil, nil
+	}
+	chapter := h.loadChapter(c, book)
+	if chapter == nil {
+		return nil, nil
+	}
+	return chapter, h.loadRevision(c, chapter)
+}
+
+// loadRevision đọc path param :revision và lấy revision tương ứng của chương.
+// Trả về nil khi có lỗi, lúc đó response lỗi đã được ghi.
+func (h *BookHandler) loadRevision(c *gin.Context, chapter *models.Chapter) *models.ChapterRevision {
+	number, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Số revision không hợp lệ"})
+		return nil
+	}
+
+	rev, err := h.chapterRepo.GetRevision(chapter.ID, number)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return nil
+	}
+	if rev == nil {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Revision không tồn tại"})
+		return nil
+	}
+
+	return rev
+}
+
+// revisionText đọc nội dung revision dưới dạng văn bản để so sánh; revision chỉ có link ngoài
+// được biểu diễn bằng chính link đó
+func (h *BookHandler) revisionText(ctx context.Context, rev *models.ChapterRevision) (string, error) {
+	if rev.ContentCID == "" {
+		return rev.ContentURL, nil
+	}
+	if rev.ContentSize > maxDiffContent {
+		return "", errDiffTooLarge
+	}
+
+	obj, err := h.store.Open(ctx, rev.ContentCID)
+	if err != nil {
+		return "", err
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(io.LimitReader(obj, maxDiffContent+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > maxDiffContent {
+		return "", errDiffTooLarge
+	}
+	if !utf8.Valid(data) {
+		return "", errDiffNotText
+	}
+	return string(data), nil
+}
+
+// buildDiff so sánh hai văn bản theo mode và chuyển kết quả sang DTO.
+// Trả về diff.ErrTooLarge nếu văn bản có quá nhiều dòng/từ.
+func buildDiff(from, to int, mode, oldText, newText string) (dto.RevisionDiffResponse, error) {
+	compare, split := diff.Lines, diff.SplitLines
+	if mode == "word" {
+		compare, split = diff.Words, diff.SplitWords
+	}
+	ops, err := compare(oldText, newText)
+	if err != nil {
+		return dto.RevisionDiffResponse{}, err
+	}
+	insertions, deletions := diff.Stats(ops, split)
+
+	items := make([]dto.DiffOp, 0, len(ops))
+	for _, op := range ops {
+		items = append(items, dto.DiffOp{Op: op.Op, Text: op.Text})
+	}
+	return dto.RevisionDiffResponse{
+		From:       from,
+		To:         to,
+		Mode:       mode,
+		Insertions: insertions,
+		Deletions:  deletions,
+		Ops:        items,
+	}, nil
+}
+
+// toRevisionResponse chuyển models.ChapterRevision sang DTO trả về cho client
+func toRevisionResponse(chapter *models.Chapter, rev *models.ChapterRevision) dto.ChapterRevisionResponse {
+	return dto.ChapterRevisionResponse{
+		Revision:    rev.Revision,
+		AuthorID:    rev.AuthorID,
+		ContentURL:  rev.ContentURL,
+		ContentCID:  rev.ContentCID,
+		ContentSize: rev.ContentSize,
+		ContentType: rev.ContentType,
+		ChangeNote:  rev.ChangeNote,
+		Published:   rev.Revision == chapter.PublishedRevision,
+		Latest:      rev.Revision == chapter.LatestRevision,
+		CreatedAt:   rev.CreatedAt,
+	}
+}
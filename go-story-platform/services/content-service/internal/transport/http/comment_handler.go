@@ -0,0 +1,414 @@
+package http
+
+import (
+	"content-service/internal/models"
+	"content-service/internal/repository"
+	"content-service/internal/transport/http/dto"
+	"content-service/internal/transport/http/middleware"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	maxCommentLength = 2000 // Độ dài tối đa của bình luận (ký tự)
+	maxCommentDepth  = 5    // Độ sâu tối đa của trả lời, bình luận gốc có độ sâu 0
+)
+
+// CommentHandler xử lý bình luận chương
+type CommentHandler struct {
+	repo  *repository.CommentRepository
+	books *BookHandler // Dùng lại việc tải truyện/chương và kiểm tra quyền của BookHandler
+}
+
+// NewCommentHandler tạo CommentHandler với repo và BookHandler được truyền vào
+func NewCommentHandler(repo *repository.CommentRepository, books *BookHandler) *CommentHandler {
+	return &CommentHandler{repo: repo, books: books}
+}
+
+// ListComments godoc
+// @Summary Lấy bình luận gốc của chương
+// @Description Mới nhất trước, mỗi bình luận kèm reply_count; dùng API replies để lấy trả lời.
+// @Description Bình luận đã xóa hoặc bị ẩn vẫn có trong danh sách (body rỗng) để giữ mạch trả lời;
+// @Description người viết và admin vẫn thấy nội dung bình luận bị ẩn.
+// @Tags Comments
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Param cursor query string false "Con trỏ trang tiếp theo"
+// @Param limit query int false "Số bản ghi mỗi trang (mặc định 20, tối đa 100)"
+// @Success 200 {object} dto.ApiResponse{data=dto.CommentListResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number}/comments [get]
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	chapter := h.loadChapter(c)
+	if chapter == nil {
+		return
+	}
+	before, limit, ok := parseCursor(c)
+	if !ok {
+		return
+	}
+
+	comments, err := h.repo.ListComments(chapter.ID, before, limit+1)
+	if err != nil {
+		serverError(c, err, "Không thể lấy bình luận")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    toCommentList(c, comments, limit),
+	})
+}
+
+// ListReplies godoc
+// @Summary Lấy trả lời của một bình luận
+// @Description Các trả lời trực tiếp, cũ nhất trước.
+// @Tags Comments
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Param comment path int true "Comment ID"
+// @Param cursor query string false "Con trỏ trang tiếp theo"
+// @Param limit query int false "Số bản ghi mỗi trang (mặc định 20, tối đa 100)"
+// @Success 200 {object} dto.ApiResponse{data=dto.CommentListResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number}/comments/{comment}/replies [get]
+func (h *CommentHandler) ListReplies(c *gin.Context) {
+	chapter := h.loadChapter(c)
+	if chapter == nil {
+		return
+	}
+	parent := h.loadComment(c, chapter)
+	if parent == nil {
+		return
+	}
+	after, limit, ok := parseCursor(c)
+	if !ok {
+		return
+	}
+
+	replies, err := h.repo.ListReplies(parent.ID, after, limit+1)
+	if err != nil {
+		serverError(c, err, "Không thể lấy bình luận")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    toCommentList(c, replies, limit),
+	})
+}
+
+// CreateComment godoc
+// @Summary Bình luận chương
+// @Description Gửi parent_id để trả lời một bình luận (tối đa 5 cấp). Không trả lời được bình luận đã xóa hoặc bị ẩn.
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Param body body dto.CreateCommentRequest true "Nội dung bình luận"
+// @Success 201 {object} dto.ApiResponse{data=dto.CommentResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 401 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number}/comments [post]
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	chapter := h.loadChapter(c)
+	if chapter == nil {
+		return
+	}
+
+	var input dto.CreateCommentRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+	body, ok := validateCommentBody(c, input.Body)
+	if !ok {
+		return
+	}
+
+	comment := models.ChapterComment{
+		ChapterID:  chapter.ID,
+		BookID:     chapter.BookID,
+		UserID:     middleware.CurrentUserID(c),
+		Body:       body,
+		Spoiler:    input.Spoiler,
+		Moderation: models.Moderation{Status: models.VisibilityVisible},
+	}
+	if input.ParentID != nil {
+		parent, err := h.repo.GetCommentByID(*input.ParentID)
+		if err != nil {
+			serverError(c, err, "Lỗi server")
+			return
+		}
+		if parent == nil || parent.ChapterID != chapter.ID {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Bình luận được trả lời không tồn tại"})
+			return
+		}
+		if !parent.IsVisible() {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Không thể trả lời bình luận đã xóa hoặc bị ẩn"})
+			return
+		}
+		if parent.Depth >= maxCommentDepth {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Chuỗi trả lời đã đạt độ sâu tối đa"})
+			return
+		}
+		comment.ParentID = &parent.ID
+		comment.Depth = parent.Depth + 1
+	}
+
+	if err := h.repo.CreateComment(&comment); err != nil {
+		serverError(c, err, "Không thể đăng bình luận")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Đăng bình luận thành công",
+		Data:    toCommentResponse(c, &comment),
+	})
+}
+
+// UpdateComment godoc
+// @Summary Sửa bình luận
+// @Description Chỉ người viết. Bình luận bị admin ẩn vẫn ở trạng thái ẩn sau khi sửa.
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Param comment path int true "Comment ID"
+// @Param body body dto.UpdateCommentRequest true "Nội dung mới"
+// @Success 200 {object} dto.ApiResponse{data=dto.CommentResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number}/comments/{comment} [put]
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	comment := h.loadOwnedComment(c)
+	if comment == nil {
+		return
+	}
+
+	var input dto.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+	body, ok := validateCommentBody(c, input.Body)
+	if !ok {
+		return
+	}
+
+	if body != comment.Body || input.Spoiler != comment.Spoiler {
+		now := time.Now()
+		comment.Body = body
+		comment.Spoiler = input.Spoiler
+		comment.EditedAt = &now
+		if err := h.repo.UpdateComment(comment); err != nil {
+			serverError(c, err, "Cập nhật thất bại")
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Cập nhật thành công",
+		Data:    toCommentResponse(c, comment),
+	})
+}
+
+// DeleteComment godoc
+// @Summary Xóa bình luận
+// @Description Chỉ người viết. Nội dung bị xóa nhưng bình luận vẫn giữ chỗ trong luồng để các trả lời không bị mất.
+// @Tags Comments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Param comment path int true "Comment ID"
+// @Success 200 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number}/comments/{comment} [delete]
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	comment := h.loadOwnedComment(c)
+	if comment == nil {
+		return
+	}
+
+	comment.Status = models.VisibilityDeleted
+	comment.Body = ""
+	comment.Spoiler = false
+	if err := h.repo.UpdateComment(comment); err != nil {
+		serverError(c, err, "Xóa thất bại")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{Success: true, Message: "Đã xóa bình luận"})
+}
+
+// ModerateComment godoc
+// @Summary Ẩn hoặc hiện lại bình luận
+// @Description Chỉ admin. Bình luận bị ẩn vẫn giữ chỗ trong luồng nhưng người khác không thấy nội dung.
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Param comment path int true "Comment ID"
+// @Param body body dto.ModerationRequest true "Ẩn/hiện và lý do"
+// @Success 200 {object} dto.ApiResponse{data=dto.CommentResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number}/comments/{comment}/moderation [put]
+func (h *CommentHandler) ModerateComment(c *gin.Context) {
+	chapter := h.loadChapter(c)
+	if chapter == nil {
+		return
+	}
+	comment := h.loadComment(c, chapter)
+	if comment == nil {
+		return
+	}
+
+	var input dto.ModerationRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+	if !applyModeration(c, &comment.Moderation, &input) {
+		return
+	}
+
+	if err := h.repo.UpdateComment(comment); err != nil {
+		serverError(c, err, "Cập nhật thất bại")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Cập nhật thành công",
+		Data:    toCommentResponse(c, comment),
+	})
+}
+
+// loadChapter lấy chương theo path param :id/:number, áp dụng quy tắc hiển thị của BookHandler.
+// Trả về nil khi có lỗi, lúc đó response lỗi đã được ghi.
+func (h *CommentHandler) loadChapter(c *gin.Context) *models.Chapter {
+	book := h.books.loadBook(c)
+	if book == nil {
+		return nil
+	}
+	return h.books.loadChapter(c, book)
+}
+
+// loadComment đọc path param :comment và lấy bình luận tương ứng trong chapter.
+// Trả về nil khi có lỗi, lúc đó response lỗi đã được ghi.
+func (h *CommentHandler) loadComment(c *gin.Context, chapter *models.Chapter) *models.ChapterComment {
+	id, err := strconv.ParseUint(c.Param("comment"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "ID bình luận không hợp lệ"})
+		return nil
+	}
+
+	comment, err := h.repo.GetCommentByID(uint(id))
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return nil
+	}
+	if comment == nil || comment.ChapterID != chapter.ID {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Bình luận không tồn tại"})
+		return nil
+	}
+
+	return comment
+}
+
+// loadOwnedComment giống loadComment nhưng chỉ trả về bình luận chưa xóa của user hiện tại, ngược lại trả 403/404
+func (h *CommentHandler) loadOwnedComment(c *gin.Context) *models.ChapterComment {
+	chapter := h.loadChapter(c)
+	if chapter == nil {
+		return nil
+	}
+	comment := h.loadComment(c, chapter)
+	if comment == nil {
+		return nil
+	}
+
+	if comment.Status == models.VisibilityDeleted {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Bình luận không tồn tại"})
+		return nil
+	}
+	if comment.UserID != middleware.CurrentUserID(c) {
+		c.JSON(http.StatusForbidden, dto.ApiResponse{Success: false, Message: "Chỉ người viết mới được thực hiện thao tác này"})
+		return nil
+	}
+
+	return comment
+}
+
+// validateCommentBody bỏ khoảng trắng thừa và kiểm tra độ dài bình luận. Ghi lỗi và trả về false nếu không hợp lệ.
+func validateCommentBody(c *gin.Context, body string) (string, bool) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Nội dung bình luận không được để trống"})
+		return "", false
+	}
+	if utf8.RuneCountInString(body) > maxCommentLength {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Bình luận tối đa " + strconv.Itoa(maxCommentLength) + " ký tự"})
+		return "", false
+	}
+	return body, true
+}
+
+// toCommentList chuyển một trang bình luận (lấy dư 1 bản ghi để biết còn trang sau không) sang DTO
+func toCommentList(c *gin.Context, comments []models.ChapterComment, limit int) dto.CommentListResponse {
+	var resp dto.CommentListResponse
+	if len(comments) > limit {
+		comments = comments[:limit]
+		resp.NextCursor = encodeCursor(comments[limit-1].ID)
+	}
+	resp.Items = make([]dto.CommentResponse, 0, len(comments))
+	for i := range comments {
+		resp.Items = append(resp.Items, toCommentResponse(c, &comments[i]))
+	}
+	return resp
+}
+
+// toCommentResponse chuyển models.ChapterComment sang DTO; nội dung bình luận bị ẩn chỉ trả về
+// cho người viết và admin
+func toCommentResponse(c *gin.Context, comment *models.ChapterComment) dto.CommentResponse {
+	body := comment.Body
+	if comment.Status == models.VisibilityHidden && comment.UserID != middleware.CurrentUserID(c) && !isModerator(c) {
+		body = ""
+	}
+	return dto.CommentResponse{
+		ID:         comment.ID,
+		UserID:     comment.UserID,
+		ParentID:   comment.ParentID,
+		Depth:      comment.Depth,
+		Body:       body,
+		Spoiler:    comment.Spoiler,
+		ReplyCount: comment.ReplyCount,
+		Status:     comment.Status,
+		EditedAt:   comment.EditedAt,
+		CreatedAt:  comment.CreatedAt,
+	}
+}
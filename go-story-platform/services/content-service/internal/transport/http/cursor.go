@@ -0,0 +1,38 @@
+package http
+
+import (
+	"content-service/internal/transport/http/dto"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseCursor đọc con trỏ phân trang (query cursor, là next_cursor của trang trước) và limit
+// (mặc định 20, tối đa 100). Con trỏ rỗng = trang đầu, trả về 0.
+// Trả về false khi con trỏ không hợp lệ, lúc đó response lỗi đã được ghi.
+func parseCursor(c *gin.Context) (uint, int, bool) {
+	_, limit := parsePagination(c)
+
+	cursor := c.Query("cursor")
+	if cursor == "" {
+		return 0, limit, true
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "cursor không hợp lệ"})
+		return 0, 0, false
+	}
+	id, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "cursor không hợp lệ"})
+		return 0, 0, false
+	}
+	return uint(id), limit, true
+}
+
+// encodeCursor tạo con trỏ trỏ tới bản ghi id (bản ghi cuối của trang hiện tại)
+func encodeCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(id), 10)))
+}
@@ -0,0 +1,58 @@
+package dto
+
+import "time"
+
+type CreateBookRequest struct {
+	Title       string   `json:"title"`
+	CategoryIDs []uint   `json:"category_ids"`
+	Tags        []string `json:"tags"` // Tên thẻ tự do, thẻ chưa có sẽ được tạo
+	Description string   `json:"description"`
+	IsPremium   bool     `json:"is_premium"`
+	Price       int      `json:"price"`
+}
+
+// UpdateBookRequest dùng con trỏ để phân biệt trường không gửi với giá trị rỗng
+type UpdateBookRequest struct {
+	Title       *string   `json:"title"`
+	CategoryIDs *[]uint   `json:"category_ids"` // Thay toàn bộ thể loại, [] để bỏ hết
+	Tags        *[]string `json:"tags"`         // Thay toàn bộ thẻ, [] để bỏ hết
+	Description *string   `json:"description"`
+	IsPremium   *bool     `json:"is_premium"`
+	Price       *int      `json:"price"`
+}
+
+type BookResponse struct {
+	ID          uint              `json:"id"`
+	Title       string            `json:"title"`
+	AuthorID    uint              `json:"author_id"`
+	Categories  []BookCategoryRef `json:"categories"`
+	Tags        []TagRef          `json:"tags"`
+	Description string            `json:"description"`
+	IsPremium   bool              `json:"is_premium"`
+	Price       int               `json:"price"`
+	Status      string            `json:"status"`
+	PublishAt   *time.Time        `json:"publish_at,omitempty"`
+	PublishedAt *time.Time        `json:"published_at,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// BookCategoryRef là thể loại gắn với một truyện
+type BookCategoryRef struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type BookListResponse struct {
+	Items []BookResponse `json:"items"`
+	Total int64          `json:"total"`
+	Page  int            `json:"page"`
+	Limit int            `json:"limit"`
+}
+
+// PublicationRequest chuyển trạng thái xuất bản của truyện hoặc chương
+type PublicationRequest struct {
+	Status    string     `json:"status"`     // draft, scheduled, published, unlisted, taken_down (chỉ admin)
+	PublishAt *time.Time `json:"publish_at"` // Bắt buộc khi status = scheduled, phải ở tương lai
+}
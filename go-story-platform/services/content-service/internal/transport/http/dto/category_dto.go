@@ -0,0 +1,24 @@
+package dto
+
+type CategoryRequest struct {
+	Name       string `json:"name"`
+	Slug       string `json:"slug"`        // Bỏ trống để tự sinh từ Name (bỏ dấu tiếng Việt)
+	ParentSlug string `json:"parent_slug"` // Slug của thể loại cha, bỏ trống nếu là thể loại gốc
+}
+
+type CategoryResponse struct {
+	ID         uint   `json:"id"`
+	Name       string `json:"name"`
+	Slug       string `json:"slug"`
+	ParentID   *uint  `json:"parent_id"`
+	ParentSlug string `json:"parent_slug,omitempty"`
+}
+
+// CategoryNode là một nút của cây thể loại
+type CategoryNode struct {
+	ID        uint           `json:"id"`
+	Name      string         `json:"name"`
+	Slug      string         `json:"slug"`
+	BookCount int64          `json:"book_count"` // Số truyện đã xuất bản, tính cả thể loại con cháu
+	Children  []CategoryNode `json:"children"`
+}
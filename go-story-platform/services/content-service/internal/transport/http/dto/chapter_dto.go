@@ -0,0 +1,61 @@
+package dto
+
+import "time"
+
+type ChapterRequest struct {
+	ChapterNumber int    `json:"chapter_number"`
+	ContentURL    string `json:"content_url"` // Link ngoài; bỏ trống nếu nội dung sẽ được tải lên qua API content
+	Price         int    `json:"price"`
+	ChangeNote    string `json:"change_note"` // Ghi chú cho revision mới khi content_url thay đổi
+	Publish       bool   `json:"publish"`     // Xuất bản ngay nội dung mới thay vì để làm bản nháp
+}
+
+type ChapterResponse struct {
+	ID                uint       `json:"id"`
+	BookID            uint       `json:"book_id"`
+	ChapterNumber     int        `json:"chapter_number"`
+	ContentURL        string     `json:"content_url,omitempty"`  // Bỏ trống khi chương bị khóa với người đọc hiện tại
+	ContentCID        string     `json:"content_cid,omitempty"`  // IPFS CID của nội dung đã tải lên, dùng để kiểm chứng nội dung; bỏ trống như content_url
+	ContentSize       int64      `json:"content_size,omitempty"` // Kích thước nội dung đã tải lên (byte); bỏ trống như content_url
+	Price             int        `json:"price"`
+	Locked            bool       `json:"locked"`                       // Chương trả phí (truyện premium hoặc có giá lẻ)
+	LatestRevision    int        `json:"latest_revision,omitempty"`    // Revision mới nhất, có thể chưa xuất bản
+	PublishedRevision int        `json:"published_revision,omitempty"` // Revision người đọc đang thấy
+	Status            string     `json:"status"`
+	PublishAt         *time.Time `json:"publish_at,omitempty"`
+	PublishedAt       *time.Time `json:"published_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+type ChapterRevisionResponse struct {
+	Revision    int       `json:"revision"`
+	AuthorID    uint      `json:"author_id"`
+	ContentURL  string    `json:"content_url"`
+	ContentCID  string    `json:"content_cid,omitempty"` // Hash nội dung, rỗng nếu revision chỉ là link ngoài
+	ContentSize int64     `json:"content_size,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	ChangeNote  string    `json:"change_note,omitempty"`
+	Published   bool      `json:"published"` // Revision đang được xuất bản cho người đọc
+	Latest      bool      `json:"latest"`    // Revision mới nhất (bản nháp hiện tại)
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type RestoreRevisionRequest struct {
+	ChangeNote string `json:"change_note"`
+	Publish    bool   `json:"publish"` // Xuất bản ngay revision được tạo ra từ bản khôi phục
+}
+
+type DiffOp struct {
+	Op   string `json:"op"` // equal, insert hoặc delete
+	Text string `json:"text"`
+}
+
+type RevisionDiffResponse struct {
+	From       int      `json:"from"`
+	To         int      `json:"to"`
+	Mode       string   `json:"mode"`       // line hoặc word
+	Insertions int      `json:"insertions"` // Số dòng/từ được thêm
+	Deletions  int      `json:"deletions"`  // Số dòng/từ bị xóa
+	Ops        []DiffOp `json:"ops"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+type CreateCommentRequest struct {
+	Body     string `json:"body"`
+	Spoiler  bool   `json:"spoiler"`
+	ParentID *uint  `json:"parent_id"` // Bình luận được trả lời, bỏ trống nếu là bình luận gốc
+}
+
+type UpdateCommentRequest struct {
+	Body    string `json:"body"`
+	Spoiler bool   `json:"spoiler"`
+}
+
+type CommentResponse struct {
+	ID         uint       `json:"id"`
+	UserID     uint       `json:"user_id"`
+	ParentID   *uint      `json:"parent_id"`
+	Depth      int        `json:"depth"`
+	Body       string     `json:"body"`    // Rỗng nếu bình luận đã xóa hoặc bị ẩn
+	Spoiler    bool       `json:"spoiler"` // Client nên che nội dung cho tới khi người đọc chọn xem
+	ReplyCount int64      `json:"reply_count"`
+	Status     string     `json:"status"` // visible, hidden (bị admin ẩn), deleted
+	EditedAt   *time.Time `json:"edited_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CommentListResponse struct {
+	Items      []CommentResponse `json:"items"`
+	NextCursor string            `json:"next_cursor,omitempty"` // Rỗng khi đã hết
+}
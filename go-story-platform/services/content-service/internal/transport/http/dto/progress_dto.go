@@ -0,0 +1,89 @@
+package dto
+
+import "time"
+
+// ProgressSyncItem là tiến độ đọc một chương do thiết bị gửi lên
+type ProgressSyncItem struct {
+	BookID        uint      `json:"book_id"`
+	ChapterNumber int       `json:"chapter_number"`
+	Position      int64     `json:"position"`   // Vị trí trong chương do client định nghĩa (vd. offset ký tự)
+	Percentage    float64   `json:"percentage"` // 0-100, 100 = đọc xong chương
+	UpdatedAt     time.Time `json:"updated_at"` // Thời điểm ghi trên thiết bị (RFC3339), bản mới hơn thắng
+}
+
+type ProgressSyncRequest struct {
+	Items []ProgressSyncItem `json:"items"`
+}
+
+type ProgressResponse struct {
+	BookID        uint      `json:"book_id"`
+	ChapterNumber int       `json:"chapter_number"`
+	Position      int64     `json:"position"`
+	Percentage    float64   `json:"percentage"`
+	Completed     bool      `json:"completed"`
+	UpdatedAt     time.Time `json:"updated_at"` // Thời điểm ghi trên thiết bị
+	SyncedAt      time.Time `json:"synced_at"`  // Thời điểm server lưu
+}
+
+// ProgressSyncResult là kết quả đồng bộ của một item: applied (đã ghi), stale (server có bản mới hơn,
+// progress là bản của server) hoặc rejected (item không hợp lệ, xem message)
+type ProgressSyncResult struct {
+	BookID        uint              `json:"book_id"`
+	ChapterNumber int               `json:"chapter_number"`
+	Status        string            `json:"status"`
+	Message       string            `json:"message,omitempty"`
+	Progress      *ProgressResponse `json:"progress,omitempty"`
+}
+
+type ProgressSyncResponse struct {
+	Results    []ProgressSyncResult `json:"results"`
+	ServerTime time.Time            `json:"server_time"`
+}
+
+type ProgressChangesResponse struct {
+	Items      []ProgressResponse `json:"items"`
+	NextCursor int64              `json:"next_cursor"` // Gửi lại làm cursor ở lần kéo sau
+	HasMore    bool               `json:"has_more"`
+}
+
+type ShelfItemResponse struct {
+	Book              BookResponse `json:"book"`
+	ChapterNumber     int          `json:"chapter_number"` // Chương đọc gần nhất
+	Position          int64        `json:"position"`
+	Percentage        float64      `json:"percentage"`
+	LastReadAt        time.Time    `json:"last_read_at"`
+	CompletedChapters int64        `json:"completed_chapters"`
+	TotalChapters     int64        `json:"total_chapters"`
+	Completion        float64      `json:"completion"` // Phần trăm số chương đã đọc xong
+}
+
+type ShelfResponse struct {
+	Items []ShelfItemResponse `json:"items"`
+	Total int64               `json:"total"`
+	Page  int                 `json:"page"`
+	Limit int                 `json:"limit"`
+}
+
+type BookProgressResponse struct {
+	BookID            uint               `json:"book_id"`
+	Current           *ProgressResponse  `json:"current"` // Chương đọc gần nhất, null nếu chưa đọc
+	CompletedChapters int64              `json:"completed_chapters"`
+	TotalChapters     int64              `json:"total_chapters"`
+	Completion        float64            `json:"completion"`
+	Chapters          []ProgressResponse `json:"chapters"`
+}
+
+type ChapterReadersResponse struct {
+	ChapterNumber int   `json:"chapter_number"`
+	Started       int64 `json:"started"`
+	Completed     int64 `json:"completed"`
+}
+
+type ReadingStatsResponse struct {
+	BookID         uint                     `json:"book_id"`
+	Readers        int64                    `json:"readers"`         // Số người đã đọc ít nhất một chương
+	Finished       int64                    `json:"finished"`        // Số người đã đọc xong mọi chương
+	TotalChapters  int64                    `json:"total_chapters"`  // Số chương đang hiển thị
+	CompletionRate float64                  `json:"completion_rate"` // Phần trăm người đọc đã đọc xong
+	Chapters       []ChapterReadersResponse `json:"chapters"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+type ReviewRequest struct {
+	Rating  int    `json:"rating"`  // 1-5 sao
+	Body    string `json:"body"`    // Nhận xét, có thể bỏ trống
+	Spoiler bool   `json:"spoiler"` // Nhận xét tiết lộ nội dung truyện
+}
+
+type ReviewResponse struct {
+	ID               uint       `json:"id"`
+	BookID           uint       `json:"book_id"`
+	UserID           uint       `json:"user_id"`
+	Rating           int        `json:"rating"`
+	Body             string     `json:"body"`
+	Spoiler          bool       `json:"spoiler"` // Client nên che nội dung cho tới khi người đọc chọn xem
+	Status           string     `json:"status"`  // visible, hidden (bị admin ẩn)
+	ModerationReason string     `json:"moderation_reason,omitempty"`
+	EditedAt         *time.Time `json:"edited_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+type ReviewListResponse struct {
+	Items      []ReviewResponse `json:"items"`
+	NextCursor string           `json:"next_cursor,omitempty"` // Rỗng khi đã hết
+}
+
+type RatingSummaryResponse struct {
+	BookID    uint             `json:"book_id"`
+	Average   float64          `json:"average"` // Làm tròn 2 chữ số, 0 khi chưa có đánh giá
+	Count     int64            `json:"count"`
+	Histogram map[string]int64 `json:"histogram"` // Số đánh giá theo số sao, khóa "1"-"5"
+}
+
+// ModerationRequest ẩn hoặc hiện lại đánh giá/bình luận
+type ModerationRequest struct {
+	Hidden bool   `json:"hidden"`
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+type SearchChapterHit struct {
+	ChapterNumber int     `json:"chapter_number"`
+	Rank          float64 `json:"rank"`
+	Snippet       string  `json:"snippet,omitempty"`
+}
+
+type SearchHit struct {
+	Book             BookResponse       `json:"book"`
+	Rank             float64            `json:"rank"`
+	TitleHighlight   string             `json:"title_highlight"`             // HTML đã escape, từ khớp nằm trong <mark>
+	DescriptionMatch string             `json:"description_match,omitempty"` // Đoạn mô tả khớp, cùng định dạng với title_highlight
+	Chapters         []SearchChapterHit `json:"chapters,omitempty"`          // Tối đa 3 chương khớp nhất
+}
+
+type CategoryFacet struct {
+	CategoryID uint   `json:"category_id"`
+	Name       string `json:"name"`
+	Count      int64  `json:"count"`
+}
+
+type PremiumFacet struct {
+	Free    int64 `json:"free"`
+	Premium int64 `json:"premium"`
+}
+
+type SearchFacets struct {
+	Categories []CategoryFacet `json:"categories"`
+	Premium    PremiumFacet    `json:"premium"`
+}
+
+type SearchResponse struct {
+	Query  string       `json:"query"`
+	Items  []SearchHit  `json:"items"`
+	Total  int64        `json:"total"`
+	Page   int          `json:"page"`
+	Limit  int          `json:"limit"`
+	Facets SearchFacets `json:"facets"` // Tính trên mọi truyện khớp từ khóa, không áp dụng bộ lọc category_id/premium
+}
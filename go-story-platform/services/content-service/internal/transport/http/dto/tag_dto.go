@@ -0,0 +1,21 @@
+package dto
+
+// TagRef là thẻ gắn với một truyện
+type TagRef struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type TagResponse struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	BookCount int64  `json:"book_count"` // Số truyện đã xuất bản được gắn thẻ
+}
+
+type TagListResponse struct {
+	Items []TagResponse `json:"items"`
+	Total int64         `json:"total"`
+	Page  int           `json:"page"`
+	Limit int           `json:"limit"`
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"content-service/internal/transport/http/dto"
+	"content-service/pkg/auth"
+
+	jwtauth "shared/auth"
+	"shared/identity"
+	"shared/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthMiddleware xác thực access token do user-service cấp và lưu userID, userRole vào context.
+// Request đi qua API gateway được nhận bằng header danh tính do gateway ký; gateway nil thì luôn kiểm JWT.
+func AuthMiddleware(verifier *jwtauth.Verifier, gateway *identity.Signer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Lấy header Authorization: Bearer <token>
+		if c.GetHeader("Authorization") == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ApiResponse{
+				Success: false,
+				Message: "Bạn cần đăng nhập để truy cập",
+			})
+			return
+		}
+		if !authenticate(c, verifier, gateway) {
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware giống AuthMiddleware nhưng cho phép request không có token đi tiếp
+// như khách. Token có gửi lên mà không hợp lệ vẫn bị từ chối 401.
+func OptionalAuthMiddleware(verifier *jwtauth.Verifier, gateway *identity.Signer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "" && !authenticate(c, verifier, gateway) {
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// authenticate kiểm tra header Authorization, lưu userID, userRole và accessToken vào context.
+// Trả về false khi token không hợp lệ, lúc đó request đã bị abort với 401.
+func authenticate(c *gin.Context, verifier *jwtauth.Verifier, gateway *identity.Signer) bool {
+	// Tách chuỗi để lấy token
+	parts := strings.Split(c.GetHeader("Authorization"), " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ApiResponse{
+			Success: false,
+			Message: "Định dạng Token phải là 'Bearer <token>'",
+		})
+		return false
+	}
+	// Request đi qua API gateway: token đã được kiểm ở gateway, chỉ cần kiểm chữ ký header danh tính
+	gatewayIdentity, err := gateway.Verify(c.Request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ApiResponse{
+			Success: false,
+			Message: "Danh tính từ gateway không hợp lệ",
+		})
+		return false
+	}
+	if gatewayIdentity != nil {
+		logging.SetUserID(c.Request.Context(), gatewayIdentity.UserID)
+		c.Set("userID", gatewayIdentity.UserID)
+		c.Set("userRole", gatewayIdentity.Role)
+		c.Set("accessToken", parts[1])
+		return true
+	}
+	//Gọi service để xác thực
+	claims, err := verifier.Verify(parts[1])
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ApiResponse{
+			Success: false,
+			Message: "Token không hợp lệ hoặc đã hết hạn",
+		})
+		return false
+	}
+	// Lưu thông tin user vào context để các handler khác sử dụng.
+	// accessToken được chuyển tiếp khi cần gọi service khác thay mặt user.
+	logging.SetUserID(c.Request.Context(), claims.UserID)
+	c.Set("userID", claims.UserID)
+	c.Set("userRole", claims.Role)
+	c.Set("accessToken", parts[1])
+	return true
+}
+
+// RequirePermission chặn request bằng 403 nếu vai trò của user không có quyền perm.
+// Phải đặt sau AuthMiddleware.
+func RequirePermission(perm auth.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !auth.HasPermission(c.GetString("userRole"), perm) {
+			c.AbortWithStatusJSON(http.StatusForbidden, dto.ApiResponse{
+				Success: false,
+				Message: "Bạn không có quyền thực hiện thao tác này",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// CurrentUserID lấy ID của user đang đăng nhập do AuthMiddleware lưu vào context
+func CurrentUserID(c *gin.Context) uint {
+	if v, exists := c.Get("userID"); exists {
+		if id, ok := v.(uint); ok {
+			return id
+		}
+	}
+	return 0
+}
@@ -0,0 +1,371 @@
+package http
+
+import (
+	"content-service/internal/models"
+	"content-service/internal/repository"
+	"content-service/internal/transport/http/dto"
+	"content-service/internal/transport/http/middleware"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	maxSyncItems    = 100             // Số item tối đa trong một lần đồng bộ
+	maxPullItems    = 500             // Số bản ghi tối đa trong một lần kéo thay đổi
+	maxClockSkew    = 5 * time.Minute // Thời điểm từ thiết bị vượt quá giờ server quá mức này bị đưa về giờ server
+	progressApplied = "applied"
+	progressStale   = "stale"
+	progressReject  = "rejected"
+)
+
+// ProgressHandler xử lý tiến độ đọc và kệ "đọc tiếp" của người đọc
+type ProgressHandler struct {
+	repo  *repository.ProgressRepository
+	books *BookHandler // Dùng lại việc tải truyện/chương và kiểm tra quyền của BookHandler
+}
+
+// NewProgressHandler tạo ProgressHandler với repo và BookHandler được truyền vào
+func NewProgressHandler(repo *repository.ProgressRepository, books *BookHandler) *ProgressHandler {
+	return &ProgressHandler{repo: repo, books: books}
+}
+
+// SyncProgress godoc
+// @Summary Đồng bộ tiến độ đọc
+// @Description Thiết bị gửi lên tiến độ của các chương (tối đa 100 item). Mỗi chương giữ bản có updated_at mới nhất
+// @Description (last-write-wins); bản cũ hơn bản trên server có status stale kèm bản của server để thiết bị cập nhật lại.
+// @Description updated_at vượt giờ server quá 5 phút bị đưa về giờ server. Chương đã đọc xong (percentage = 100)
+// @Description luôn được giữ là đã đọc xong. Item không hợp lệ có status rejected, các item khác vẫn được ghi.
+// @Tags Reading
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body dto.ProgressSyncRequest true "Tiến độ các chương"
+// @Success 200 {object} dto.ApiResponse{data=dto.ProgressSyncResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 401 {object} dto.ApiResponse
+// @Router /me/progress [put]
+func (h *ProgressHandler) SyncProgress(c *gin.Context) {
+	var input dto.ProgressSyncRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+	if len(input.Items) == 0 || len(input.Items) > maxSyncItems {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Mỗi lần đồng bộ gửi từ 1 đến " + strconv.Itoa(maxSyncItems) + " item"})
+		return
+	}
+
+	userID := middleware.CurrentUserID(c)
+	now := time.Now()
+	chapters := make(map[[2]int]*models.Chapter)
+	results := make([]dto.ProgressSyncResult, 0, len(input.Items))
+	for _, item := range input.Items {
+		result := dto.ProgressSyncResult{BookID: item.BookID, ChapterNumber: item.ChapterNumber, Status: progressReject}
+		if msg := validateProgressItem(&item); msg != "" {
+			result.Message = msg
+			results = append(results, result)
+			continue
+		}
+
+		key := [2]int{int(item.BookID), item.ChapterNumber}
+		chapter, seen := chapters[key]
+		if !seen {
+			var err error
+			chapter, err = h.books.chapterRepo.GetVisibleChapter(item.BookID, item.ChapterNumber)
+			if err != nil {
+				serverError(c, err, "Lỗi server")
+				return
+			}
+			chapters[key] = chapter
+		}
+		if chapter == nil {
+			result.Message = "Chương không tồn tại"
+			results = append(results, result)
+			continue
+		}
+
+		updatedAt := item.UpdatedAt
+		if updatedAt.After(now.Add(maxClockSkew)) {
+			updatedAt = now
+		}
+		progress := models.ReadingProgress{
+			UserID:          userID,
+			ChapterID:       chapter.ID,
+			BookID:          chapter.BookID,
+			Position:        item.Position,
+			Percentage:      item.Percentage,
+			Completed:       item.Percentage >= 100,
+			ClientUpdatedAt: updatedAt,
+		}
+		applied, err := h.repo.SaveProgress(&progress)
+		if err != nil {
+			serverError(c, err, "Không thể lưu tiến độ đọc")
+			return
+		}
+
+		result.Status = progressStale
+		if applied {
+			result.Status = progressApplied
+		}
+		resp := toProgressResponse(&progress, chapter.ChapterNumber)
+		result.Progress = &resp
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Đồng bộ thành công",
+		Data:    dto.ProgressSyncResponse{Results: results, ServerTime: now},
+	})
+}
+
+// ListProgressChanges godoc
+// @Summary Kéo tiến độ đọc đã thay đổi
+// @Description Trả về tiến độ server đã ghi sau cursor, theo thứ tự ghi, tối đa 500 bản ghi mỗi lần.
+// @Description Bỏ trống cursor để lấy toàn bộ. Lưu next_cursor và gửi lại làm cursor ở lần kéo sau; khi has_more = true thì kéo tiếp ngay.
+// @Tags Reading
+// @Produce json
+// @Security BearerAuth
+// @Param cursor query int false "next_cursor của lần kéo trước"
+// @Success 200 {object} dto.ApiResponse{data=dto.ProgressChangesResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 401 {object} dto.ApiResponse
+// @Router /me/progress [get]
+func (h *ProgressHandler) ListProgressChanges(c *gin.Context) {
+	var cursor int64
+	if v := c.Query("cursor"); v != "" {
+		var err error
+		if cursor, err = strconv.ParseInt(v, 10, 64); err != nil || cursor < 0 {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "cursor không hợp lệ"})
+			return
+		}
+	}
+
+	entries, err := h.repo.ListChanges(middleware.CurrentUserID(c), cursor, maxPullItems+1)
+	if err != nil {
+		serverError(c, err, "Không thể lấy tiến độ đọc")
+		return
+	}
+
+	resp := dto.ProgressChangesResponse{NextCursor: cursor, HasMore: len(entries) > maxPullItems}
+	if resp.HasMore {
+		entries = entries[:maxPullItems]
+	}
+	resp.Items = make([]dto.ProgressResponse, 0, len(entries))
+	for i := range entries {
+		resp.Items = append(resp.Items, toProgressResponse(&entries[i].ReadingProgress, entries[i].ChapterNumber))
+		resp.NextCursor = entries[i].Version
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    resp,
+	})
+}
+
+// GetShelf godoc
+// @Summary Kệ "đọc tiếp"
+// @Description Các truyện user đang đọc, đọc gần nhất đứng trước, kèm chương đọc gần nhất và số chương đã đọc xong.
+// @Description Truyện đã đọc xong mọi chương chỉ xuất hiện khi include_finished=true.
+// @Tags Reading
+// @Produce json
+// @Security BearerAuth
+// @Param include_finished query bool false "Gồm cả truyện đã đọc xong"
+// @Param page query int false "Trang (mặc định 1)"
+// @Param limit query int false "Số bản ghi mỗi trang (mặc định 20, tối đa 100)"
+// @Success 200 {object} dto.ApiResponse{data=dto.ShelfResponse}
+// @Failure 401 {object} dto.ApiResponse
+// @Router /me/shelf [get]
+func (h *ProgressHandler) GetShelf(c *gin.Context) {
+	page, limit := parsePagination(c)
+	includeFinished := c.Query("include_finished") == "true"
+
+	shelf, total, err := h.repo.ListShelf(middleware.CurrentUserID(c), includeFinished, page, limit)
+	if err != nil {
+		serverError(c, err, "Không thể lấy kệ truyện")
+		return
+	}
+
+	ids := make([]uint, 0, len(shelf))
+	for _, item := range shelf {
+		ids = append(ids, item.BookID)
+	}
+	books, err := h.books.bookRepo.GetBooksByIDs(ids)
+	if err != nil {
+		serverError(c, err, "Không thể lấy kệ truyện")
+		return
+	}
+	byID := make(map[uint]*models.Book, len(books))
+	for i := range books {
+		byID[books[i].ID] = &books[i]
+	}
+
+	items := make([]dto.ShelfItemResponse, 0, len(shelf))
+	for _, item := range shelf {
+		book, ok := byID[item.BookID]
+		if !ok {
+			continue
+		}
+		items = append(items, dto.ShelfItemResponse{
+			Book:              toBookResponse(book),
+			ChapterNumber:     item.ChapterNumber,
+			Position:          item.Position,
+			Percentage:        item.Percentage,
+			LastReadAt:        item.ClientUpdatedAt,
+			CompletedChapters: item.CompletedChapters,
+			TotalChapters:     item.TotalChapters,
+			Completion:        percent(item.CompletedChapters, item.TotalChapters),
+		})
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data: dto.ShelfResponse{
+			Items: items,
+			Total: total,
+			Page:  page,
+			Limit: limit,
+		},
+	})
+}
+
+// GetBookProgress godoc
+// @Summary Tiến độ đọc truyện của user hiện tại
+// @Description Chương đọc gần nhất, tiến độ từng chương đã đọc và mức độ hoàn thành trên các chương đang hiển thị.
+// @Tags Reading
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} dto.ApiResponse{data=dto.BookProgressResponse}
+// @Failure 401 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/progress [get]
+func (h *ProgressHandler) GetBookProgress(c *gin.Context) {
+	book := h.books.loadBook(c)
+	if book == nil {
+		return
+	}
+
+	entries, err := h.repo.ListBookProgress(middleware.CurrentUserID(c), book.ID)
+	if err != nil {
+		serverError(c, err, "Không thể lấy tiến độ đọc")
+		return
+	}
+	total, err := h.repo.CountVisibleChapters(book.ID)
+	if err != nil {
+		serverError(c, err, "Không thể lấy tiến độ đọc")
+		return
+	}
+
+	resp := dto.BookProgressResponse{
+		BookID:        book.ID,
+		TotalChapters: total,
+		Chapters:      make([]dto.ProgressResponse, 0, len(entries)),
+	}
+	for i := range entries {
+		progress := toProgressResponse(&entries[i].ReadingProgress, entries[i].ChapterNumber)
+		resp.Chapters = append(resp.Chapters, progress)
+		if progress.Completed {
+			resp.CompletedChapters++
+		}
+		if resp.Current == nil || progress.UpdatedAt.After(resp.Current.UpdatedAt) {
+			resp.Current = &progress
+		}
+	}
+	resp.Completion = percent(resp.CompletedChapters, resp.TotalChapters)
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    resp,
+	})
+}
+
+// GetReadingStats godoc
+// @Summary Thống kê hoàn thành của truyện
+// @Description Chỉ tác giả của truyện hoặc admin. Số người đọc, số người đã đọc xong mọi chương đang hiển thị
+// @Description và số người bắt đầu/đọc xong từng chương.
+// @Tags Reading
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} dto.ApiResponse{data=dto.ReadingStatsResponse}
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/reading-stats [get]
+func (h *ProgressHandler) GetReadingStats(c *gin.Context) {
+	book := h.books.loadOwnedBook(c)
+	if book == nil {
+		return
+	}
+
+	stats, err := h.repo.GetReadingStats(book.ID)
+	if err != nil {
+		serverError(c, err, "Không thể lấy thống kê")
+		return
+	}
+
+	chapters := make([]dto.ChapterReadersResponse, 0, len(stats.Chapters))
+	for _, ch := range stats.Chapters {
+		chapters = append(chapters, dto.ChapterReadersResponse{
+			ChapterNumber: ch.ChapterNumber,
+			Started:       ch.Started,
+			Completed:     ch.Completed,
+		})
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data: dto.ReadingStatsResponse{
+			BookID:         book.ID,
+			Readers:        stats.Readers,
+			Finished:       stats.Finished,
+			TotalChapters:  stats.TotalChapters,
+			CompletionRate: percent(stats.Finished, stats.Readers),
+			Chapters:       chapters,
+		},
+	})
+}
+
+// validateProgressItem kiểm tra một item đồng bộ, trả về thông báo lỗi hoặc rỗng nếu hợp lệ
+func validateProgressItem(item *dto.ProgressSyncItem) string {
+	switch {
+	case item.BookID == 0 || item.ChapterNumber <= 0:
+		return "book_id và chapter_number là bắt buộc"
+	case item.Position < 0:
+		return "position không được âm"
+	case math.IsNaN(item.Percentage) || item.Percentage < 0 || item.Percentage > 100:
+		return "percentage phải trong khoảng 0-100"
+	case item.UpdatedAt.IsZero():
+		return "updated_at là bắt buộc"
+	}
+	return ""
+}
+
+// percent tính part/total theo phần trăm, làm tròn 1 chữ số thập phân; 0 nếu total = 0
+func percent(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)*1000/float64(total)) / 10
+}
+
+// toProgressResponse chuyển models.ReadingProgress sang DTO trả về cho client
+func toProgressResponse(progress *models.ReadingProgress, chapterNumber int) dto.ProgressResponse {
+	return dto.ProgressResponse{
+		BookID:        progress.BookID,
+		ChapterNumber: chapterNumber,
+		Position:      progress.Position,
+		Percentage:    progress.Percentage,
+		Completed:     progress.Completed,
+		UpdatedAt:     progress.ClientUpdatedAt,
+		SyncedAt:      progress.UpdatedAt,
+	}
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"content-service/internal/models"
+	"content-service/internal/publishing"
+	"content-service/internal/transport/http/dto"
+	"content-service/pkg/auth"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetBookStatus godoc
+// @Summary Chuyển trạng thái xuất bản của truyện
+// @Description draft → scheduled (cần publish_at) / published / unlisted; published ⇄ unlisted; về draft để ẩn.
+// @Description Truyện premium phải có giá trước khi hiển thị. Chỉ admin được gỡ (taken_down) và khôi phục về draft.
+// @Description Lần đầu truyện hiển thị cho người đọc, event book.published được phát ra.
+// @Tags Books
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param body body dto.PublicationRequest true "Trạng thái mới"
+// @Success 200 {object} dto.ApiResponse{data=dto.BookResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/status [put]
+func (h *BookHandler) SetBookStatus(c *gin.Context) {
+	book := h.loadBook(c)
+	if book == nil {
+		return
+	}
+	moderator := isModerator(c)
+	if !canManageBook(c, book) && !moderator {
+		c.JSON(http.StatusForbidden, dto.ApiResponse{Success: false, Message: "Chỉ tác giả của truyện mới được thực hiện thao tác này"})
+		return
+	}
+
+	var input dto.PublicationRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+
+	err := publishing.CheckTransition(book.Status, input.Status, moderator)
+	if err == nil && requiresPublishCheck(input.Status) {
+		err = publishing.CheckBook(book)
+	}
+	var firstVisible bool
+	if err == nil {
+		firstVisible, err = publishing.Apply(&book.Publication, input.Status, input.PublishAt, time.Now())
+	}
+	if !writePublicationError(c, err) {
+		return
+	}
+
+	if err := h.bookRepo.SavePublication(book, firstVisible); err != nil {
+		serverError(c, err, "Cập nhật trạng thái thất bại")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Đã chuyển truyện sang trạng thái " + book.Status,
+		Data:    toBookResponse(book),
+	})
+}
+
+// SetChapterStatus godoc
+// @Summary Chuyển trạng thái xuất bản của chương
+// @Description Giống trạng thái của truyện. Chương phải có revision đã xuất bản, và chương của truyện premium phải có giá.
+// @Description Người đọc chỉ thấy chương khi cả truyện và chương đều hiển thị.
+// @Tags Chapters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param number path int true "Số thứ tự chương"
+// @Param body body dto.PublicationRequest true "Trạng thái mới"
+// @Success 200 {object} dto.ApiResponse{data=dto.ChapterResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/chapters/{number}/status [put]
+func (h *BookHandler) SetChapterStatus(c *gin.Context) {
+	book := h.loadBook(c)
+	if book == nil {
+		return
+	}
+	moderator := isModerator(c)
+	if !canManageBook(c, book) && !moderator {
+		c.JSON(http.StatusForbidden, dto.ApiResponse{Success: false, Message: "Chỉ tác giả của truyện mới được thực hiện thao tác này"})
+		return
+	}
+	chapter := h.loadChapter(c, book)
+	if chapter == nil {
+		return
+	}
+
+	var input dto.PublicationRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+
+	err := publishing.CheckTransition(chapter.Status, input.Status, moderator)
+	if err == nil && requiresPublishCheck(input.Status) {
+		err = publishing.CheckChapter(book, chapter)
+	}
+	if err == nil {
+		_, err = publishing.Apply(&chapter.Publication, input.Status, input.PublishAt, time.Now())
+	}
+	if !writePublicationError(c, err) {
+		return
+	}
+
+	if err := h.chapterRepo.UpdateChapter(chapter); err != nil {
+		serverError(c, err, "Cập nhật trạng thái thất bại")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Đã chuyển chương sang trạng thái " + chapter.Status,
+		Data:    toChapterResponse(book, chapter),
+	})
+}
+
+// requiresPublishCheck cho biết trạng thái đích có làm nội dung hiển thị (ngay hoặc theo lịch) không
+func requiresPublishCheck(status string) bool {
+	return status == models.StatusScheduled || status == models.StatusPublished || status == models.StatusUnlisted
+}
+
+// isModerator kiểm tra user hiện tại có quyền gỡ nội dung không
+func isModerator(c *gin.Context) bool {
+	return auth.HasPermission(c.GetString("userRole"), auth.PermContentModerate)
+}
+
+// writePublicationError ghi lỗi chuyển trạng thái ra response. Trả về true nếu err == nil.
+func writePublicationError(c *gin.Context, err error) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, publishing.ErrModeratorOnly):
+		c.JSON(http.StatusForbidden, dto.ApiResponse{Success: false, Message: err.Error()})
+	case publishing.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: err.Error()})
+	default:
+		serverError(c, err, "Lỗi server")
+	}
+	return false
+}
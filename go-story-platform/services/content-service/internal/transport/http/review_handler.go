@@ -0,0 +1,372 @@
+package http
+
+import (
+	"content-service/internal/models"
+	"content-service/internal/repository"
+	"content-service/internal/transport/http/dto"
+	"content-service/internal/transport/http/middleware"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	maxReviewLength     = 5000 // Độ dài tối đa của nhận xét (ký tự)
+	maxModerationReason = 500  // Độ dài tối đa của lý do ẩn (ký tự)
+)
+
+// ReviewHandler xử lý đánh giá truyện
+type ReviewHandler struct {
+	repo  *repository.ReviewRepository
+	books *BookHandler // Dùng lại việc tải truyện và kiểm tra quyền của BookHandler
+}
+
+// NewReviewHandler tạo ReviewHandler với repo và BookHandler được truyền vào
+func NewReviewHandler(repo *repository.ReviewRepository, books *BookHandler) *ReviewHandler {
+	return &ReviewHandler{repo: repo, books: books}
+}
+
+// GetRatingSummary godoc
+// @Summary Điểm đánh giá của truyện
+// @Description Điểm trung bình, số đánh giá và số đánh giá theo từng mức sao. Đánh giá bị ẩn không được tính.
+// @Tags Reviews
+// @Produce json
+// @Param id path int true "Book ID"
+// @Success 200 {object} dto.ApiResponse{data=dto.RatingSummaryResponse}
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/ratings [get]
+func (h *ReviewHandler) GetRatingSummary(c *gin.Context) {
+	book := h.books.loadBook(c)
+	if book == nil {
+		return
+	}
+
+	stats, err := h.repo.GetRatingStats(book.ID)
+	if err != nil {
+		serverError(c, err, "Không thể lấy điểm đánh giá")
+		return
+	}
+
+	var average float64
+	if stats.RatingCount > 0 {
+		average = math.Round(float64(stats.RatingSum)*100/float64(stats.RatingCount)) / 100
+	}
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data: dto.RatingSummaryResponse{
+			BookID:  book.ID,
+			Average: average,
+			Count:   stats.RatingCount,
+			Histogram: map[string]int64{
+				"1": stats.Count1,
+				"2": stats.Count2,
+				"3": stats.Count3,
+				"4": stats.Count4,
+				"5": stats.Count5,
+			},
+		},
+	})
+}
+
+// ListReviews godoc
+// @Summary Lấy danh sách đánh giá của truyện
+// @Description Đánh giá đang hiển thị, mới nhất trước. Gửi next_cursor của trang trước vào cursor để lấy trang tiếp.
+// @Tags Reviews
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param rating query int false "Lọc theo số sao (1-5)"
+// @Param cursor query string false "Con trỏ trang tiếp theo"
+// @Param limit query int false "Số bản ghi mỗi trang (mặc định 20, tối đa 100)"
+// @Success 200 {object} dto.ApiResponse{data=dto.ReviewListResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/reviews [get]
+func (h *ReviewHandler) ListReviews(c *gin.Context) {
+	book := h.books.loadBook(c)
+	if book == nil {
+		return
+	}
+	before, limit, ok := parseCursor(c)
+	if !ok {
+		return
+	}
+	rating, _ := strconv.Atoi(c.Query("rating"))
+	if rating < 0 || rating > 5 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "rating phải từ 1 đến 5"})
+		return
+	}
+
+	reviews, err := h.repo.ListReviews(repository.ReviewFilter{
+		BookID: book.ID,
+		Rating: rating,
+		Before: before,
+		Limit:  limit + 1,
+	})
+	if err != nil {
+		serverError(c, err, "Không thể lấy danh sách đánh giá")
+		return
+	}
+
+	var resp dto.ReviewListResponse
+	if len(reviews) > limit {
+		reviews = reviews[:limit]
+		resp.NextCursor = encodeCursor(reviews[limit-1].ID)
+	}
+	resp.Items = make([]dto.ReviewResponse, 0, len(reviews))
+	for i := range reviews {
+		resp.Items = append(resp.Items, toReviewResponse(&reviews[i]))
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    resp,
+	})
+}
+
+// GetMyReview godoc
+// @Summary Lấy đánh giá của user hiện tại cho truyện
+// @Tags Reviews
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} dto.ApiResponse{data=dto.ReviewResponse}
+// @Failure 401 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/review [get]
+func (h *ReviewHandler) GetMyReview(c *gin.Context) {
+	book := h.books.loadBook(c)
+	if book == nil {
+		return
+	}
+
+	review, err := h.repo.GetUserReview(book.ID, middleware.CurrentUserID(c))
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	if review == nil {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Bạn chưa đánh giá truyện này"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    toReviewResponse(review),
+	})
+}
+
+// SaveMyReview godoc
+// @Summary Đánh giá truyện
+// @Description Mỗi người đọc một đánh giá cho mỗi truyện: gửi lại sẽ sửa đánh giá cũ. Tác giả không đánh giá được truyện của mình.
+// @Description Đánh giá bị admin ẩn vẫn ở trạng thái ẩn sau khi sửa.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param body body dto.ReviewRequest true "Số sao và nhận xét"
+// @Success 200 {object} dto.ApiResponse{data=dto.ReviewResponse}
+// @Success 201 {object} dto.ApiResponse{data=dto.ReviewResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 401 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/review [put]
+func (h *ReviewHandler) SaveMyReview(c *gin.Context) {
+	book := h.books.loadBook(c)
+	if book == nil {
+		return
+	}
+	userID := middleware.CurrentUserID(c)
+	if book.AuthorID == userID {
+		c.JSON(http.StatusForbidden, dto.ApiResponse{Success: false, Message: "Tác giả không thể đánh giá truyện của mình"})
+		return
+	}
+
+	var input dto.ReviewRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+	if input.Rating < 1 || input.Rating > 5 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Số sao phải từ 1 đến 5"})
+		return
+	}
+	body := strings.TrimSpace(input.Body)
+	if utf8.RuneCountInString(body) > maxReviewLength {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Nhận xét tối đa " + strconv.Itoa(maxReviewLength) + " ký tự"})
+		return
+	}
+
+	review, err := h.repo.GetUserReview(book.ID, userID)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	status, message := http.StatusOK, "Cập nhật đánh giá thành công"
+	if review == nil {
+		status, message = http.StatusCreated, "Đánh giá thành công"
+		review = &models.BookReview{
+			BookID:     book.ID,
+			UserID:     userID,
+			Moderation: models.Moderation{Status: models.VisibilityVisible},
+		}
+	} else if review.Rating != input.Rating || review.Body != body || review.Spoiler != input.Spoiler {
+		now := time.Now()
+		review.EditedAt = &now
+	}
+	review.Rating = input.Rating
+	review.Body = body
+	review.Spoiler = input.Spoiler
+
+	if err := h.repo.SaveReview(review); err != nil {
+		serverError(c, err, "Không thể lưu đánh giá")
+		return
+	}
+
+	c.JSON(status, dto.ApiResponse{
+		Success: true,
+		Message: message,
+		Data:    toReviewResponse(review),
+	})
+}
+
+// DeleteMyReview godoc
+// @Summary Xóa đánh giá của user hiện tại cho truyện
+// @Tags Reviews
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} dto.ApiResponse
+// @Failure 401 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/review [delete]
+func (h *ReviewHandler) DeleteMyReview(c *gin.Context) {
+	book := h.books.loadBook(c)
+	if book == nil {
+		return
+	}
+
+	review, err := h.repo.GetUserReview(book.ID, middleware.CurrentUserID(c))
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	if review == nil {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Bạn chưa đánh giá truyện này"})
+		return
+	}
+
+	if err := h.repo.DeleteReview(review.ID); err != nil {
+		serverError(c, err, "Xóa thất bại")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{Success: true, Message: "Đã xóa đánh giá"})
+}
+
+// ModerateReview godoc
+// @Summary Ẩn hoặc hiện lại đánh giá
+// @Description Chỉ admin. Đánh giá bị ẩn không xuất hiện trong danh sách và không được tính vào điểm trung bình.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param review path int true "Review ID"
+// @Param body body dto.ModerationRequest true "Ẩn/hiện và lý do"
+// @Success 200 {object} dto.ApiResponse{data=dto.ReviewResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Router /books/{id}/reviews/{review}/moderation [put]
+func (h *ReviewHandler) ModerateReview(c *gin.Context) {
+	book := h.books.loadBook(c)
+	if book == nil {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("review"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "ID đánh giá không hợp lệ"})
+		return
+	}
+	review, err := h.repo.GetReviewByID(uint(id))
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	if review == nil || review.BookID != book.ID {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Đánh giá không tồn tại"})
+		return
+	}
+
+	var input dto.ModerationRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+	if !applyModeration(c, &review.Moderation, &input) {
+		return
+	}
+
+	if err := h.repo.ModerateReview(review); err != nil {
+		serverError(c, err, "Cập nhật thất bại")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Cập nhật thành công",
+		Data:    toReviewResponse(review),
+	})
+}
+
+// applyModeration kiểm tra lý do rồi chuyển nội dung sang hidden/visible, ghi lại admin thực hiện.
+// Nội dung đã bị người viết xóa thì không đổi được. Ghi lỗi và trả về false nếu không hợp lệ.
+func applyModeration(c *gin.Context, moderation *models.Moderation, input *dto.ModerationRequest) bool {
+	if moderation.Status == models.VisibilityDeleted {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Nội dung đã bị xóa"})
+		return false
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if utf8.RuneCountInString(reason) > maxModerationReason {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Lý do tối đa " + strconv.Itoa(maxModerationReason) + " ký tự"})
+		return false
+	}
+
+	moderatorID := middleware.CurrentUserID(c)
+	now := time.Now()
+	moderation.Status = models.VisibilityVisible
+	if input.Hidden {
+		moderation.Status = models.VisibilityHidden
+	}
+	moderation.ModerationReason = reason
+	moderation.ModeratedBy = &moderatorID
+	moderation.ModeratedAt = &now
+	return true
+}
+
+// toReviewResponse chuyển models.BookReview sang DTO trả về cho client
+func toReviewResponse(review *models.BookReview) dto.ReviewResponse {
+	return dto.ReviewResponse{
+		ID:               review.ID,
+		BookID:           review.BookID,
+		UserID:           review.UserID,
+		Rating:           review.Rating,
+		Body:             review.Body,
+		Spoiler:          review.Spoiler,
+		Status:           review.Status,
+		ModerationReason: review.ModerationReason,
+		EditedAt:         review.EditedAt,
+		CreatedAt:        review.CreatedAt,
+		UpdatedAt:        review.UpdatedAt,
+	}
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"content-service/internal/repository"
+	"content-service/internal/transport/http/dto"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxQueryLength là độ dài tối đa (ký tự) của từ khóa tìm kiếm
+const maxQueryLength = 200
+
+// SearchHandler xử lý tìm kiếm toàn văn
+type SearchHandler struct {
+	repo *repository.SearchRepository
+}
+
+// NewSearchHandler tạo SearchHandler với repo được truyền vào
+func NewSearchHandler(repo *repository.SearchRepository) *SearchHandler {
+	return &SearchHandler{repo: repo}
+}
+
+// Search godoc
+// @Summary Tìm kiếm truyện
+// @Description Tìm theo tên truyện, mô tả, tên thể loại, tên thẻ và nội dung chương; có dấu hay không dấu đều khớp.
+// @Description Hỗ trợ cú pháp web: "cụm từ chính xác", -từ_loại_trừ, or. Chỉ tìm trong truyện và chương đã xuất bản; nội dung chương trả phí không được tìm.
+// @Description Kết quả xếp theo mức độ liên quan, kèm đoạn trích đánh dấu <mark> và facet theo thể loại/premium.
+// @Tags Search
+// @Produce json
+// @Param q query string true "Từ khóa"
+// @Param category_id query int false "Lọc theo thể loại (gồm cả thể loại con)"
+// @Param premium query bool false "Lọc truyện premium (true) hoặc miễn phí (false)"
+// @Param page query int false "Trang (mặc định 1)"
+// @Param limit query int false "Số bản ghi mỗi trang (mặc định 20, tối đa 100)"
+// @Success 200 {object} dto.ApiResponse{data=dto.SearchResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Router /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Từ khóa tìm kiếm không được để trống"})
+		return
+	}
+	if utf8.RuneCountInString(query) > maxQueryLength {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Từ khóa tối đa " + strconv.Itoa(maxQueryLength) + " ký tự"})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	params := repository.SearchParams{Query: query, Page: page, Limit: limit}
+	if v := c.Query("category_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "category_id không hợp lệ"})
+			return
+		}
+		params.CategoryID = uint(id)
+	}
+	if v := c.Query("premium"); v != "" {
+		premium, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "premium phải là true hoặc false"})
+			return
+		}
+		params.Premium = &premium
+	}
+
+	result, err := h.repo.Search(params)
+	if err != nil {
+		serverError(c, err, "Không thể tìm kiếm")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Tìm kiếm thành công",
+		Data:    toSearchResponse(query, page, limit, result),
+	})
+}
+
+// toSearchResponse chuyển kết quả tìm kiếm sang DTO trả về cho client
+func toSearchResponse(query string, page, limit int, result *repository.SearchResult) dto.SearchResponse {
+	items := make([]dto.SearchHit, 0, len(result.Hits))
+	for i := range result.Hits {
+		hit := &result.Hits[i]
+		chapters := make([]dto.SearchChapterHit, 0, len(hit.Chapters))
+		for _, ch := range hit.Chapters {
+			chapters = append(chapters, dto.SearchChapterHit{ChapterNumber: ch.ChapterNumber, Rank: ch.Rank, Snippet: ch.Snippet})
+		}
+		items = append(items, dto.SearchHit{
+			Book:             toBookResponse(&hit.Book),
+			Rank:             hit.Rank,
+			TitleHighlight:   hit.TitleHighlight,
+			DescriptionMatch: hit.DescriptionMatch,
+			Chapters:         chapters,
+		})
+	}
+
+	categories := make([]dto.CategoryFacet, 0, len(result.Categories))
+	for _, f := range result.Categories {
+		categories = append(categories, dto.CategoryFacet{CategoryID: f.Value, Name: f.Name, Count: f.Count})
+	}
+
+	return dto.SearchResponse{
+		Query: query,
+		Items: items,
+		Total: result.Total,
+		Page:  page,
+		Limit: limit,
+		Facets: dto.SearchFacets{
+			Categories: categories,
+			Premium:    dto.PremiumFacet{Free: result.Premium[false], Premium: result.Premium[true]},
+		},
+	}
+}
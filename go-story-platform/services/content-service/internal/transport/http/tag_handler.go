@@ -0,0 +1,62 @@
+package http
+
+import (
+	"content-service/internal/repository"
+	"content-service/internal/slug"
+	"content-service/internal/transport/http/dto"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagHandler xử lý các request liên quan đến thẻ
+type TagHandler struct {
+	repo *repository.TagRepository
+}
+
+// NewTagHandler tạo TagHandler với repo được truyền vào
+func NewTagHandler(repo *repository.TagRepository) *TagHandler {
+	return &TagHandler{repo: repo}
+}
+
+// ListTags godoc
+// @Summary Lấy danh sách thẻ
+// @Description Chỉ gồm thẻ đang gắn cho truyện đã xuất bản, thẻ nhiều truyện nhất đứng trước.
+// @Description Dùng GET /books?tag=<slug> để xem truyện của một thẻ.
+// @Tags Tags
+// @Produce json
+// @Param q query string false "Lọc thẻ có slug bắt đầu bằng từ khóa (có dấu hay không đều được)"
+// @Param page query int false "Trang (mặc định 1)"
+// @Param limit query int false "Số bản ghi mỗi trang (mặc định 20, tối đa 100)"
+// @Success 200 {object} dto.ApiResponse{data=dto.TagListResponse}
+// @Router /tags [get]
+func (h *TagHandler) ListTags(c *gin.Context) {
+	page, limit := parsePagination(c)
+
+	tags, total, err := h.repo.ListTags(slug.Make(c.Query("q")), page, limit)
+	if err != nil {
+		serverError(c, err, "Không thể lấy danh sách thẻ")
+		return
+	}
+
+	items := make([]dto.TagResponse, 0, len(tags))
+	for _, tag := range tags {
+		items = append(items, dto.TagResponse{
+			ID:        tag.ID,
+			Name:      tag.Name,
+			Slug:      tag.Slug,
+			BookCount: tag.BookCount,
+		})
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data: dto.TagListResponse{
+			Items: items,
+			Total: total,
+			Page:  page,
+			Limit: limit,
+		},
+	})
+}
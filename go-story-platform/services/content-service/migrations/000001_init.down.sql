@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS outbox_messages;
+DROP TABLE IF EXISTS chapters;
+DROP TABLE IF EXISTS books;
+DROP TABLE IF EXISTS categories;
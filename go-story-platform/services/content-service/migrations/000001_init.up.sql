@@ -0,0 +1,58 @@
+-- Schema ban đầu, giống với những gì AutoMigrate đã tạo trước đây.
+-- Dùng IF NOT EXISTS để database cũ (tạo bằng AutoMigrate) có thể chuyển sang migration.
+CREATE TABLE IF NOT EXISTS categories (
+    id         BIGSERIAL PRIMARY KEY,
+    name       TEXT NOT NULL,
+    slug       TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    CONSTRAINT uni_categories_name UNIQUE (name),
+    CONSTRAINT uni_categories_slug UNIQUE (slug)
+);
+CREATE INDEX IF NOT EXISTS idx_categories_slug ON categories (slug);
+CREATE INDEX IF NOT EXISTS idx_categories_deleted_at ON categories (deleted_at);
+
+CREATE TABLE IF NOT EXISTS books (
+    id          BIGSERIAL PRIMARY KEY,
+    title       TEXT NOT NULL,
+    author_id   BIGINT,
+    category_id BIGINT,
+    description TEXT,
+    is_premium  BOOLEAN DEFAULT false,
+    price       BIGINT NOT NULL DEFAULT 0,
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ,
+    deleted_at  TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_books_author_id ON books (author_id);
+CREATE INDEX IF NOT EXISTS idx_books_category_id ON books (category_id);
+CREATE INDEX IF NOT EXISTS idx_books_deleted_at ON books (deleted_at);
+
+CREATE TABLE IF NOT EXISTS chapters (
+    id             BIGSERIAL PRIMARY KEY,
+    book_id        BIGINT,
+    chapter_number BIGINT NOT NULL,
+    contenr_url    TEXT NOT NULL,
+    price          BIGINT NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ,
+    deleted_at     TIMESTAMPTZ,
+    CONSTRAINT fk_books_chapters FOREIGN KEY (book_id) REFERENCES books (id) ON UPDATE CASCADE ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_chapters_book_id ON chapters (book_id);
+CREATE INDEX IF NOT EXISTS idx_chapters_deleted_at ON chapters (deleted_at);
+
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    id           BIGSERIAL PRIMARY KEY,
+    event_id     VARCHAR(36) NOT NULL,
+    type         TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    payload      JSONB NOT NULL,
+    created_at   TIMESTAMPTZ,
+    published_at TIMESTAMPTZ,
+    attempts     BIGINT NOT NULL DEFAULT 0,
+    last_error   TEXT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_messages_event_id ON outbox_messages (event_id);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_published_at ON outbox_messages (published_at);
@@ -0,0 +1 @@
+ALTER TABLE chapters RENAME COLUMN content_url TO contenr_url;
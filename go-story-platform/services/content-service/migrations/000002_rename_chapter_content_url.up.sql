@@ -0,0 +1,2 @@
+-- Sửa lỗi chính tả tên cột (Chapter.ContenrURL)
+ALTER TABLE chapters RENAME COLUMN contenr_url TO content_url;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_outbox_messages_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_published_at ON outbox_messages (published_at);
+
+DROP INDEX IF EXISTS idx_chapters_book_number_active;
+
+DROP INDEX IF EXISTS idx_categories_slug_active;
+DROP INDEX IF EXISTS idx_categories_name_active;
+CREATE INDEX IF NOT EXISTS idx_categories_slug ON categories (slug);
+ALTER TABLE categories ADD CONSTRAINT uni_categories_slug UNIQUE (slug);
+ALTER TABLE categories ADD CONSTRAINT uni_categories_name UNIQUE (name);
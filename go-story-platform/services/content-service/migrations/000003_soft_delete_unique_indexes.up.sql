@@ -0,0 +1,14 @@
+-- Ràng buộc UNIQUE cũ tính cả dòng đã soft delete nên không thể tạo lại thể loại trùng tên
+-- với thể loại đã xóa. Thay bằng unique index chỉ áp dụng cho các dòng chưa xóa.
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS uni_categories_name;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS uni_categories_slug;
+DROP INDEX IF EXISTS idx_categories_slug;
+CREATE UNIQUE INDEX idx_categories_name_active ON categories (name) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_categories_slug_active ON categories (slug) WHERE deleted_at IS NULL;
+
+-- Số thứ tự chương là duy nhất trong một truyện (trước đây chỉ kiểm tra ở handler)
+CREATE UNIQUE INDEX idx_chapters_book_number_active ON chapters (book_id, chapter_number) WHERE deleted_at IS NULL;
+
+-- Relay chỉ quét các event chưa gửi
+DROP INDEX IF EXISTS idx_outbox_messages_published_at;
+CREATE INDEX idx_outbox_messages_pending ON outbox_messages (id) WHERE published_at IS NULL;
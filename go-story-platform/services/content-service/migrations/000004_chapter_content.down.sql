@@ -0,0 +1,4 @@
+ALTER TABLE chapters DROP COLUMN IF EXISTS content_type;
+ALTER TABLE chapters DROP COLUMN IF EXISTS content_size;
+ALTER TABLE chapters DROP COLUMN IF EXISTS content_cid;
+ALTER TABLE chapters ALTER COLUMN content_url DROP DEFAULT;
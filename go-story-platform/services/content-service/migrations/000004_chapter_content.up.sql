@@ -0,0 +1,5 @@
+-- Nội dung chương tải lên được lưu trong BlobStore với khóa là IPFS CID
+ALTER TABLE chapters ALTER COLUMN content_url SET DEFAULT '';
+ALTER TABLE chapters ADD COLUMN IF NOT EXISTS content_cid VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE chapters ADD COLUMN IF NOT EXISTS content_size BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE chapters ADD COLUMN IF NOT EXISTS content_type VARCHAR(100) NOT NULL DEFAULT '';
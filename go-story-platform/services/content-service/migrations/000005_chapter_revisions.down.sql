@@ -0,0 +1,3 @@
+ALTER TABLE chapters DROP COLUMN IF EXISTS published_revision;
+ALTER TABLE chapters DROP COLUMN IF EXISTS latest_revision;
+DROP TABLE IF EXISTS chapter_revisions;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS chapter_revisions (
+    id           BIGSERIAL PRIMARY KEY,
+    chapter_id   BIGINT NOT NULL,
+    revision     BIGINT NOT NULL,
+    author_id    BIGINT NOT NULL,
+    content_url  TEXT NOT NULL,
+    content_cid  VARCHAR(64) NOT NULL DEFAULT '',
+    content_size BIGINT NOT NULL DEFAULT 0,
+    content_type VARCHAR(100) NOT NULL DEFAULT '',
+    change_note  VARCHAR(500) NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ,
+    CONSTRAINT fk_chapter_revisions_chapter FOREIGN KEY (chapter_id) REFERENCES chapters (id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_chapter_revisions_chapter_revision ON chapter_revisions (chapter_id, revision);
+
+ALTER TABLE chapters ADD COLUMN IF NOT EXISTS latest_revision BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE chapters ADD COLUMN IF NOT EXISTS published_revision BIGINT NOT NULL DEFAULT 0;
+
+-- Nội dung hiện có của các chương trở thành revision 1, được xuất bản sẵn
+INSERT INTO chapter_revisions (chapter_id, revision, author_id, content_url, content_cid, content_size, content_type, change_note, created_at)
+SELECT c.id, 1, COALESCE(b.author_id, 0), c.content_url, c.content_cid, c.content_size, c.content_type, 'Phiên bản ban đầu', c.updated_at
+FROM chapters c
+LEFT JOIN books b ON b.id = c.book_id
+WHERE c.content_url <> ''
+ON CONFLICT DO NOTHING;
+
+UPDATE chapters SET latest_revision = 1, published_revision = 1
+WHERE content_url <> '' AND latest_revision = 0;
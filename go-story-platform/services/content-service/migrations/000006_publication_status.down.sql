@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_chapters_scheduled;
+DROP INDEX IF EXISTS idx_books_scheduled;
+DROP INDEX IF EXISTS idx_chapters_status;
+DROP INDEX IF EXISTS idx_books_status;
+
+ALTER TABLE chapters DROP COLUMN IF EXISTS published_at;
+ALTER TABLE chapters DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE chapters DROP COLUMN IF EXISTS status;
+ALTER TABLE books DROP COLUMN IF EXISTS published_at;
+ALTER TABLE books DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE books DROP COLUMN IF EXISTS status;
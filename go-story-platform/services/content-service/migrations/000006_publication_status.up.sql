@@ -0,0 +1,17 @@
+-- Vòng đời xuất bản: draft, scheduled, published, unlisted, taken_down
+ALTER TABLE books ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'draft';
+ALTER TABLE books ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ;
+ALTER TABLE books ADD COLUMN IF NOT EXISTS published_at TIMESTAMPTZ;
+ALTER TABLE chapters ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'draft';
+ALTER TABLE chapters ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ;
+ALTER TABLE chapters ADD COLUMN IF NOT EXISTS published_at TIMESTAMPTZ;
+
+-- Trước đây mọi thứ hiển thị ngay khi tạo nên dữ liệu cũ được coi là đã xuất bản
+UPDATE books SET status = 'published', published_at = created_at WHERE published_at IS NULL;
+UPDATE chapters SET status = 'published', published_at = created_at WHERE published_at IS NULL AND published_revision > 0;
+
+CREATE INDEX IF NOT EXISTS idx_books_status ON books (status);
+CREATE INDEX IF NOT EXISTS idx_chapters_status ON chapters (status);
+-- Scheduler chỉ quét các dòng đang hẹn giờ
+CREATE INDEX IF NOT EXISTS idx_books_scheduled ON books (publish_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS idx_chapters_scheduled ON chapters (publish_at) WHERE status = 'scheduled';
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_chapters_search_pending;
+DROP INDEX IF EXISTS idx_chapters_search_vector;
+ALTER TABLE chapters DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE chapters DROP COLUMN IF EXISTS search_cid;
+ALTER TABLE chapters DROP COLUMN IF EXISTS search_text;
+
+DROP TRIGGER IF EXISTS trg_categories_search_refresh ON categories;
+DROP FUNCTION IF EXISTS categories_search_refresh();
+DROP INDEX IF EXISTS idx_books_search_vector;
+DROP TRIGGER IF EXISTS trg_books_search_vector ON books;
+DROP FUNCTION IF EXISTS books_search_vector_update();
+ALTER TABLE books DROP COLUMN IF EXISTS search_vector;
+
+DROP TEXT SEARCH CONFIGURATION IF EXISTS vn_unaccent;
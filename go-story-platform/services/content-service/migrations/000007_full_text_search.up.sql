@@ -0,0 +1,58 @@
+-- Tìm kiếm toàn văn. Cấu hình vn_unaccent bỏ dấu tiếng Việt (kể cả đ → d) rồi chuyển chữ thường,
+-- nên "truyện kiếm hiệp" và "truyen kiem hiep" cho cùng một kết quả, còn ts_headline vẫn
+-- đánh dấu đúng từ có dấu trong văn bản gốc.
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'vn_unaccent') THEN
+        CREATE TEXT SEARCH CONFIGURATION vn_unaccent (COPY = simple);
+        ALTER TEXT SEARCH CONFIGURATION vn_unaccent
+            ALTER MAPPING FOR hword, hword_part, word WITH unaccent, simple;
+    END IF;
+END
+$$;
+
+-- Truyện: tên (A), tên thể loại (B), mô tả (C). Tên thể loại nằm ở bảng khác nên dùng trigger.
+ALTER TABLE books ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+
+CREATE OR REPLACE FUNCTION books_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('vn_unaccent', coalesce(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('vn_unaccent', coalesce((SELECT name FROM categories WHERE id = NEW.category_id), '')), 'B') ||
+        setweight(to_tsvector('vn_unaccent', coalesce(NEW.description, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_books_search_vector ON books;
+CREATE TRIGGER trg_books_search_vector
+    BEFORE INSERT OR UPDATE OF title, description, category_id ON books
+    FOR EACH ROW EXECUTE FUNCTION books_search_vector_update();
+
+-- Đổi tên thể loại thì tính lại search_vector của các truyện thuộc thể loại đó
+CREATE OR REPLACE FUNCTION categories_search_refresh() RETURNS trigger AS $$
+BEGIN
+    UPDATE books SET category_id = category_id WHERE category_id = NEW.id;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_categories_search_refresh ON categories;
+CREATE TRIGGER trg_categories_search_refresh
+    AFTER UPDATE OF name ON categories
+    FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+    EXECUTE FUNCTION categories_search_refresh();
+
+UPDATE books SET title = title;
+CREATE INDEX IF NOT EXISTS idx_books_search_vector ON books USING GIN (search_vector);
+
+-- Chương: văn bản của revision đang xuất bản, do search indexer đọc từ BlobStore và ghi vào search_text.
+-- search_cid là CID đã được index, khác content_cid nghĩa là cần index lại.
+ALTER TABLE chapters ADD COLUMN IF NOT EXISTS search_text TEXT NOT NULL DEFAULT '';
+ALTER TABLE chapters ADD COLUMN IF NOT EXISTS search_cid VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE chapters ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector('vn_unaccent', search_text)) STORED;
+CREATE INDEX IF NOT EXISTS idx_chapters_search_vector ON chapters USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_chapters_search_pending ON chapters (id) WHERE content_cid <> search_cid;
@@ -0,0 +1,41 @@
+-- Quay về một thể loại cho mỗi truyện: giữ thể loại có ID nhỏ nhất, thẻ và cây thể loại bị bỏ
+ALTER TABLE books ADD COLUMN IF NOT EXISTS category_id BIGINT;
+UPDATE books b SET category_id = (SELECT min(category_id) FROM book_categories WHERE book_id = b.id);
+CREATE INDEX IF NOT EXISTS idx_books_category_id ON books (category_id);
+
+DROP TRIGGER IF EXISTS trg_book_tags_search_refresh ON book_tags;
+DROP TRIGGER IF EXISTS trg_book_categories_search_refresh ON book_categories;
+DROP FUNCTION IF EXISTS book_taxonomy_search_refresh();
+
+CREATE OR REPLACE FUNCTION books_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('vn_unaccent', coalesce(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('vn_unaccent', coalesce((SELECT name FROM categories WHERE id = NEW.category_id), '')), 'B') ||
+        setweight(to_tsvector('vn_unaccent', coalesce(NEW.description, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_books_search_vector ON books;
+CREATE TRIGGER trg_books_search_vector
+    BEFORE INSERT OR UPDATE OF title, description, category_id ON books
+    FOR EACH ROW EXECUTE FUNCTION books_search_vector_update();
+
+CREATE OR REPLACE FUNCTION categories_search_refresh() RETURNS trigger AS $$
+BEGIN
+    UPDATE books SET category_id = category_id WHERE category_id = NEW.id;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS book_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS book_categories;
+
+DROP INDEX IF EXISTS idx_categories_parent_id;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS chk_categories_parent_not_self;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS fk_categories_parent;
+ALTER TABLE categories DROP COLUMN IF EXISTS parent_id;
+
+UPDATE books SET title = title;
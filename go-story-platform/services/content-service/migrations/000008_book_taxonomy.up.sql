@@ -0,0 +1,97 @@
+-- Phân loại truyện: một truyện thuộc nhiều thể loại (book_categories), thể loại có thể lồng nhau
+-- (parent_id) và truyện được gắn thẻ tự do (tags, book_tags). Cột books.category_id được chuyển
+-- sang book_categories rồi bỏ đi.
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS parent_id BIGINT;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS fk_categories_parent;
+ALTER TABLE categories ADD CONSTRAINT fk_categories_parent
+    FOREIGN KEY (parent_id) REFERENCES categories (id) ON DELETE SET NULL;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS chk_categories_parent_not_self;
+ALTER TABLE categories ADD CONSTRAINT chk_categories_parent_not_self CHECK (parent_id <> id);
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories (parent_id);
+
+CREATE TABLE IF NOT EXISTS book_categories (
+    book_id     BIGINT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    category_id BIGINT NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    PRIMARY KEY (book_id, category_id)
+);
+CREATE INDEX IF NOT EXISTS idx_book_categories_category_id ON book_categories (category_id);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id         BIGSERIAL PRIMARY KEY,
+    name       TEXT NOT NULL,
+    slug       TEXT NOT NULL,
+    created_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_slug ON tags (slug);
+
+CREATE TABLE IF NOT EXISTS book_tags (
+    book_id BIGINT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    tag_id  BIGINT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (book_id, tag_id)
+);
+CREATE INDEX IF NOT EXISTS idx_book_tags_tag_id ON book_tags (tag_id);
+
+INSERT INTO book_categories (book_id, category_id)
+SELECT b.id, b.category_id
+FROM books b
+JOIN categories c ON c.id = b.category_id
+WHERE b.category_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+-- search_vector: tên thể loại và tên thẻ cùng trọng số B
+CREATE OR REPLACE FUNCTION books_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('vn_unaccent', coalesce(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('vn_unaccent', coalesce((
+            SELECT string_agg(c.name, ' ')
+            FROM book_categories bc
+            JOIN categories c ON c.id = bc.category_id AND c.deleted_at IS NULL
+            WHERE bc.book_id = NEW.id), '')), 'B') ||
+        setweight(to_tsvector('vn_unaccent', coalesce((
+            SELECT string_agg(t.name, ' ')
+            FROM book_tags bt
+            JOIN tags t ON t.id = bt.tag_id
+            WHERE bt.book_id = NEW.id), '')), 'B') ||
+        setweight(to_tsvector('vn_unaccent', coalesce(NEW.description, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_books_search_vector ON books;
+ALTER TABLE books DROP COLUMN IF EXISTS category_id;
+CREATE TRIGGER trg_books_search_vector
+    BEFORE INSERT OR UPDATE OF title, description ON books
+    FOR EACH ROW EXECUTE FUNCTION books_search_vector_update();
+
+-- Gán/bỏ thể loại hoặc thẻ thì tính lại search_vector của truyện
+CREATE OR REPLACE FUNCTION book_taxonomy_search_refresh() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        UPDATE books SET title = title WHERE id = OLD.book_id;
+    ELSE
+        UPDATE books SET title = title WHERE id = NEW.book_id;
+    END IF;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_book_categories_search_refresh ON book_categories;
+CREATE TRIGGER trg_book_categories_search_refresh
+    AFTER INSERT OR DELETE ON book_categories
+    FOR EACH ROW EXECUTE FUNCTION book_taxonomy_search_refresh();
+
+DROP TRIGGER IF EXISTS trg_book_tags_search_refresh ON book_tags;
+CREATE TRIGGER trg_book_tags_search_refresh
+    AFTER INSERT OR DELETE ON book_tags
+    FOR EACH ROW EXECUTE FUNCTION book_taxonomy_search_refresh();
+
+CREATE OR REPLACE FUNCTION categories_search_refresh() RETURNS trigger AS $$
+BEGIN
+    UPDATE books SET title = title
+    WHERE id IN (SELECT book_id FROM book_categories WHERE category_id = NEW.id);
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+UPDATE books SET title = title;
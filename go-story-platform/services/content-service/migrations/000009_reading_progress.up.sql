@@ -0,0 +1,22 @@
+-- Tiến độ đọc của người đọc, mỗi chương một dòng. client_updated_at là thời điểm trên thiết bị
+-- đã ghi tiến độ, dùng để đồng bộ last-write-wins giữa các thiết bị; updated_at là thời điểm
+-- server lưu, dùng làm con trỏ khi thiết bị kéo thay đổi về.
+CREATE TABLE IF NOT EXISTS reading_progress (
+    user_id           BIGINT NOT NULL,
+    chapter_id        BIGINT NOT NULL REFERENCES chapters (id) ON DELETE CASCADE,
+    book_id           BIGINT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    position          BIGINT NOT NULL DEFAULT 0,
+    percentage        REAL NOT NULL DEFAULT 0,
+    completed         BOOLEAN NOT NULL DEFAULT false,
+    client_updated_at TIMESTAMPTZ NOT NULL,
+    created_at        TIMESTAMPTZ,
+    updated_at        TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, chapter_id),
+    CONSTRAINT chk_reading_progress_percentage CHECK (percentage >= 0 AND percentage <= 100)
+);
+-- Kệ "đọc tiếp": chương đọc gần nhất của mỗi truyện
+CREATE INDEX IF NOT EXISTS idx_reading_progress_user_book ON reading_progress (user_id, book_id, client_updated_at DESC);
+-- Thiết bị kéo các thay đổi sau một thời điểm
+CREATE INDEX IF NOT EXISTS idx_reading_progress_user_updated ON reading_progress (user_id, updated_at);
+-- Thống kê hoàn thành của truyện
+CREATE INDEX IF NOT EXISTS idx_reading_progress_book ON reading_progress (book_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS chapter_comments;
+DROP TABLE IF EXISTS book_rating_stats;
+DROP TABLE IF EXISTS book_reviews;
@@ -0,0 +1,58 @@
+-- Đánh giá truyện: mỗi người đọc một đánh giá (1-5 sao) cho mỗi truyện, kèm nhận xét tùy chọn.
+-- status = hidden khi bị admin ẩn; đánh giá bị ẩn không được tính vào điểm trung bình.
+CREATE TABLE IF NOT EXISTS book_reviews (
+    id                BIGSERIAL PRIMARY KEY,
+    book_id           BIGINT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    user_id           BIGINT NOT NULL,
+    rating            SMALLINT NOT NULL,
+    body              TEXT NOT NULL DEFAULT '',
+    spoiler           BOOLEAN NOT NULL DEFAULT false,
+    status            VARCHAR(20) NOT NULL DEFAULT 'visible',
+    moderated_by      BIGINT,
+    moderated_at      TIMESTAMPTZ,
+    moderation_reason TEXT NOT NULL DEFAULT '',
+    edited_at         TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ,
+    updated_at        TIMESTAMPTZ,
+    deleted_at        TIMESTAMPTZ,
+    CONSTRAINT chk_book_reviews_rating CHECK (rating BETWEEN 1 AND 5)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_book_reviews_book_user_active ON book_reviews (book_id, user_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_book_reviews_book_visible ON book_reviews (book_id, id DESC) WHERE deleted_at IS NULL AND status = 'visible';
+CREATE INDEX IF NOT EXISTS idx_book_reviews_deleted_at ON book_reviews (deleted_at);
+
+-- Điểm tổng hợp của truyện, được cộng/trừ trong cùng transaction với thay đổi đánh giá
+CREATE TABLE IF NOT EXISTS book_rating_stats (
+    book_id      BIGINT PRIMARY KEY REFERENCES books (id) ON DELETE CASCADE,
+    rating_count BIGINT NOT NULL DEFAULT 0,
+    rating_sum   BIGINT NOT NULL DEFAULT 0,
+    count_1      BIGINT NOT NULL DEFAULT 0,
+    count_2      BIGINT NOT NULL DEFAULT 0,
+    count_3      BIGINT NOT NULL DEFAULT 0,
+    count_4      BIGINT NOT NULL DEFAULT 0,
+    count_5      BIGINT NOT NULL DEFAULT 0,
+    updated_at   TIMESTAMPTZ
+);
+
+-- Bình luận chương dạng cây (parent_id). Bình luận đã xóa giữ lại với status = deleted và body rỗng
+-- để các trả lời của nó vẫn hiển thị đúng chỗ. reply_count là số trả lời trực tiếp.
+CREATE TABLE IF NOT EXISTS chapter_comments (
+    id                BIGSERIAL PRIMARY KEY,
+    chapter_id        BIGINT NOT NULL REFERENCES chapters (id) ON DELETE CASCADE,
+    book_id           BIGINT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    user_id           BIGINT NOT NULL,
+    parent_id         BIGINT REFERENCES chapter_comments (id) ON DELETE CASCADE,
+    depth             SMALLINT NOT NULL DEFAULT 0,
+    body              TEXT NOT NULL DEFAULT '',
+    spoiler           BOOLEAN NOT NULL DEFAULT false,
+    reply_count       BIGINT NOT NULL DEFAULT 0,
+    status            VARCHAR(20) NOT NULL DEFAULT 'visible',
+    moderated_by      BIGINT,
+    moderated_at      TIMESTAMPTZ,
+    moderation_reason TEXT NOT NULL DEFAULT '',
+    edited_at         TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ,
+    updated_at        TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_chapter_comments_roots ON chapter_comments (chapter_id, id DESC) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_chapter_comments_parent ON chapter_comments (parent_id, id);
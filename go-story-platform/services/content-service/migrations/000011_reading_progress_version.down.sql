@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_reading_progress_user_version;
+CREATE INDEX IF NOT EXISTS idx_reading_progress_user_updated ON reading_progress (user_id, updated_at);
+
+DROP TRIGGER IF EXISTS trg_reading_progress_version ON reading_progress;
+DROP FUNCTION IF EXISTS reading_progress_version_update();
+
+-- Xóa cột cũng xóa reading_progress_version_seq (OWNED BY)
+ALTER TABLE reading_progress DROP COLUMN IF EXISTS version;
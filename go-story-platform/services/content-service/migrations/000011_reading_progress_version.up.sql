@@ -0,0 +1,21 @@
+-- Con trỏ đồng bộ tiến độ đọc: mỗi lần ghi một dòng reading_progress được cấp version mới từ
+-- sequence, tăng dần và không trùng. Thiết bị kéo các dòng có version lớn hơn version lớn nhất
+-- đã nhận thay vì so updated_at, vốn có thể trùng nhau hoặc lệch giữa các replica.
+CREATE SEQUENCE IF NOT EXISTS reading_progress_version_seq;
+ALTER TABLE reading_progress ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT nextval('reading_progress_version_seq');
+ALTER SEQUENCE reading_progress_version_seq OWNED BY reading_progress.version;
+
+CREATE OR REPLACE FUNCTION reading_progress_version_update() RETURNS trigger AS $$
+BEGIN
+    NEW.version := nextval('reading_progress_version_seq');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_reading_progress_version ON reading_progress;
+CREATE TRIGGER trg_reading_progress_version
+    BEFORE UPDATE ON reading_progress
+    FOR EACH ROW EXECUTE FUNCTION reading_progress_version_update();
+
+DROP INDEX IF EXISTS idx_reading_progress_user_updated;
+CREATE INDEX IF NOT EXISTS idx_reading_progress_user_version ON reading_progress (user_id, version);
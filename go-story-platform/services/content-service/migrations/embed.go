@@ -0,0 +1,10 @@
+// Package migrations nhúng các file migration SQL của content-service vào binary.
+// Tạo migration mới bằng: go run ./cmd migrate create <tên>
+package migrations
+
+import "embed"
+
+// FS chứa các file *.up.sql / *.down.sql
+//
+//go:embed *.sql
+var FS embed.FS
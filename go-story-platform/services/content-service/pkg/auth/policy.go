@@ -0,0 +1,41 @@
+package auth
+
+// Các vai trò lưu trong User.Role của user-service
+const (
+	RoleReader = "reader"
+	RoleAuthor = "author"
+	RoleAdmin  = "admin"
+)
+
+// Permission là một quyền thao tác cụ thể, được gán cho vai trò qua rolePermissions
+type Permission string
+
+const (
+	PermBookCreate      Permission = "book:create"      // Tạo truyện mới (trở thành tác giả của truyện)
+	PermBookManageAny   Permission = "book:manage:any"  // Sửa/xóa truyện và chương của bất kỳ tác giả nào
+	PermCategoryManage  Permission = "category:manage"  // Tạo/sửa/xóa thể loại
+	PermContentModerate Permission = "content:moderate" // Gỡ (take down) truyện/chương vi phạm, ẩn đánh giá/bình luận
+)
+
+// rolePermissions định nghĩa tập quyền của từng vai trò.
+// Quyền sửa truyện của chính mình được kiểm tra bằng Book.AuthorID, không nằm ở đây.
+var rolePermissions = map[string][]Permission{
+	RoleReader: {},
+	RoleAuthor: {PermBookCreate},
+	RoleAdmin: {
+		PermBookCreate,
+		PermBookManageAny,
+		PermCategoryManage,
+		PermContentModerate,
+	},
+}
+
+// HasPermission kiểm tra vai trò có quyền perm không
+func HasPermission(role string, perm Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"payment-service/internal/catalog"
+	"payment-service/internal/chain"
+	"payment-service/internal/indexer"
+	"payment-service/internal/nft"
+
+	"shared/config"
+	"shared/eventbus"
+	"shared/identity"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/rpc"
+)
+
+// Config là toàn bộ cấu hình của payment-service, nạp một lần lúc khởi động bằng config.MustLoad
+type Config struct {
+	HTTPPort int `env:"HTTP_PORT" default:"8082" min:"1"`
+	GRPCPort int `env:"GRPC_PORT" default:"9082" min:"1"`
+
+	Log       logging.Config
+	Lifecycle lifecycle.Config
+	Database  config.Database
+	JWT       config.JWT
+	CORS      config.CORS
+	EventBus  eventbus.Config
+	Gateway   identity.Config
+	GRPCTLS   rpc.TLSConfig
+
+	Catalog  catalog.Config
+	Chain    chain.Config
+	Deposits indexer.Config
+	NFT      nft.Config
+}
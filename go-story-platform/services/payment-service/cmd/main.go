@@ -1,21 +1,201 @@
 package main
 
 import (
-	"fmt"
+	"context"
+	"log"
+	"log/slog"
+	"os"
+	"payment-service/internal/catalog"
+	"payment-service/internal/chain"
+	"payment-service/internal/consumer"
 	"payment-service/internal/database"
+	"payment-service/internal/indexer"
+	"payment-service/internal/nft"
+	"payment-service/internal/repository"
+	"payment-service/internal/transport/grpc"
+	"payment-service/internal/transport/http"
+	"payment-service/internal/transport/http/middleware"
+	"payment-service/migrations"
+	"payment-service/pkg/auth"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	_ "payment-service/docs"
+
+	jwtauth "shared/auth"
+	"shared/config"
+	"shared/eventbus"
+	"shared/health"
+	"shared/identity"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/migrate"
+	"shared/outbox"
+	"shared/rpc"
+	"shared/rpc/paymentpb"
+
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+// @title Payment Service API
+
+// @version 1.0
+
+// @description API ví, giao dịch và mua truyện/chương (Gin + Swagger)
+
+// @host localhost:8082
+
+// @BasePath /
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 func main() {
-	// 1. Kết nối DB
-	db := database.InitDB()
+	// Lệnh quản lý schema: go run ./cmd migrate up|down|status|create, chỉ cần cấu hình database
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		var dbCfg config.Database
+		config.MustLoad(&dbCfg)
+		connect := func() *gorm.DB { return database.Connect(dbCfg) }
+		if err := migrate.RunCLI(os.Args[2:], connect, migrations.FS, "migrations"); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// 0. Nạp và kiểm tra cấu hình, dừng ngay nếu thiếu biến bắt buộc hoặc secret quá yếu
+	var cfg Config
+	config.MustLoad(&cfg)
+	if err := logging.Setup("payment-service", cfg.Log); err != nil {
+		log.Fatal("❌ Cấu hình log không hợp lệ: ", err)
+	}
+	config.LogEffective("Payment Service", &cfg)
+
+	// Các thành phần được dừng theo thứ tự ngược với lúc thêm vào app khi nhận SIGTERM:
+	// HTTP server trả lời xong request đang xử lý trước, database đóng sau cùng.
+	app := lifecycle.New("Payment Service", cfg.Lifecycle)
+
+	// 1. Kết nối DB và event bus. Relay đẩy event ChapterPurchased trong outbox lên broker,
+	// consumer tự tạo ví khi nhận UserRegistered từ user-service.
+	db := database.InitDB(cfg.Database)
+	app.Add(lifecycle.Closer("database", func() error { return database.Close(db) }))
+	broker, err := eventbus.New(cfg.EventBus, cfg.Database)
+	if err != nil {
+		log.Fatal("❌ Không thể khởi tạo event bus:", err)
+	}
+	app.Add(lifecycle.Closer("event bus", broker.Close))
+	app.Add(lifecycle.Worker("outbox relay", outbox.NewRelay(db, broker, "payment-service").Run))
+	app.Add(lifecycle.Background("event consumers", func(ctx context.Context) error {
+		return consumer.Register(ctx, broker, db)
+	}))
+
+	// Kết nối blockchain và chạy deposit indexer. Chain mô phỏng tự đào block theo chu kỳ.
+	chainClient, err := chain.New(cfg.Chain)
+	if err != nil {
+		log.Fatal("❌ Không thể khởi tạo chain client:", err)
+	}
+	if sim, ok := chainClient.(*chain.SimulatedChain); ok {
+		slog.Warn("Chain: CHAIN_DRIVER=simulated, tiền nạp và NFT không có thật, không dùng cho production")
+		app.Add(lifecycle.Worker("simulated chain", sim.Run))
+	}
+	depositIndexer, err := indexer.New(repository.NewDepositRepository(db), chainClient, cfg.Deposits)
+	if err != nil {
+		log.Fatal("❌ Không thể khởi tạo deposit indexer:", err)
+	}
+	app.Add(lifecycle.Worker("deposit indexer", depositIndexer.Run))
 
-	// Thử kiểm tra kết nối bằng cách in ra thông tin DB
-	sqlDB, _ := db.DB()
-	err := sqlDB.Ping()
-	if err == nil {
-		fmt.Println("🚀 User Service đang chạy và sẵn sàng!")
+	// Đúc token sở hữu cho các lần mua của user đã liên kết ví
+	nftRepo := repository.NewNFTRepository(db)
+	app.Add(lifecycle.Worker("NFT minter", nft.New(nftRepo, chainClient, cfg.NFT).Run))
+
+	// 2. Khởi tạo Repository & Handler
+	ledgerRepo := repository.NewLedgerRepository(db)
+	purchaseRepo := repository.NewPurchaseRepository(db)
+	walletHandler := http.NewWalletHandler(ledgerRepo)
+	contentCatalog, err := catalog.New(cfg.Catalog, cfg.GRPCTLS)
+	if err != nil {
+		log.Fatal("❌ Không thể khởi tạo kết nối tới content-service:", err)
+	}
+	purchaseHandler := http.NewPurchaseHandler(purchaseRepo, contentCatalog)
+	nftHandler := http.NewNFTHandler(nftRepo, chainClient, contentCatalog)
+	entitlementHandler := http.NewEntitlementHandler(purchaseRepo)
+
+	// gRPC nội bộ cho content-service hỏi quyền đọc, chạy song song với Gin
+	grpcServer, err := rpc.NewServer(cfg.GRPCTLS)
+	if err != nil {
+		log.Fatal("❌ Không thể khởi tạo gRPC server:", err)
 	}
+	paymentpb.RegisterPaymentServiceServer(grpcServer, grpc.NewPaymentServer(ledgerRepo, purchaseRepo))
+	app.Add(lifecycle.GRPC("gRPC server", grpcServer, cfg.GRPCTLS.ListenAddr(cfg.GRPCPort)))
+
+	// Token do user-service cấp; request qua API gateway mang danh tính đã ký bằng GATEWAY_IDENTITY_SECRET
+	requireAuth := middleware.AuthMiddleware(jwtauth.NewVerifier(cfg.JWT), identity.NewSigner(cfg.Gateway))
+
+	// 3. Khởi tạo Gin
+	// Request ID và access log JSON thay cho logger mặc định của Gin; panic trả 500 với message chung
+	r := gin.New()
+	r.Use(logging.Middleware(), logging.Recovery())
 
-	// Sau này bạn sẽ khởi chạy Server HTTP (Gin) hoặc gRPC ở đây
-	select {} // Giữ cho main không bị thoát
+	// Cấu hình CORS để UI có thể gọi API
+	r.Use(cors.New(cors.Config{
+		AllowOrigins:     cfg.CORS.AllowedOrigins, // URL của UI, cấu hình qua CORS_ALLOWED_ORIGINS
+		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", logging.HeaderRequestID},
+		ExposeHeaders:    []string{logging.HeaderRequestID},
+		AllowCredentials: true,
+	}))
+
+	// 4. Swagger endpoint
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	// Liveness và readiness cho orchestrator/load balancer; /readyz trả 503 ngay khi bắt đầu dừng
+	checker := health.New()
+	checker.Add("database", health.DB(db))
+	checker.Add("event bus", broker.Ping)
+	checker.Add("content-service", health.HTTP(cfg.Catalog.ServiceURL+"/healthz"))
+	app.OnShutdown(checker.Drain)
+	r.GET("/healthz", gin.WrapF(checker.Liveness))
+	r.GET("/readyz", gin.WrapF(checker.Readiness))
+
+	// 5. Định nghĩa Routes
+
+	// Mọi route đều cần đăng nhập; ví và lịch sử luôn là của user trong token
+	wallet := r.Group("/wallet", requireAuth)
+	{
+		wallet.GET("", walletHandler.GetWallet)
+		wallet.GET("/transactions", walletHandler.ListTransactions)
+		wallet.POST("/deposits", walletHandler.CreateDeposit)
+	}
+
+	// Xác nhận / hủy giao dịch nạp tiền chỉ dành cho admin
+	transactions := r.Group("/transactions", requireAuth, middleware.RequirePermission(auth.PermTransactionSettle))
+	{
+		transactions.POST("/:id/confirm", walletHandler.ConfirmTransaction)
+		transactions.POST("/:id/fail", walletHandler.FailTransaction)
+	}
+
+	purchases := r.Group("/purchases", requireAuth)
+	{
+		purchases.GET("", purchaseHandler.ListPurchases)
+		purchases.POST("/books", purchaseHandler.PurchaseBook)
+		purchases.POST("/chapters", purchaseHandler.PurchaseChapter)
+	}
+
+	// Content-service chuyển tiếp token của người đọc để hỏi quyền đọc chương premium
+	r.GET("/entitlements", requireAuth, entitlementHandler.CheckEntitlement)
+
+	// Metadata công khai cho ví/sàn NFT; đối soát chủ token cần đăng nhập
+	nfts := r.Group("/nfts")
+	{
+		nfts.GET("/metadata/:purchase_id", nftHandler.Metadata)
+		nfts.POST("/:token_id/verify", requireAuth, nftHandler.VerifyOwnership)
+	}
+
+	// 6. Chạy Server cùng các thành phần đã đăng ký cho tới khi nhận SIGINT/SIGTERM
+	app.Add(lifecycle.HTTP("HTTP server", cfg.HTTPPort, r))
+	if err := app.Run(); err != nil {
+		log.Fatal("❌ Payment Service dừng do lỗi: ", err)
+	}
 }
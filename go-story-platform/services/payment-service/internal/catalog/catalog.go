@@ -0,0 +1,139 @@
+// Package catalog lấy thông tin giá truyện/chương từ content-service.
+// Payment-service không tin giá do client gửi lên mà luôn hỏi lại content-service.
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"shared/logging"
+	"shared/rpc"
+)
+
+// ErrNotFound được trả về khi truyện hoặc chương không tồn tại bên content-service
+var ErrNotFound = errors.New("nội dung không tồn tại")
+
+// Book là thông tin bán của một truyện
+type Book struct {
+	ID        uint   `json:"id"`
+	Title     string `json:"title"`
+	IsPremium bool   `json:"is_premium"`
+	Price     int    `json:"price"`
+}
+
+// Chapter là thông tin bán lẻ của một chương
+type Chapter struct {
+	ID            uint `json:"id"`
+	BookID        uint `json:"book_id"`
+	ChapterNumber int  `json:"chapter_number"`
+	Price         int  `json:"price"`
+}
+
+// Catalog là interface tra cứu giá, cho phép thay bằng bản giả khi phát triển.
+// ctx là context của request đang xử lý, mang theo deadline và request ID sang content-service.
+type Catalog interface {
+	GetBook(ctx context.Context, bookID uint) (*Book, error)
+	GetChapter(ctx context.Context, bookID, chapterID uint) (*Chapter, error)
+	// ResourceURL trả về địa chỉ công khai của truyện (chapterNumber = 0) hoặc chương
+	ResourceURL(bookID uint, chapterNumber int) string
+}
+
+// HTTPCatalog gọi HTTP API công khai của content-service
+type HTTPCatalog struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPCatalog tạo HTTPCatalog trỏ tới baseURL của content-service
+func NewHTTPCatalog(baseURL string) *HTTPCatalog {
+	return &HTTPCatalog{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Config là cách kết nối tới content-service. Có CONTENT_GRPC_ADDR thì tra cứu qua gRPC nội bộ,
+// ngược lại gọi HTTP API tại CONTENT_SERVICE_URL (vẫn dùng để dựng ResourceURL).
+type Config struct {
+	ServiceURL string `env:"CONTENT_SERVICE_URL" default:"http://localhost:8081"`
+	GRPCAddr   string `env:"CONTENT_GRPC_ADDR"`
+}
+
+// New tạo Catalog theo cfg, tls là chứng chỉ dùng khi gọi gRPC
+func New(cfg Config, tls rpc.TLSConfig) (Catalog, error) {
+	if cfg.GRPCAddr != "" {
+		conn, err := rpc.Dial(cfg.GRPCAddr, tls)
+		if err != nil {
+			return nil, err
+		}
+		return NewGRPCCatalog(conn, cfg.ServiceURL), nil
+	}
+	return NewHTTPCatalog(cfg.ServiceURL), nil
+}
+
+// GetBook lấy thông tin bán của truyện qua GET /books/{id}
+func (c *HTTPCatalog) GetBook(ctx context.Context, bookID uint) (*Book, error) {
+	var book Book
+	if err := c.get(ctx, fmt.Sprintf("/books/%d", bookID), &book); err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// GetChapter lấy thông tin bán của chương qua GET /books/{id}/chapters
+func (c *HTTPCatalog) GetChapter(ctx context.Context, bookID, chapterID uint) (*Chapter, error) {
+	var chapters []Chapter
+	if err := c.get(ctx, fmt.Sprintf("/books/%d/chapters", bookID), &chapters); err != nil {
+		return nil, err
+	}
+	for i := range chapters {
+		if chapters[i].ID == chapterID {
+			return &chapters[i], nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// ResourceURL trả về URL của truyện hoặc chương trên content-service
+func (c *HTTPCatalog) ResourceURL(bookID uint, chapterNumber int) string {
+	if chapterNumber == 0 {
+		return fmt.Sprintf("%s/books/%d", c.baseURL, bookID)
+	}
+	return fmt.Sprintf("%s/books/%d/chapters/%d", c.baseURL, bookID, chapterNumber)
+}
+
+// get gọi content-service và giải mã trường data của ApiResponse vào out
+func (c *HTTPCatalog) get(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(logging.HeaderRequestID, logging.RequestID(ctx))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("content-service trả về mã %d", resp.StatusCode)
+	}
+
+	body := struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return err
+	}
+	return json.Unmarshal(body.Data, out)
+}
@@ -0,0 +1,75 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"shared/rpc/contentpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCCatalog tra cứu giá qua ContentService gRPC nội bộ. baseURL của HTTP API công khai
+// vẫn cần để dựng ResourceURL cho metadata NFT.
+type GRPCCatalog struct {
+	client  contentpb.ContentServiceClient
+	baseURL string
+}
+
+// NewGRPCCatalog tạo GRPCCatalog trên kết nối tới content-service
+func NewGRPCCatalog(conn grpc.ClientConnInterface, baseURL string) *GRPCCatalog {
+	return &GRPCCatalog{
+		client:  contentpb.NewContentServiceClient(conn),
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// GetBook lấy thông tin bán của truyện qua ContentService.GetBook
+func (c *GRPCCatalog) GetBook(ctx context.Context, bookID uint) (*Book, error) {
+	book, err := c.client.GetBook(ctx, &contentpb.GetBookRequest{Id: uint64(bookID)})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &Book{
+		ID:        uint(book.GetId()),
+		Title:     book.GetTitle(),
+		IsPremium: book.GetIsPremium(),
+		Price:     int(book.GetPrice()),
+	}, nil
+}
+
+// GetChapter lấy thông tin bán của chương qua ContentService.GetChapter
+func (c *GRPCCatalog) GetChapter(ctx context.Context, bookID, chapterID uint) (*Chapter, error) {
+	chapter, err := c.client.GetChapter(ctx, &contentpb.GetChapterRequest{
+		BookId:    uint64(bookID),
+		ChapterId: uint64(chapterID),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &Chapter{
+		ID:            uint(chapter.GetId()),
+		BookID:        uint(chapter.GetBookId()),
+		ChapterNumber: int(chapter.GetChapterNumber()),
+		Price:         int(chapter.GetPrice()),
+	}, nil
+}
+
+// ResourceURL trả về URL của truyện hoặc chương trên content-service
+func (c *GRPCCatalog) ResourceURL(bookID uint, chapterNumber int) string {
+	if chapterNumber == 0 {
+		return fmt.Sprintf("%s/books/%d", c.baseURL, bookID)
+	}
+	return fmt.Sprintf("%s/books/%d/chapters/%d", c.baseURL, bookID, chapterNumber)
+}
+
+// translateError đổi NOT_FOUND của gRPC thành ErrNotFound để handler xử lý như HTTPCatalog
+func translateError(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return ErrNotFound
+	}
+	return err
+}
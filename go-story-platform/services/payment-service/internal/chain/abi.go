@@ -0,0 +1,137 @@
+package chain
+
+import (
+	"encoding/hex"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// TransferTopic là keccak256("Transfer(address,address,uint256)"), chữ ký event Transfer
+// chung cho ERC-20 và ERC-721
+const TransferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+// ZeroAddress là địa chỉ 0, xuất hiện ở trường from của event Transfer khi đúc token
+const ZeroAddress = "0x0000000000000000000000000000000000000000"
+
+var addressRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// TransferEvent là event Transfer đã giải mã. Với ERC-721 TokenID khác nil,
+// với ERC-20 Value khác nil.
+type TransferEvent struct {
+	Contract string
+	From     string
+	To       string
+	Value    *big.Int
+	TokenID  *big.Int
+}
+
+// NormalizeAddress kiểm tra và đưa địa chỉ về chữ thường để so sánh
+func NormalizeAddress(address string) (string, error) {
+	if !addressRegex.MatchString(address) {
+		return "", ErrInvalidAddress
+	}
+	return strings.ToLower(address), nil
+}
+
+// DecodeTransfer giải mã log Transfer của ERC-20 (3 topic + data) hoặc ERC-721 (4 topic)
+func DecodeTransfer(log Log) (TransferEvent, bool) {
+	if len(log.Topics) < 3 || !strings.EqualFold(log.Topics[0], TransferTopic) {
+		return TransferEvent{}, false
+	}
+
+	event := TransferEvent{
+		Contract: strings.ToLower(log.Address),
+		From:     topicToAddress(log.Topics[1]),
+		To:       topicToAddress(log.Topics[2]),
+	}
+	switch {
+	case len(log.Topics) == 4:
+		event.TokenID = topicToInt(log.Topics[3])
+	case len(log.Data) == 32:
+		event.Value = new(big.Int).SetBytes(log.Data)
+	default:
+		return TransferEvent{}, false
+	}
+	return event, true
+}
+
+// MintedTokenID lấy token ID từ event Transfer(0x0 -> to) trong receipt của giao dịch đúc token
+func MintedTokenID(receipt *Receipt) (*big.Int, bool) {
+	for _, log := range receipt.Logs {
+		if event, ok := DecodeTransfer(log); ok && event.TokenID != nil && event.From == ZeroAddress {
+			return event.TokenID, true
+		}
+	}
+	return nil, false
+}
+
+// keccak256 băm dữ liệu bằng Keccak-256 (bản gốc, không phải SHA3-256 chuẩn NIST)
+func keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// selector trả về 4 byte đầu của keccak256(chữ ký hàm), dùng để gọi hàm contract
+func selector(signature string) []byte {
+	return keccak256([]byte(signature))[:4]
+}
+
+// addressToTopic đệm địa chỉ 20 byte thành topic 32 byte
+func addressToTopic(address string) string {
+	return "0x" + strings.Repeat("0", 24) + strings.TrimPrefix(strings.ToLower(address), "0x")
+}
+
+// intToTopic mã hóa số nguyên không âm thành topic/word 32 byte
+func intToTopic(n *big.Int) string {
+	return "0x" + hex.EncodeToString(word(n))
+}
+
+func topicToAddress(topic string) string {
+	raw := strings.TrimPrefix(strings.ToLower(topic), "0x")
+	if len(raw) < 40 {
+		return ZeroAddress
+	}
+	return "0x" + raw[len(raw)-40:]
+}
+
+func topicToInt(topic string) *big.Int {
+	n, _ := new(big.Int).SetString(strings.TrimPrefix(topic, "0x"), 16)
+	if n == nil {
+		return new(big.Int)
+	}
+	return n
+}
+
+// word mã hóa số nguyên thành một word ABI 32 byte (big-endian)
+func word(n *big.Int) []byte {
+	out := make([]byte, 32)
+	n.FillBytes(out)
+	return out
+}
+
+// encodeAddressWord đệm địa chỉ 20 byte thành một word ABI 32 byte
+func encodeAddressWord(address string) []byte {
+	addr, _ := hex.DecodeString(strings.TrimPrefix(strings.ToLower(address), "0x"))
+	out := make([]byte, 32)
+	copy(out[12:], addr)
+	return out
+}
+
+// encodeMintCall mã hóa lời gọi safeMint(address to, string uri) theo ABI của Solidity
+func encodeMintCall(to, uri string) []byte {
+	data := []byte(uri)
+	padded := make([]byte, (len(data)+31)/32*32)
+	copy(padded, data)
+
+	out := append([]byte{}, selector("safeMint(address,string)")...)
+	out = append(out, encodeAddressWord(to)...)
+	out = append(out, word(big.NewInt(64))...) // offset của tham số string
+	out = append(out, word(big.NewInt(int64(len(data))))...)
+	return append(out, padded...)
+}
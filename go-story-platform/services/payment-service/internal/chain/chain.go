@@ -0,0 +1,142 @@
+// Package chain là lớp trừu tượng làm việc với blockchain cho payment-service.
+// ChainClient có hai hiện thực: SimulatedChain chạy trong process (phát triển/kiểm thử offline)
+// và RPCClient gọi JSON-RPC của một node dev (anvil, hardhat, geth --dev).
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+var (
+	// ErrNotFound được trả về khi block/receipt chưa tồn tại (tx còn pending hoặc không có)
+	ErrNotFound = errors.New("không tìm thấy trên chain")
+	// ErrInvalidAddress được trả về khi địa chỉ không đúng dạng 0x + 40 ký tự hex
+	ErrInvalidAddress = errors.New("địa chỉ không hợp lệ")
+)
+
+// Trạng thái của Receipt, giống trường status của Ethereum
+const (
+	ReceiptStatusFailed     uint64 = 0
+	ReceiptStatusSuccessful uint64 = 1
+)
+
+// Block là một block đã được đào cùng các giao dịch của nó
+type Block struct {
+	Number       uint64
+	Hash         string
+	ParentHash   string
+	Timestamp    time.Time
+	Transactions []Tx
+}
+
+// Tx là một giao dịch trong block. Value là số wei chuyển kèm (chuyển coin gốc).
+type Tx struct {
+	Hash  string
+	From  string
+	To    string
+	Value *big.Int
+	Input []byte
+}
+
+// Log là một event do contract phát ra, theo đúng cấu trúc log của Ethereum.
+// Removed = true khi block chứa log bị loại khỏi chuỗi chính do reorg.
+type Log struct {
+	Address     string
+	Topics      []string
+	Data        []byte
+	BlockNumber uint64
+	BlockHash   string
+	TxHash      string
+	Index       uint
+	Removed     bool
+}
+
+// Receipt là kết quả thực thi của một giao dịch đã vào block
+type Receipt struct {
+	TxHash      string
+	BlockNumber uint64
+	BlockHash   string
+	Status      uint64
+	Logs        []Log
+}
+
+// LogFilter chọn log cần nhận. Trường rỗng nghĩa là không lọc theo trường đó.
+type LogFilter struct {
+	Addresses []string // Địa chỉ contract phát log
+	Topic0    []string // Chữ ký event, ví dụ TransferTopic
+	FromBlock uint64   // Gửi lại cả log cũ từ block này (0 = chỉ log mới)
+}
+
+// TransferRequest là yêu cầu chuyển coin gốc từ From sang To
+type TransferRequest struct {
+	From  string
+	To    string
+	Value *big.Int
+}
+
+// MintRequest là yêu cầu đúc một token sở hữu (ERC-721) cho To, với metadata tại TokenURI
+type MintRequest struct {
+	To       string
+	TokenURI string
+}
+
+// ChainClient là interface payment-service dùng để làm việc với blockchain
+type ChainClient interface {
+	// ChainID trả về chain id (EIP-155)
+	ChainID(ctx context.Context) (uint64, error)
+	// BlockNumber trả về số của block mới nhất
+	BlockNumber(ctx context.Context) (uint64, error)
+	// BlockByNumber trả về block kèm giao dịch, ErrNotFound nếu chưa có
+	BlockByNumber(ctx context.Context, number uint64) (*Block, error)
+	// SubmitTransfer gửi giao dịch chuyển coin và trả về tx hash
+	SubmitTransfer(ctx context.Context, req TransferRequest) (string, error)
+	// MintToken gửi giao dịch đúc token sở hữu và trả về tx hash; token ID lấy từ receipt
+	// bằng MintedTokenID khi giao dịch đã vào block
+	MintToken(ctx context.Context, req MintRequest) (string, error)
+	// OwnerOf trả về địa chỉ đang giữ token sở hữu (ERC-721 ownerOf), ErrNotFound nếu token chưa tồn tại
+	OwnerOf(ctx context.Context, tokenID *big.Int) (string, error)
+	// TransactionReceipt trả về receipt, ErrNotFound nếu giao dịch chưa vào block
+	TransactionReceipt(ctx context.Context, txHash string) (*Receipt, error)
+	// SubscribeLogs gửi các log khớp filter vào channel cho tới khi ctx bị hủy
+	SubscribeLogs(ctx context.Context, filter LogFilter) (<-chan Log, error)
+}
+
+// Confirmations tính số xác nhận của một block so với block mới nhất head
+// (block vừa được đào có 1 xác nhận)
+func Confirmations(head, blockNumber uint64) uint64 {
+	if blockNumber == 0 || head < blockNumber {
+		return 0
+	}
+	return head - blockNumber + 1
+}
+
+// Config chọn ChainClient qua CHAIN_DRIVER (bắt buộc, để môi trường thật không vô tình chạy chain giả):
+//   - "simulated": SimulatedChain chỉ dùng khi phát triển, các tham số CHAIN_ID, CHAIN_SIM_SEED,
+//     CHAIN_SIM_BLOCK_TIME (chu kỳ đào block khi chạy SimulatedChain.Run)
+//   - "jsonrpc": RPCClient tới CHAIN_RPC_URL, gửi giao dịch từ CHAIN_FROM_ADDRESS
+//     và đúc token qua contract CHAIN_NFT_CONTRACT
+type Config struct {
+	Driver       string        `env:"CHAIN_DRIVER" required:"true"`
+	ChainID      uint64        `env:"CHAIN_ID" default:"31337"`
+	SimSeed      string        `env:"CHAIN_SIM_SEED"`
+	SimBlockTime time.Duration `env:"CHAIN_SIM_BLOCK_TIME"`
+	RPCURL       string        `env:"CHAIN_RPC_URL" default:"http://localhost:8545"`
+	FromAddress  string        `env:"CHAIN_FROM_ADDRESS"`
+	NFTContract  string        `env:"CHAIN_NFT_CONTRACT"`
+}
+
+// New tạo ChainClient theo cfg
+func New(cfg Config) (ChainClient, error) {
+	switch cfg.Driver {
+	case "simulated":
+		return NewSimulatedChain(SimulatedConfig{ChainID: cfg.ChainID, Seed: cfg.SimSeed, BlockTime: cfg.SimBlockTime}), nil
+	case "jsonrpc":
+		return NewRPCClient(cfg.RPCURL, cfg.FromAddress, cfg.NFTContract)
+	default:
+		return nil, fmt.Errorf("CHAIN_DRIVER không hỗ trợ: %q", cfg.Driver)
+	}
+}
@@ -0,0 +1,482 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// RPCClient hiện thực ChainClient qua JSON-RPC của node Ethereum. Giao dịch được gửi bằng
+// eth_sendTransaction nên tài khoản From phải được node mở khóa sẵn (anvil, hardhat, geth --dev).
+type RPCClient struct {
+	url          string
+	from         string
+	nftContract  string
+	httpClient   *http.Client
+	nextID       atomic.Uint64
+	PollInterval time.Duration // Chu kỳ gọi eth_getLogs của SubscribeLogs
+}
+
+// NewRPCClient tạo RPCClient tới url. from là tài khoản gửi giao dịch đúc token,
+// nftContract là địa chỉ contract có hàm safeMint(address,string).
+func NewRPCClient(url, from, nftContract string) (*RPCClient, error) {
+	client := &RPCClient{
+		url:          url,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		PollInterval: 2 * time.Second,
+	}
+	if from != "" {
+		addr, err := NormalizeAddress(from)
+		if err != nil {
+			return nil, fmt.Errorf("CHAIN_FROM_ADDRESS: %w", err)
+		}
+		client.from = addr
+	}
+	if nftContract != "" {
+		addr, err := NormalizeAddress(nftContract)
+		if err != nil {
+			return nil, fmt.Errorf("CHAIN_NFT_CONTRACT: %w", err)
+		}
+		client.nftContract = addr
+	}
+	return client, nil
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("json-rpc lỗi %d: %s", e.Code, e.Message)
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type rpcTx struct {
+	Hash  string `json:"hash"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Value string `json:"value"`
+	Input string `json:"input"`
+}
+
+type rpcBlock struct {
+	Number       string  `json:"number"`
+	Hash         string  `json:"hash"`
+	ParentHash   string  `json:"parentHash"`
+	Timestamp    string  `json:"timestamp"`
+	Transactions []rpcTx `json:"transactions"`
+}
+
+type rpcLog struct {
+	Address     string   `json:"address"`
+	Topics      []string `json:"topics"`
+	Data        string   `json:"data"`
+	BlockNumber string   `json:"blockNumber"`
+	BlockHash   string   `json:"blockHash"`
+	TxHash      string   `json:"transactionHash"`
+	LogIndex    string   `json:"logIndex"`
+	Removed     bool     `json:"removed"`
+}
+
+type rpcReceipt struct {
+	TxHash      string   `json:"transactionHash"`
+	BlockNumber string   `json:"blockNumber"`
+	BlockHash   string   `json:"blockHash"`
+	Status      string   `json:"status"`
+	Logs        []rpcLog `json:"logs"`
+}
+
+// call gửi một request JSON-RPC và giải mã result vào out. Result null trả về ErrNotFound.
+func (c *RPCClient) call(ctx context.Context, method string, out interface{}, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: c.nextID.Add(1), Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("gọi %s thất bại: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("gọi %s thất bại: HTTP %d", method, resp.StatusCode)
+	}
+
+	var payload rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return fmt.Errorf("response %s không hợp lệ: %w", method, err)
+	}
+	if payload.Error != nil {
+		return payload.Error
+	}
+	if len(payload.Result) == 0 || string(payload.Result) == "null" {
+		return ErrNotFound
+	}
+	return json.Unmarshal(payload.Result, out)
+}
+
+// ChainID gọi eth_chainId
+func (c *RPCClient) ChainID(ctx context.Context) (uint64, error) {
+	var raw string
+	if err := c.call(ctx, "eth_chainId", &raw); err != nil {
+		return 0, err
+	}
+	return parseQuantity(raw)
+}
+
+// BlockNumber gọi eth_blockNumber
+func (c *RPCClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var raw string
+	if err := c.call(ctx, "eth_blockNumber", &raw); err != nil {
+		return 0, err
+	}
+	return parseQuantity(raw)
+}
+
+// BlockByNumber gọi eth_getBlockByNumber kèm đầy đủ giao dịch
+func (c *RPCClient) BlockByNumber(ctx context.Context, number uint64) (*Block, error) {
+	var raw rpcBlock
+	if err := c.call(ctx, "eth_getBlockByNumber", &raw, toQuantity(number), true); err != nil {
+		return nil, err
+	}
+
+	num, err := parseQuantity(raw.Number)
+	if err != nil {
+		return nil, err
+	}
+	ts, err := parseQuantity(raw.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	block := &Block{
+		Number:     num,
+		Hash:       strings.ToLower(raw.Hash),
+		ParentHash: strings.ToLower(raw.ParentHash),
+		Timestamp:  time.Unix(int64(ts), 0).UTC(),
+	}
+	for _, tx := range raw.Transactions {
+		value, err := parseBig(tx.Value)
+		if err != nil {
+			return nil, err
+		}
+		input, _ := hex.DecodeString(strings.TrimPrefix(tx.Input, "0x"))
+		block.Transactions = append(block.Transactions, Tx{
+			Hash:  strings.ToLower(tx.Hash),
+			From:  strings.ToLower(tx.From),
+			To:    strings.ToLower(tx.To), // rỗng với giao dịch tạo contract
+			Value: value,
+			Input: input,
+		})
+	}
+	return block, nil
+}
+
+// SubmitTransfer gửi coin gốc bằng eth_sendTransaction
+func (c *RPCClient) SubmitTransfer(ctx context.Context, req TransferRequest) (string, error) {
+	from, err := NormalizeAddress(req.From)
+	if err != nil {
+		return "", err
+	}
+	to, err := NormalizeAddress(req.To)
+	if err != nil {
+		return "", err
+	}
+	if req.Value == nil || req.Value.Sign() <= 0 {
+		return "", errors.New("số tiền chuyển phải lớn hơn 0")
+	}
+
+	var hash string
+	err = c.call(ctx, "eth_sendTransaction", &hash, map[string]string{
+		"from":  from,
+		"to":    to,
+		"value": "0x" + req.Value.Text(16),
+	})
+	return strings.ToLower(hash), err
+}
+
+// MintToken gọi safeMint(to, tokenURI) trên contract CHAIN_NFT_CONTRACT từ tài khoản CHAIN_FROM_ADDRESS
+func (c *RPCClient) MintToken(ctx context.Context, req MintRequest) (string, error) {
+	if c.from == "" || c.nftContract == "" {
+		return "", errors.New("chưa cấu hình CHAIN_FROM_ADDRESS/CHAIN_NFT_CONTRACT để đúc token")
+	}
+	to, err := NormalizeAddress(req.To)
+	if err != nil {
+		return "", err
+	}
+
+	var hash string
+	err = c.call(ctx, "eth_sendTransaction", &hash, map[string]string{
+		"from": c.from,
+		"to":   c.nftContract,
+		"data": "0x" + hex.EncodeToString(encodeMintCall(to, req.TokenURI)),
+	})
+	return strings.ToLower(hash), err
+}
+
+// OwnerOf gọi ownerOf(uint256) trên contract CHAIN_NFT_CONTRACT bằng eth_call
+func (c *RPCClient) OwnerOf(ctx context.Context, tokenID *big.Int) (string, error) {
+	if c.nftContract == "" {
+		return "", errors.New("chưa cấu hình CHAIN_NFT_CONTRACT")
+	}
+
+	data := append(selector("ownerOf(uint256)"), word(tokenID)...)
+	var raw string
+	err := c.call(ctx, "eth_call", &raw, map[string]string{
+		"to":   c.nftContract,
+		"data": "0x" + hex.EncodeToString(data),
+	}, "latest")
+	if err != nil {
+		// Contract ERC-721 revert khi token chưa được đúc
+		var rpcErr *rpcError
+		if errors.As(err, &rpcErr) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+
+	owner := topicToAddress(raw)
+	if owner == ZeroAddress {
+		return "", ErrNotFound
+	}
+	return owner, nil
+}
+
+// TransactionReceipt gọi eth_getTransactionReceipt
+func (c *RPCClient) TransactionReceipt(ctx context.Context, txHash string) (*Receipt, error) {
+	var raw rpcReceipt
+	if err := c.call(ctx, "eth_getTransactionReceipt", &raw, txHash); err != nil {
+		return nil, err
+	}
+
+	blockNumber, err := parseQuantity(raw.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	status, err := parseQuantity(raw.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &Receipt{
+		TxHash:      strings.ToLower(raw.TxHash),
+		BlockNumber: blockNumber,
+		BlockHash:   strings.ToLower(raw.BlockHash),
+		Status:      status,
+	}
+	for _, l := range raw.Logs {
+		log, err := l.toLog()
+		if err != nil {
+			return nil, err
+		}
+		receipt.Logs = append(receipt.Logs, log)
+	}
+	return receipt, nil
+}
+
+// SubscribeLogs thăm dò eth_getLogs theo PollInterval vì HTTP không hỗ trợ eth_subscribe.
+// Khi phát hiện block đã xử lý bị thay hash, log cũ của block đó được gửi lại với Removed = true.
+func (c *RPCClient) SubscribeLogs(ctx context.Context, filter LogFilter) (<-chan Log, error) {
+	for i, addr := range filter.Addresses {
+		normalized, err := NormalizeAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+		filter.Addresses[i] = normalized
+	}
+
+	next := filter.FromBlock
+	if next == 0 {
+		head, err := c.BlockNumber(ctx)
+		if err != nil {
+			return nil, err
+		}
+		next = head + 1
+	}
+
+	out := make(chan Log)
+	go func() {
+		defer close(out)
+		// Log đã gửi theo block, để phát hiện reorg trong phạm vi vài block gần nhất
+		delivered := make(map[uint64][]Log)
+		ticker := time.NewTicker(c.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			var err error
+			next, err = c.pollLogs(ctx, filter, next, delivered, out)
+			if err != nil && ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return out, nil
+}
+
+// reorgWindow là số block gần nhất được kiểm tra lại hash khi thăm dò log
+const reorgWindow = 64
+
+// pollLogs lấy log từ block next tới head, gửi vào out và trả về block kế tiếp cần đọc
+func (c *RPCClient) pollLogs(ctx context.Context, filter LogFilter, next uint64, delivered map[uint64][]Log, out chan<- Log) (uint64, error) {
+	head, err := c.BlockNumber(ctx)
+	if err != nil {
+		return next, err
+	}
+
+	// Lùi next về block đầu tiên có hash đã thay đổi
+	for number := range delivered {
+		if number+reorgWindow < head {
+			delete(delivered, number)
+			continue
+		}
+		block, err := c.BlockByNumber(ctx, number)
+		if err == nil && len(delivered[number]) > 0 && block.Hash == delivered[number][0].BlockHash {
+			continue
+		}
+		if number < next {
+			next = number
+		}
+	}
+	for number := head + 1; number > next; number-- {
+		for _, log := range delivered[number-1] {
+			log.Removed = true
+			if !send(ctx, out, log) {
+				return next, ctx.Err()
+			}
+		}
+		delete(delivered, number-1)
+	}
+	if next > head {
+		return next, nil
+	}
+
+	query := map[string]interface{}{
+		"fromBlock": toQuantity(next),
+		"toBlock":   toQuantity(head),
+	}
+	if len(filter.Addresses) > 0 {
+		query["address"] = filter.Addresses
+	}
+	if len(filter.Topic0) > 0 {
+		query["topics"] = []interface{}{filter.Topic0}
+	}
+
+	var raw []rpcLog
+	if err := c.call(ctx, "eth_getLogs", &raw, query); err != nil && !errors.Is(err, ErrNotFound) {
+		return next, err
+	}
+	for _, l := range raw {
+		log, err := l.toLog()
+		if err != nil {
+			return next, err
+		}
+		delivered[log.BlockNumber] = append(delivered[log.BlockNumber], log)
+		if !send(ctx, out, log) {
+			return next, ctx.Err()
+		}
+	}
+	return head + 1, nil
+}
+
+func send(ctx context.Context, out chan<- Log, log Log) bool {
+	select {
+	case out <- log:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (l rpcLog) toLog() (Log, error) {
+	blockNumber, err := parseQuantity(l.BlockNumber)
+	if err != nil {
+		return Log{}, err
+	}
+	index, err := parseQuantity(l.LogIndex)
+	if err != nil {
+		return Log{}, err
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(l.Data, "0x"))
+	if err != nil {
+		return Log{}, fmt.Errorf("data của log không hợp lệ: %w", err)
+	}
+
+	topics := make([]string, len(l.Topics))
+	for i, t := range l.Topics {
+		topics[i] = strings.ToLower(t)
+	}
+	return Log{
+		Address:     strings.ToLower(l.Address),
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: blockNumber,
+		BlockHash:   strings.ToLower(l.BlockHash),
+		TxHash:      strings.ToLower(l.TxHash),
+		Index:       uint(index),
+		Removed:     l.Removed,
+	}, nil
+}
+
+// parseQuantity đọc số dạng hex "0x..." theo quy ước JSON-RPC của Ethereum
+func parseQuantity(raw string) (uint64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(strings.TrimPrefix(raw, "0x"), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("số hex không hợp lệ %q", raw)
+	}
+	return n, nil
+}
+
+func parseBig(raw string) (*big.Int, error) {
+	if raw == "" || raw == "0x" {
+		return new(big.Int), nil
+	}
+	n, ok := new(big.Int).SetString(strings.TrimPrefix(raw, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("số hex không hợp lệ %q", raw)
+	}
+	return n, nil
+}
+
+func toQuantity(n uint64) string {
+	return "0x" + strconv.FormatUint(n, 16)
+}
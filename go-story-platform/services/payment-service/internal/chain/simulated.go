@@ -0,0 +1,561 @@
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SimulatedConfig cấu hình SimulatedChain. Hai chain có cùng cấu hình và cùng chuỗi thao tác
+// luôn sinh ra cùng block hash, tx hash và token ID.
+type SimulatedConfig struct {
+	ChainID     uint64
+	Seed        string              // Đổi seed để tách các chain mô phỏng khác nhau
+	GenesisTime time.Time           // Mặc định 2024-01-01 UTC
+	BlockTime   time.Duration       // Khoảng cách timestamp giữa hai block, mặc định 2s
+	Alloc       map[string]*big.Int // Số dư ban đầu của các địa chỉ
+}
+
+// simTx là giao dịch trong chain mô phỏng kèm dữ liệu cần để thực thi lại khi reorg
+type simTx struct {
+	Tx
+	mintTo   string
+	tokenURI string
+	tokenID  *big.Int // Với giao dịch chuyển token (transferFrom), người nhận là tokenTo
+	tokenTo  string
+}
+
+// simState là trạng thái sổ cái của chain mô phỏng, được tính lại từ genesis khi reorg
+type simState struct {
+	balances    map[string]*big.Int
+	owners      map[string]string // token ID (thập phân) -> chủ sở hữu
+	tokenURIs   map[string]string
+	nextTokenID int64
+}
+
+// SimulatedChain là blockchain chạy trong process: block được đào khi gọi Mine (hoặc theo
+// chu kỳ với Run), hỗ trợ chuyển coin gốc, đúc token ERC-721 và giả lập reorg bằng Reorg.
+type SimulatedChain struct {
+	mu        sync.Mutex
+	cfg       SimulatedConfig
+	contract  string
+	minter    string
+	blocks    []*Block
+	blockTxs  [][]simTx
+	blockLogs [][]Log
+	receipts  map[string]*Receipt
+	pending   []simTx
+	nonces    map[string]uint64
+	state     simState
+	fork      uint64
+	subs      map[int]*logSubscription
+	nextSubID int
+}
+
+// NewSimulatedChain tạo chain mô phỏng chỉ có block genesis
+func NewSimulatedChain(cfg SimulatedConfig) *SimulatedChain {
+	if cfg.ChainID == 0 {
+		cfg.ChainID = 31337
+	}
+	if cfg.GenesisTime.IsZero() {
+		cfg.GenesisTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	if cfg.BlockTime <= 0 {
+		cfg.BlockTime = 2 * time.Second
+	}
+	alloc := make(map[string]*big.Int, len(cfg.Alloc))
+	for addr, amount := range cfg.Alloc {
+		alloc[strings.ToLower(addr)] = new(big.Int).Set(amount)
+	}
+	cfg.Alloc = alloc
+
+	s := &SimulatedChain{
+		cfg:      cfg,
+		contract: deriveAddress(cfg.Seed, "ownership-token"),
+		minter:   deriveAddress(cfg.Seed, "minter"),
+		receipts: make(map[string]*Receipt),
+		nonces:   make(map[string]uint64),
+		subs:     make(map[int]*logSubscription),
+	}
+	genesis := &Block{
+		Number:     0,
+		Hash:       "0x" + hex.EncodeToString(keccak256([]byte("genesis"), []byte(cfg.Seed), uint64Bytes(cfg.ChainID))),
+		ParentHash: "0x" + strings.Repeat("0", 64),
+		Timestamp:  cfg.GenesisTime,
+	}
+	s.blocks = []*Block{genesis}
+	s.blockTxs = [][]simTx{nil}
+	s.blockLogs = [][]Log{nil}
+	s.state = s.genesisState()
+	return s
+}
+
+// NFTContract trả về địa chỉ contract token sở hữu của chain mô phỏng
+func (s *SimulatedChain) NFTContract() string {
+	return s.contract
+}
+
+// Minter trả về địa chỉ gửi các giao dịch đúc token
+func (s *SimulatedChain) Minter() string {
+	return s.minter
+}
+
+// ChainID trả về chain id đã cấu hình
+func (s *SimulatedChain) ChainID(ctx context.Context) (uint64, error) {
+	return s.cfg.ChainID, nil
+}
+
+// BlockNumber trả về số của block mới nhất
+func (s *SimulatedChain) BlockNumber(ctx context.Context) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return uint64(len(s.blocks) - 1), nil
+}
+
+// BlockByNumber trả về bản sao của block, ErrNotFound nếu chưa được đào
+func (s *SimulatedChain) BlockByNumber(ctx context.Context, number uint64) (*Block, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if number >= uint64(len(s.blocks)) {
+		return nil, ErrNotFound
+	}
+	return copyBlock(s.blocks[number]), nil
+}
+
+// SubmitTransfer đưa giao dịch chuyển coin vào mempool; số dư được kiểm tra khi đào,
+// giao dịch thiếu số dư vẫn vào block với receipt thất bại
+func (s *SimulatedChain) SubmitTransfer(ctx context.Context, req TransferRequest) (string, error) {
+	from, err := NormalizeAddress(req.From)
+	if err != nil {
+		return "", err
+	}
+	to, err := NormalizeAddress(req.To)
+	if err != nil {
+		return "", err
+	}
+	if req.Value == nil || req.Value.Sign() <= 0 {
+		return "", errors.New("số tiền chuyển phải lớn hơn 0")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx := s.newTx(from, to, req.Value, nil)
+	s.pending = append(s.pending, simTx{Tx: tx})
+	return tx.Hash, nil
+}
+
+// MintToken đưa giao dịch đúc token vào mempool; token ID được cấp tăng dần khi đào
+func (s *SimulatedChain) MintToken(ctx context.Context, req MintRequest) (string, error) {
+	to, err := NormalizeAddress(req.To)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx := s.newTx(s.minter, s.contract, new(big.Int), encodeMintCall(to, req.TokenURI))
+	s.pending = append(s.pending, simTx{Tx: tx, mintTo: to, tokenURI: req.TokenURI})
+	return tx.Hash, nil
+}
+
+// TransferToken đưa giao dịch chuyển token sở hữu (ERC-721 transferFrom) vào mempool, dùng để
+// giả lập việc bán lại token trên sàn. Giao dịch thất bại khi đào nếu from không giữ token.
+func (s *SimulatedChain) TransferToken(ctx context.Context, from, to string, tokenID *big.Int) (string, error) {
+	fromAddr, err := NormalizeAddress(from)
+	if err != nil {
+		return "", err
+	}
+	toAddr, err := NormalizeAddress(to)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	input := append(selector("transferFrom(address,address,uint256)"), encodeAddressWord(fromAddr)...)
+	input = append(input, encodeAddressWord(toAddr)...)
+	input = append(input, word(tokenID)...)
+	tx := s.newTx(fromAddr, s.contract, new(big.Int), input)
+	s.pending = append(s.pending, simTx{Tx: tx, tokenID: new(big.Int).Set(tokenID), tokenTo: toAddr})
+	return tx.Hash, nil
+}
+
+// OwnerOf trả về chủ sở hữu hiện tại của token theo trạng thái block mới nhất
+func (s *SimulatedChain) OwnerOf(ctx context.Context, tokenID *big.Int) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	owner, ok := s.state.owners[tokenID.String()]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return owner, nil
+}
+
+// TransactionReceipt trả về receipt của giao dịch đã vào block
+func (s *SimulatedChain) TransactionReceipt(ctx context.Context, txHash string) (*Receipt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	receipt, ok := s.receipts[strings.ToLower(txHash)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	out := *receipt
+	out.Logs = append([]Log(nil), receipt.Logs...)
+	return &out, nil
+}
+
+// SubscribeLogs đăng ký nhận log; log của block bị reorg được gửi lại với Removed = true
+func (s *SimulatedChain) SubscribeLogs(ctx context.Context, filter LogFilter) (<-chan Log, error) {
+	for i, addr := range filter.Addresses {
+		normalized, err := NormalizeAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+		filter.Addresses[i] = normalized
+	}
+
+	sub := newLogSubscription(filter)
+	s.mu.Lock()
+	id := s.nextSubID
+	s.nextSubID++
+	s.subs[id] = sub
+	if filter.FromBlock > 0 {
+		for n := filter.FromBlock; n < uint64(len(s.blocks)); n++ {
+			sub.push(s.blockLogs[n])
+		}
+	}
+	s.mu.Unlock()
+
+	out := make(chan Log)
+	go func() {
+		sub.run(ctx, out)
+		s.mu.Lock()
+		delete(s.subs, id)
+		s.mu.Unlock()
+	}()
+	return out, nil
+}
+
+// Fund cộng coin cho một địa chỉ ngay từ genesis (vòi cấp coin cho môi trường dev)
+func (s *SimulatedChain) Fund(address string, amount *big.Int) error {
+	addr, err := NormalizeAddress(address)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	total := new(big.Int).Set(amount)
+	if prev, ok := s.cfg.Alloc[addr]; ok {
+		total.Add(total, prev)
+	}
+	s.cfg.Alloc[addr] = total
+	s.state.balance(addr).Add(s.state.balance(addr), amount)
+	return nil
+}
+
+// BalanceOf trả về số dư coin gốc hiện tại của một địa chỉ
+func (s *SimulatedChain) BalanceOf(address string) *big.Int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return new(big.Int).Set(s.state.balance(strings.ToLower(address)))
+}
+
+// Mine đào n block; block đầu tiên chứa toàn bộ giao dịch trong mempool.
+// Trả về số của block mới nhất.
+func (s *SimulatedChain) Mine(n int) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.mineLocked()
+	}
+	return uint64(len(s.blocks) - 1)
+}
+
+// Run đào một block sau mỗi BlockTime cho tới khi ctx bị hủy, giống chế độ --block-time của node dev
+func (s *SimulatedChain) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.BlockTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Mine(1)
+		}
+	}
+}
+
+// Reorg thay depth block cuối bằng một nhánh mới dài depth+1 block có hash khác.
+// Giao dịch của các block bị bỏ được đưa lại vào nhánh mới, trừ khi dropTxs = true
+// (mô phỏng giao dịch biến mất sau reorg). Subscriber nhận lại log cũ với Removed = true.
+func (s *SimulatedChain) Reorg(depth int, dropTxs bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if depth < 1 || depth >= len(s.blocks) {
+		return fmt.Errorf("độ sâu reorg không hợp lệ: %d", depth)
+	}
+
+	keep := len(s.blocks) - depth
+	var orphanedTxs []simTx
+	for n := len(s.blocks) - 1; n >= keep; n-- {
+		removed := make([]Log, len(s.blockLogs[n]))
+		for i, log := range s.blockLogs[n] {
+			log.Removed = true
+			removed[i] = log
+		}
+		s.publishLocked(removed)
+	}
+	for n := keep; n < len(s.blocks); n++ {
+		for _, tx := range s.blockTxs[n] {
+			delete(s.receipts, tx.Hash)
+		}
+		orphanedTxs = append(orphanedTxs, s.blockTxs[n]...)
+	}
+
+	s.blocks = s.blocks[:keep]
+	s.blockTxs = s.blockTxs[:keep]
+	s.blockLogs = s.blockLogs[:keep]
+	s.replayLocked()
+
+	if !dropTxs {
+		s.pending = append(orphanedTxs, s.pending...)
+	}
+	s.fork++
+	for i := 0; i <= depth; i++ {
+		s.mineLocked()
+	}
+	return nil
+}
+
+// newTx tạo giao dịch với nonce kế tiếp của người gửi; hash phụ thuộc toàn bộ nội dung
+func (s *SimulatedChain) newTx(from, to string, value *big.Int, input []byte) Tx {
+	nonce := s.nonces[from]
+	s.nonces[from] = nonce + 1
+	hash := keccak256(uint64Bytes(s.cfg.ChainID), []byte(from), []byte(to), value.Bytes(), uint64Bytes(nonce), input)
+	return Tx{
+		Hash:  "0x" + hex.EncodeToString(hash),
+		From:  from,
+		To:    to,
+		Value: new(big.Int).Set(value),
+		Input: input,
+	}
+}
+
+// mineLocked đào một block chứa toàn bộ mempool và gửi log cho subscriber
+func (s *SimulatedChain) mineLocked() {
+	parent := s.blocks[len(s.blocks)-1]
+	number := parent.Number + 1
+	txs := s.pending
+	s.pending = nil
+
+	parts := [][]byte{[]byte(parent.Hash), uint64Bytes(number), uint64Bytes(s.fork), []byte(s.cfg.Seed)}
+	block := &Block{
+		Number:     number,
+		ParentHash: parent.Hash,
+		Timestamp:  s.cfg.GenesisTime.Add(time.Duration(number) * s.cfg.BlockTime),
+	}
+	for _, tx := range txs {
+		parts = append(parts, []byte(tx.Hash))
+		block.Transactions = append(block.Transactions, tx.Tx)
+	}
+	block.Hash = "0x" + hex.EncodeToString(keccak256(parts...))
+
+	logs := s.applyBlock(block, txs)
+	s.blocks = append(s.blocks, block)
+	s.blockTxs = append(s.blockTxs, txs)
+	s.blockLogs = append(s.blockLogs, logs)
+	s.publishLocked(logs)
+}
+
+// applyBlock thực thi giao dịch của block trên s.state, ghi receipt và trả về log sinh ra
+func (s *SimulatedChain) applyBlock(block *Block, txs []simTx) []Log {
+	var logs []Log
+	for _, tx := range txs {
+		receipt := &Receipt{
+			TxHash:      tx.Hash,
+			BlockNumber: block.Number,
+			BlockHash:   block.Hash,
+			Status:      ReceiptStatusSuccessful,
+		}
+
+		switch {
+		case tx.tokenID != nil:
+			key := tx.tokenID.String()
+			if s.state.owners[key] != tx.From {
+				receipt.Status = ReceiptStatusFailed
+				break
+			}
+			s.state.owners[key] = tx.tokenTo
+			receipt.Logs = []Log{{
+				Address:     s.contract,
+				Topics:      []string{TransferTopic, addressToTopic(tx.From), addressToTopic(tx.tokenTo), intToTopic(tx.tokenID)},
+				BlockNumber: block.Number,
+				BlockHash:   block.Hash,
+				TxHash:      tx.Hash,
+				Index:       uint(len(logs)),
+			}}
+		case tx.mintTo != "":
+			s.state.nextTokenID++
+			tokenID := big.NewInt(s.state.nextTokenID)
+			s.state.owners[tokenID.String()] = tx.mintTo
+			s.state.tokenURIs[tokenID.String()] = tx.tokenURI
+			receipt.Logs = []Log{{
+				Address:     s.contract,
+				Topics:      []string{TransferTopic, addressToTopic(ZeroAddress), addressToTopic(tx.mintTo), intToTopic(tokenID)},
+				BlockNumber: block.Number,
+				BlockHash:   block.Hash,
+				TxHash:      tx.Hash,
+				Index:       uint(len(logs)),
+			}}
+		case s.state.balance(tx.From).Cmp(tx.Value) < 0:
+			receipt.Status = ReceiptStatusFailed
+		default:
+			from := s.state.balance(tx.From)
+			from.Sub(from, tx.Value)
+			s.state.balance(tx.To).Add(s.state.balance(tx.To), tx.Value)
+		}
+
+		logs = append(logs, receipt.Logs...)
+		s.receipts[tx.Hash] = receipt
+	}
+	return logs
+}
+
+// replayLocked tính lại trạng thái và receipt bằng cách thực thi lại các block còn lại từ genesis
+func (s *SimulatedChain) replayLocked() {
+	s.state = s.genesisState()
+	for n := 1; n < len(s.blocks); n++ {
+		s.blockLogs[n] = s.applyBlock(s.blocks[n], s.blockTxs[n])
+	}
+}
+
+func (s *SimulatedChain) genesisState() simState {
+	state := simState{
+		balances:  make(map[string]*big.Int, len(s.cfg.Alloc)),
+		owners:    make(map[string]string),
+		tokenURIs: make(map[string]string),
+	}
+	for addr, amount := range s.cfg.Alloc {
+		state.balances[addr] = new(big.Int).Set(amount)
+	}
+	return state
+}
+
+// publishLocked xếp log vào hàng đợi của các subscriber có filter phù hợp
+func (s *SimulatedChain) publishLocked(logs []Log) {
+	if len(logs) == 0 {
+		return
+	}
+	for _, sub := range s.subs {
+		sub.push(logs)
+	}
+}
+
+func (st simState) balance(addr string) *big.Int {
+	b, ok := st.balances[addr]
+	if !ok {
+		b = new(big.Int)
+		st.balances[addr] = b
+	}
+	return b
+}
+
+// logSubscription giữ hàng đợi không giới hạn để việc đào block không bị chặn bởi subscriber chậm
+type logSubscription struct {
+	filter LogFilter
+	mu     sync.Mutex
+	queue  []Log
+	notify chan struct{}
+}
+
+func newLogSubscription(filter LogFilter) *logSubscription {
+	return &logSubscription{filter: filter, notify: make(chan struct{}, 1)}
+}
+
+func (sub *logSubscription) push(logs []Log) {
+	sub.mu.Lock()
+	for _, log := range logs {
+		if matchLog(sub.filter, log) {
+			sub.queue = append(sub.queue, log)
+		}
+	}
+	sub.mu.Unlock()
+
+	select {
+	case sub.notify <- struct{}{}:
+	default:
+	}
+}
+
+// run chuyển log từ hàng đợi sang out theo đúng thứ tự cho tới khi ctx bị hủy rồi đóng out
+func (sub *logSubscription) run(ctx context.Context, out chan<- Log) {
+	defer close(out)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sub.notify:
+		}
+
+		sub.mu.Lock()
+		batch := sub.queue
+		sub.queue = nil
+		sub.mu.Unlock()
+
+		for _, log := range batch {
+			select {
+			case out <- log:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// matchLog kiểm tra log có khớp địa chỉ contract và chữ ký event trong filter không
+func matchLog(filter LogFilter, log Log) bool {
+	if len(filter.Addresses) > 0 && !containsFold(filter.Addresses, log.Address) {
+		return false
+	}
+	if len(filter.Topic0) > 0 && (len(log.Topics) == 0 || !containsFold(filter.Topic0, log.Topics[0])) {
+		return false
+	}
+	return true
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func copyBlock(b *Block) *Block {
+	out := *b
+	out.Transactions = make([]Tx, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		tx.Value = new(big.Int).Set(tx.Value)
+		out.Transactions[i] = tx
+	}
+	return &out
+}
+
+// deriveAddress sinh địa chỉ cố định từ seed và nhãn, dùng cho contract/minter của chain mô phỏng
+func deriveAddress(seed, label string) string {
+	return "0x" + hex.EncodeToString(keccak256([]byte(seed), []byte(label))[12:])
+}
+
+func uint64Bytes(n uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b
+}
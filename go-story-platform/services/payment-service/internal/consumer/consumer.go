@@ -0,0 +1,57 @@
+// Package consumer đăng ký các handler nhận domain event từ service khác qua event bus
+package consumer
+
+import (
+	"context"
+	"payment-service/internal/models"
+	"payment-service/internal/repository"
+
+	"shared/eventbus"
+	"shared/events"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const walletConsumer = "payment-service.wallet"
+
+// Register đăng ký các consumer của payment-service với broker
+func Register(ctx context.Context, broker eventbus.Broker, db *gorm.DB) error {
+	return broker.Subscribe(ctx, walletConsumer,
+		[]string{events.TypeUserRegistered, events.TypeUserWalletChanged},
+		eventbus.Idempotent(db, walletConsumer, handleWalletEvent))
+}
+
+func handleWalletEvent(tx *gorm.DB, msg eventbus.Message) error {
+	if msg.Type == events.TypeUserWalletChanged {
+		return updateWalletAddress(tx, msg)
+	}
+	return createWallet(tx, msg)
+}
+
+// createWallet tạo ví rỗng cho user vừa đăng ký. Ví có thể đã được tạo trước đó
+// (user gọi API ví trước khi event tới) nên trùng user_id thì bỏ qua.
+func createWallet(tx *gorm.DB, msg eventbus.Message) error {
+	var e events.UserRegistered
+	if err := events.Decode(msg.Payload, &e); err != nil {
+		return err
+	}
+
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.UserWallet{UserID: e.UserID}).Error
+}
+
+// updateWalletAddress đồng bộ ví nhận tiền nạp. Chỉ ví đã xác thực qua SIWE mới được theo dõi,
+// địa chỉ chưa xác thực thì xóa để deposit indexer không ghi có cho ví không rõ chủ.
+func updateWalletAddress(tx *gorm.DB, msg eventbus.Message) error {
+	var e events.UserWalletChanged
+	if err := events.Decode(msg.Payload, &e); err != nil {
+		return err
+	}
+
+	address := ""
+	if e.Verified {
+		address = e.WalletAddress
+	}
+	return repository.SetWalletAddress(tx, e.UserID, address)
+}
@@ -0,0 +1,239 @@
+// Package indexer chứa các tiến trình nền quét blockchain cho payment-service
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"payment-service/internal/chain"
+	"payment-service/internal/models"
+	"payment-service/internal/repository"
+	"time"
+)
+
+// cursorName là tên con trỏ của deposit indexer trong bảng chain_cursors
+const cursorName = "deposits"
+
+// ErrReorgTooDeep được trả về khi không tìm thấy tổ tiên chung trong cửa sổ block đã lưu;
+// indexer dừng lại để người vận hành xử lý thay vì ghi sai sổ cái
+var ErrReorgTooDeep = errors.New("reorg sâu hơn cửa sổ block đã lưu")
+
+// DepositIndexer quét từng block tìm giao dịch chuyển coin tới ví đã liên kết của user,
+// ghi Deposit pending và chỉ ghi có vào ví khi block đủ Confirmations xác nhận.
+// Khi block đã quét bị thay thế (reorg), Deposit trong các block bị loại được hủy hoặc đảo.
+type DepositIndexer struct {
+	repo          *repository.DepositRepository
+	client        chain.ChainClient
+	Confirmations uint64        // Số xác nhận cần có trước khi ghi có, mặc định 12
+	PollInterval  time.Duration // Chu kỳ quét, mặc định 5s
+	WeiPerCoin    *big.Int      // Số wei đổi được 1 xu, phần lẻ bị bỏ qua
+	StartBlock    uint64        // Block bắt đầu quét khi chưa có con trỏ
+}
+
+// NewDepositIndexer tạo DepositIndexer với cấu hình mặc định
+func NewDepositIndexer(repo *repository.DepositRepository, client chain.ChainClient) *DepositIndexer {
+	return &DepositIndexer{
+		repo:          repo,
+		client:        client,
+		Confirmations: 12,
+		PollInterval:  5 * time.Second,
+		WeiPerCoin:    big.NewInt(1_000_000_000_000_000), // 0.001 ETH = 1 xu
+		StartBlock:    1,
+	}
+}
+
+// Config là tham số của deposit indexer
+type Config struct {
+	Confirmations uint64        `env:"DEPOSIT_CONFIRMATIONS" default:"12" min:"1"`
+	PollInterval  time.Duration `env:"DEPOSIT_POLL_INTERVAL" default:"5s" min:"1ms"`
+	WeiPerCoin    string        `env:"DEPOSIT_WEI_PER_COIN" default:"1000000000000000"` // Số nguyên lớn, hệ 10
+	StartBlock    uint64        `env:"DEPOSIT_START_BLOCK" default:"1"`
+}
+
+// New tạo DepositIndexer theo cfg
+func New(repo *repository.DepositRepository, client chain.ChainClient, cfg Config) (*DepositIndexer, error) {
+	weiPerCoin, ok := new(big.Int).SetString(cfg.WeiPerCoin, 10)
+	if !ok || weiPerCoin.Sign() <= 0 {
+		return nil, fmt.Errorf("DEPOSIT_WEI_PER_COIN không hợp lệ: %q", cfg.WeiPerCoin)
+	}
+
+	ix := NewDepositIndexer(repo, client)
+	ix.Confirmations = cfg.Confirmations
+	ix.PollInterval = cfg.PollInterval
+	ix.WeiPerCoin = weiPerCoin
+	ix.StartBlock = cfg.StartBlock
+	return ix, nil
+}
+
+// Run gọi Sync sau mỗi PollInterval cho tới khi ctx bị hủy
+func (ix *DepositIndexer) Run(ctx context.Context) {
+	ticker := time.NewTicker(ix.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := ix.Sync(ctx); err != nil && ctx.Err() == nil {
+			slog.Warn("Deposit indexer lỗi", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync quét các block mới tới head, xử lý reorg nếu có, rồi ghi có các Deposit đã đủ xác nhận
+func (ix *DepositIndexer) Sync(ctx context.Context) error {
+	head, err := ix.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("không lấy được block mới nhất: %w", err)
+	}
+
+	cursor, err := ix.repo.GetCursor(cursorName)
+	if err != nil {
+		return err
+	}
+
+	next := ix.StartBlock
+	parentHash := ""
+	if cursor != nil {
+		next = cursor.BlockNumber + 1
+		parentHash = cursor.BlockHash
+
+		// Block ở con trỏ không còn trên chuỗi chính (chain ngắn lại hoặc đã bị thay thế)
+		canonical, err := ix.client.BlockByNumber(ctx, cursor.BlockNumber)
+		if err != nil && !errors.Is(err, chain.ErrNotFound) {
+			return fmt.Errorf("không lấy được block %d: %w", cursor.BlockNumber, err)
+		}
+		if canonical == nil || canonical.Hash != cursor.BlockHash {
+			number, hash, err := ix.rewind(ctx, min(cursor.BlockNumber, head))
+			if err != nil {
+				return err
+			}
+			next, parentHash = number+1, hash
+		}
+	}
+
+	for next <= head {
+		block, err := ix.client.BlockByNumber(ctx, next)
+		if err != nil {
+			return fmt.Errorf("không lấy được block %d: %w", next, err)
+		}
+
+		// Block mới không nối tiếp block đã quét: chuỗi đã bị thay thế từ đâu đó phía sau
+		if parentHash != "" && block.ParentHash != parentHash {
+			number, hash, err := ix.rewind(ctx, next-1)
+			if err != nil {
+				return err
+			}
+			next, parentHash = number+1, hash
+			continue
+		}
+
+		deposits, err := ix.extractDeposits(ctx, block)
+		if err != nil {
+			return err
+		}
+		if err := ix.repo.RecordBlock(cursorName, block.Number, block.Hash, block.ParentHash, deposits); err != nil {
+			return err
+		}
+		next, parentHash = block.Number+1, block.Hash
+	}
+
+	if head+1 > ix.Confirmations {
+		if _, err := ix.repo.ConfirmDeposits(head + 1 - ix.Confirmations); err != nil {
+			return err
+		}
+	}
+	return ix.repo.TouchWallets(time.Now())
+}
+
+// rewind tìm block gần nhất (từ from trở về trước) có hash đã lưu trùng với chain hiện tại,
+// rồi hủy/đảo các Deposit sau block đó. Block ngay trước StartBlock luôn được coi là tổ tiên
+// chung vì indexer không quan tâm tới phần chain trước đó. Trả về số và hash của tổ tiên chung.
+func (ix *DepositIndexer) rewind(ctx context.Context, from uint64) (uint64, string, error) {
+	var ancestor *models.IndexedBlock
+	for number := from; number >= ix.StartBlock && ancestor == nil; number-- {
+		stored, err := ix.repo.GetIndexedBlock(number)
+		if err != nil {
+			return 0, "", err
+		}
+		if stored == nil {
+			return 0, "", fmt.Errorf("%w (block %d)", ErrReorgTooDeep, number)
+		}
+
+		canonical, err := ix.client.BlockByNumber(ctx, number)
+		if err != nil {
+			return 0, "", fmt.Errorf("không lấy được block %d: %w", number, err)
+		}
+		if canonical.Hash == stored.Hash {
+			ancestor = stored
+		}
+		if number == 0 {
+			break
+		}
+	}
+
+	if ancestor == nil {
+		if ix.StartBlock == 0 {
+			return 0, "", fmt.Errorf("%w (genesis khác nhau)", ErrReorgTooDeep)
+		}
+		base, err := ix.client.BlockByNumber(ctx, ix.StartBlock-1)
+		if err != nil {
+			return 0, "", fmt.Errorf("không lấy được block %d: %w", ix.StartBlock-1, err)
+		}
+		ancestor = &models.IndexedBlock{Number: base.Number, Hash: base.Hash, ParentHash: base.ParentHash}
+	}
+
+	affected, err := ix.repo.Rewind(cursorName, ancestor)
+	if err != nil {
+		return 0, "", err
+	}
+	slog.Warn("Deposit indexer: reorg, đã hủy/đảo giao dịch nạp tiền", "from_block", ancestor.Number+1, "deposits", affected)
+	return ancestor.Number, ancestor.Hash, nil
+}
+
+// extractDeposits lọc các giao dịch chuyển coin thành công tới ví đã liên kết trong block
+func (ix *DepositIndexer) extractDeposits(ctx context.Context, block *chain.Block) ([]repository.ChainDeposit, error) {
+	addresses := make([]string, 0, len(block.Transactions))
+	for _, tx := range block.Transactions {
+		if tx.To != "" && tx.Value != nil && tx.Value.Sign() > 0 {
+			addresses = append(addresses, tx.To)
+		}
+	}
+	owners, err := ix.repo.WalletOwners(addresses)
+	if err != nil || len(owners) == 0 {
+		return nil, err
+	}
+
+	var deposits []repository.ChainDeposit
+	for _, tx := range block.Transactions {
+		userID, ok := owners[tx.To]
+		if !ok || tx.Value == nil {
+			continue
+		}
+		coins := new(big.Int).Quo(tx.Value, ix.WeiPerCoin)
+		if coins.Sign() <= 0 || !coins.IsInt64() {
+			continue
+		}
+
+		// Giao dịch vào block nhưng thực thi thất bại thì không có tiền được chuyển
+		receipt, err := ix.client.TransactionReceipt(ctx, tx.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("không lấy được receipt %s: %w", tx.Hash, err)
+		}
+		if receipt.Status != chain.ReceiptStatusSuccessful {
+			continue
+		}
+
+		deposits = append(deposits, repository.ChainDeposit{
+			UserID: userID,
+			TxHash: tx.Hash,
+			Amount: int(coins.Int64()),
+		})
+	}
+	return deposits, nil
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// ChainCursor lưu block cuối cùng một tiến trình quét chain đã xử lý, để khởi động lại
+// không phải quét lại từ đầu
+type ChainCursor struct {
+	Name        string    `gorm:"primaryKey;type:varchar(64)"`
+	BlockNumber uint64    `gorm:"not null"`
+	BlockHash   string    `gorm:"type:varchar(66);not null"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// IndexedBlock là hash của các block đã quét gần đây, dùng để tìm tổ tiên chung khi reorg
+type IndexedBlock struct {
+	Number     uint64    `gorm:"primaryKey;autoIncrement:false"`
+	Hash       string    `gorm:"type:varchar(66);not null"`
+	ParentHash string    `gorm:"type:varchar(66);not null"`
+	IndexedAt  time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Các tài khoản hệ thống trong sổ cái. Tài khoản ví của user có dạng "user:<id>" (xem UserAccount).
+const (
+	AccountDeposits = "system:deposits" // Đối ứng khi tiền được nạp từ bên ngoài vào hệ thống
+	AccountRevenue  = "system:revenue"  // Doanh thu từ việc bán truyện/chương
+)
+
+// LedgerEntry là một bút toán kép bất biến: mỗi Transaction confirmed có ít nhất hai entry
+// với tổng Amount bằng 0. Amount dương làm tăng số dư tài khoản, âm làm giảm.
+// Số dư ví được tính bằng tổng Amount của tài khoản "user:<id>"; bảng này chỉ được INSERT.
+type LedgerEntry struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	TransactionID uint      `gorm:"index;not null" json:"transaction_id"`
+	Account       string    `gorm:"type:varchar(64);index;not null" json:"account"`
+	Amount        int       `gorm:"not null" json:"amount"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// UserAccount trả về tên tài khoản sổ cái ứng với ví của user
+func UserAccount(userID uint) string {
+	return fmt.Sprintf("user:%d", userID)
+}
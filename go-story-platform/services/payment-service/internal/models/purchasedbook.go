@@ -6,14 +6,27 @@ import (
 	"gorm.io/gorm"
 )
 
+// Trạng thái đúc token sở hữu của PurchasedBook. Token chỉ được đúc khi user đã liên kết ví;
+// trước đó bản ghi ở trạng thái pending.
+const (
+	NFTStatusPending = "pending" // Chờ đúc (user chưa có ví hoặc minter chưa xử lý)
+	NFTStatusMinting = "minting" // Đã gửi giao dịch đúc, chờ đủ xác nhận
+	NFTStatusMinted  = "minted"  // NFTTokenID đã có trên chain
+	NFTStatusFailed  = "failed"  // Giao dịch đúc thất bại, cần người vận hành xử lý
+)
+
 type PurchasedBook struct {
-	ID          uint   `gorm:"primaryKey"`
-	UserID      uint   `gorm:"index"`
-	BookID      uint   `gorm:"index"`
-	ChapterID   uint   `gorm:"index"` // Có thể mua lẻ chương
-	NFTTokenID  string `gorm:"index"` // Bằng chứng sở hữu Blockchain
-	PurchasedAt time.Time
-	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID            uint   `gorm:"primaryKey"`
+	UserID        uint   `gorm:"index;uniqueIndex:idx_purchased_books_owner_active,where:deleted_at IS NULL"`
+	BookID        uint   `gorm:"index;uniqueIndex:idx_purchased_books_owner_active,where:deleted_at IS NULL"`
+	ChapterID     uint   `gorm:"index;uniqueIndex:idx_purchased_books_owner_active,where:deleted_at IS NULL"` // Có thể mua lẻ chương
+	NFTTokenID    string `gorm:"index"`                                                                       // Bằng chứng sở hữu Blockchain
+	NFTStatus     string `gorm:"type:varchar(16);default:'pending'"`
+	NFTTxHash     string `gorm:"type:varchar(66)"` // Giao dịch đúc token
+	OwnerAddress  string `gorm:"type:varchar(42)"` // Ví đang giữ token theo lần đối soát gần nhất
+	TransactionID uint   `gorm:"index"`            // Giao dịch Purchase đã thanh toán
+	PurchasedAt   time.Time
+	CreatedAt     time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
 }
@@ -6,14 +6,44 @@ import (
 	"gorm.io/gorm"
 )
 
+// Các loại giao dịch
+const (
+	TransactionTypeDeposit  = "Deposit"
+	TransactionTypePurchase = "Purchase"
+	// Bút toán đảo của một Deposit đã ghi có nhưng block chứa nó bị loại do reorg
+	TransactionTypeDepositReversal = "DepositReversal"
+)
+
+// Các trạng thái giao dịch. Chỉ cho phép pending -> confirmed hoặc pending -> failed;
+// confirmed và failed là trạng thái cuối, không thể đổi lại.
+const (
+	TransactionStatusPending   = "pending"
+	TransactionStatusConfirmed = "confirmed"
+	TransactionStatusFailed    = "failed"
+)
+
 type Transaction struct {
-	ID        uint           `gorm:"primaryKey"`
-	UserID    uint           `gorm:"index"`
-	Amount    int            `gorm:"not null"`
-	Type      string         `gorm:"type:varchar(20)"` // Deposit, Purchase
-	Status    string         `gorm:"type:varchar(20);default:'pending'"`
-	TxHash    string         `gorm:"index"` // Hash trên Blockchain
-	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID            uint           `gorm:"primaryKey"`
+	UserID        uint           `gorm:"index"`
+	Amount        int            `gorm:"not null"`
+	Type          string         `gorm:"type:varchar(20)"` // Deposit, Purchase
+	Status        string         `gorm:"type:varchar(20);default:'pending'"`
+	TxHash        string         `gorm:"index"` // Hash trên Blockchain
+	BlockNumber   uint64         `gorm:"index"` // Block chứa TxHash khi do deposit indexer ghi nhận
+	BlockHash     string         `gorm:"type:varchar(66)"`
+	BookID        uint           `gorm:"index"`        // Với giao dịch Purchase
+	ChapterID     uint           `gorm:"index"`        // Với giao dịch mua lẻ chương
+	FailureReason string         `gorm:"type:text"`    // Lý do khi Status = failed
+	ConfirmedAt   *time.Time     `json:"confirmed_at"` // Thời điểm bút toán được ghi vào sổ cái
+	CreatedAt     time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// CanTransitionTo kiểm tra trạng thái hiện tại có được chuyển sang trạng thái next không
+func (t *Transaction) CanTransitionTo(next string) bool {
+	if t.Status != TransactionStatusPending {
+		return false
+	}
+	return next == TransactionStatusConfirmed || next == TransactionStatusFailed
 }
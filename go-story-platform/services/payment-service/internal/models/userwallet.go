@@ -7,9 +7,10 @@ import (
 )
 
 type UserWallet struct {
-	UserID          uint `gorm:"primaryKey"` // Lấy từ User Service
-	BalanceInternal int  `gorm:"default:0"`  // Xu trong web
-	LastSyncAt      time.Time
+	UserID          uint           `gorm:"primaryKey;autoIncrement:false"` // Lấy từ User Service
+	BalanceInternal int            `gorm:"default:0"`                      // Xu trong web, bản cache của tổng LedgerEntry "user:<id>"
+	WalletAddress   string         `gorm:"type:varchar(42);index"`         // Ví đã xác thực ở User Service (chữ thường), rỗng nếu chưa liên kết
+	LastSyncAt      time.Time      // Lần cuối deposit indexer quét chain cho ví này
 	Transactions    []Transaction  `gorm:"foreignKey:UserID;references:UserID"`
 	CreatedAt       time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt       time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
@@ -0,0 +1,158 @@
+// Package nft đúc token sở hữu (ERC-721) cho các lần mua và mô tả metadata của token
+package nft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"payment-service/internal/chain"
+	"payment-service/internal/repository"
+	"strings"
+	"time"
+)
+
+// Minter đúc token sở hữu cho các PurchasedBook pending của user đã liên kết ví, rồi lưu
+// token ID khi giao dịch đúc đủ Confirmations xác nhận
+type Minter struct {
+	repo            *repository.NFTRepository
+	client          chain.ChainClient
+	MetadataBaseURL string        // tokenURI = MetadataBaseURL + "/" + purchase ID
+	Confirmations   uint64        // Số xác nhận trước khi coi token đã đúc, mặc định 12
+	PollInterval    time.Duration // Chu kỳ quét, mặc định 5s
+	BatchSize       int
+}
+
+// NewMinter tạo Minter với cấu hình mặc định
+func NewMinter(repo *repository.NFTRepository, client chain.ChainClient, metadataBaseURL string) *Minter {
+	return &Minter{
+		repo:            repo,
+		client:          client,
+		MetadataBaseURL: strings.TrimSuffix(metadataBaseURL, "/"),
+		Confirmations:   12,
+		PollInterval:    5 * time.Second,
+		BatchSize:       50,
+	}
+}
+
+// Config là tham số của Minter
+type Config struct {
+	MetadataBaseURL string        `env:"NFT_METADATA_BASE_URL" default:"http://localhost:8082/nfts/metadata"`
+	Confirmations   uint64        `env:"NFT_MINT_CONFIRMATIONS" default:"12" min:"1"`
+	PollInterval    time.Duration `env:"NFT_MINT_POLL_INTERVAL" default:"5s" min:"1ms"`
+}
+
+// New tạo Minter theo cfg
+func New(repo *repository.NFTRepository, client chain.ChainClient, cfg Config) *Minter {
+	m := NewMinter(repo, client, cfg.MetadataBaseURL)
+	m.Confirmations = cfg.Confirmations
+	m.PollInterval = cfg.PollInterval
+	return m
+}
+
+// Run gọi Sync sau mỗi PollInterval cho tới khi ctx bị hủy
+func (m *Minter) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Sync(ctx); err != nil && ctx.Err() == nil {
+			slog.Warn("NFT minter lỗi", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync gửi giao dịch đúc cho các lần mua mới và hoàn tất các giao dịch đúc đã đủ xác nhận
+func (m *Minter) Sync(ctx context.Context) error {
+	if err := m.submitMints(ctx); err != nil {
+		return err
+	}
+	return m.completeMints(ctx)
+}
+
+// TokenURI trả về địa chỉ metadata của token đúc cho lần mua purchaseID
+func (m *Minter) TokenURI(purchaseID uint) string {
+	return fmt.Sprintf("%s/%d", m.MetadataBaseURL, purchaseID)
+}
+
+func (m *Minter) submitMints(ctx context.Context) error {
+	pending, err := m.repo.ClaimPendingMints(m.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range pending {
+		txHash, err := m.client.MintToken(ctx, chain.MintRequest{
+			To:       p.WalletAddress,
+			TokenURI: m.TokenURI(p.Purchase.ID),
+		})
+		if err != nil {
+			if releaseErr := m.repo.ReleaseMint(p.Purchase.ID); releaseErr != nil {
+				return releaseErr
+			}
+			return fmt.Errorf("không gửi được giao dịch đúc cho lần mua %d: %w", p.Purchase.ID, err)
+		}
+		if err := m.repo.SetMintTxHash(p.Purchase.ID, txHash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Minter) completeMints(ctx context.Context) error {
+	minting, err := m.repo.ListMinting(m.BatchSize)
+	if err != nil || len(minting) == 0 {
+		return err
+	}
+
+	head, err := m.client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range minting {
+		receipt, err := m.client.TransactionReceipt(ctx, p.NFTTxHash)
+		if errors.Is(err, chain.ErrNotFound) {
+			continue // Chưa vào block hoặc block chứa nó vừa bị reorg
+		}
+		if err != nil {
+			return err
+		}
+		if chain.Confirmations(head, receipt.BlockNumber) < m.Confirmations {
+			continue
+		}
+
+		if receipt.Status != chain.ReceiptStatusSuccessful {
+			slog.Warn("NFT minter: giao dịch đúc thất bại", "tx_hash", p.NFTTxHash, "purchase_id", p.ID)
+			if err := m.repo.MarkMintFailed(p.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		event, ok := mintedTransfer(receipt)
+		if !ok {
+			return fmt.Errorf("receipt %s không có event Transfer đúc token", p.NFTTxHash)
+		}
+		if err := m.repo.MarkMinted(p.ID, event.TokenID.String(), event.To); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mintedTransfer lấy event Transfer(0x0 -> người nhận) trong receipt của giao dịch đúc
+func mintedTransfer(receipt *chain.Receipt) (chain.TransferEvent, bool) {
+	for _, log := range receipt.Logs {
+		if event, ok := chain.DecodeTransfer(log); ok && event.TokenID != nil && event.From == chain.ZeroAddress {
+			return event, true
+		}
+	}
+	return chain.TransferEvent{}, false
+}
@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"payment-service/internal/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// indexedBlockWindow là số block gần nhất được giữ hash trong indexed_blocks; reorg sâu hơn
+// cửa sổ này không thể tự xử lý
+const indexedBlockWindow = 512
+
+// ChainDeposit là một giao dịch nạp tiền tìm thấy trong block, đã quy đổi ra xu
+type ChainDeposit struct {
+	UserID uint
+	TxHash string
+	Amount int
+}
+
+// DepositRepository lưu trạng thái của deposit indexer: con trỏ block, hash các block đã quét
+// và các giao dịch Deposit ghi nhận từ chain
+type DepositRepository struct {
+	db *gorm.DB
+}
+
+// NewDepositRepository tạo DepositRepository với kết nối DB được truyền vào
+func NewDepositRepository(db *gorm.DB) *DepositRepository {
+	return &DepositRepository{db: db}
+}
+
+// GetCursor lấy con trỏ block theo tên, trả về nil nếu chưa quét lần nào
+func (r *DepositRepository) GetCursor(name string) (*models.ChainCursor, error) {
+	var cursor models.ChainCursor
+
+	err := r.db.Where("name = ?", name).First(&cursor).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &cursor, nil
+}
+
+// GetIndexedBlock lấy hash đã lưu của block number, trả về nil nếu không còn trong cửa sổ
+func (r *DepositRepository) GetIndexedBlock(number uint64) (*models.IndexedBlock, error) {
+	var block models.IndexedBlock
+
+	err := r.db.First(&block, number).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+// WalletOwners trả về map địa chỉ ví (chữ thường) -> user ID cho các địa chỉ có ví đã liên kết
+func (r *DepositRepository) WalletOwners(addresses []string) (map[string]uint, error) {
+	owners := make(map[string]uint)
+	if len(addresses) == 0 {
+		return owners, nil
+	}
+
+	var wallets []models.UserWallet
+	if err := r.db.Where("wallet_address IN ?", addresses).Find(&wallets).Error; err != nil {
+		return nil, err
+	}
+	for _, w := range wallets {
+		owners[w.WalletAddress] = w.UserID
+	}
+	return owners, nil
+}
+
+// RecordBlock ghi nhận một block đã quét trong một DB transaction: lưu hash block, tạo các
+// Deposit pending và dời con trỏ. Quét lại cùng block (sau khi crash) không tạo Deposit trùng
+// nhờ unique index (tx_hash, block_hash).
+func (r *DepositRepository) RecordBlock(cursorName string, number uint64, hash, parentHash string, deposits []ChainDeposit) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		block := models.IndexedBlock{Number: number, Hash: hash, ParentHash: parentHash}
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&block).Error; err != nil {
+			return err
+		}
+
+		for _, d := range deposits {
+			if err := recordDeposit(tx, number, hash, d); err != nil {
+				return err
+			}
+		}
+
+		if number > indexedBlockWindow {
+			if err := tx.Where("number < ?", number-indexedBlockWindow).
+				Delete(&models.IndexedBlock{}).Error; err != nil {
+				return err
+			}
+		}
+		return saveCursor(tx, cursorName, number, hash)
+	})
+}
+
+// ConfirmDeposits ghi có cho các Deposit pending nằm trong block <= maxBlock (đã đủ số xác nhận).
+// Mỗi giao dịch được xác nhận trong DB transaction riêng, trả về số giao dịch đã xác nhận.
+func (r *DepositRepository) ConfirmDeposits(maxBlock uint64) (int, error) {
+	var ids []uint
+	err := r.db.Model(&models.Transaction{}).
+		Where("type = ? AND status = ? AND block_hash <> '' AND block_number <= ?",
+			models.TransactionTypeDeposit, models.TransactionStatusPending, maxBlock).
+		Order("id").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return 0, err
+	}
+
+	confirmed := 0
+	for _, id := range ids {
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			var txn models.Transaction
+			if err := lockTransaction(tx, id, &txn); err != nil {
+				return err
+			}
+			if !txn.CanTransitionTo(models.TransactionStatusConfirmed) {
+				return nil
+			}
+			if err := lockWallet(tx, txn.UserID); err != nil {
+				return err
+			}
+			if err := postEntries(tx, &txn, []models.LedgerEntry{
+				{Account: models.UserAccount(txn.UserID), Amount: txn.Amount},
+				{Account: models.AccountDeposits, Amount: -txn.Amount},
+			}); err != nil {
+				return err
+			}
+			confirmed++
+			return refreshWalletBalance(tx, txn.UserID)
+		})
+		if err != nil {
+			return confirmed, err
+		}
+	}
+	return confirmed, nil
+}
+
+// Rewind xử lý reorg: mọi block sau ancestor bị loại khỏi chuỗi chính. Deposit còn pending
+// trong các block đó chuyển sang failed; Deposit đã ghi có được đảo bằng giao dịch
+// DepositReversal. Con trỏ lùi về ancestor. Trả về số giao dịch đã bị hủy hoặc đảo.
+func (r *DepositRepository) Rewind(cursorName string, ancestor *models.IndexedBlock) (int, error) {
+	affected := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var orphaned []models.Transaction
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("type = ? AND block_hash <> '' AND block_number > ? AND status <> ?",
+				models.TransactionTypeDeposit, ancestor.Number, models.TransactionStatusFailed).
+			Order("id").
+			Find(&orphaned).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range orphaned {
+			txn := &orphaned[i]
+			if txn.Status == models.TransactionStatusPending {
+				txn.Status = models.TransactionStatusFailed
+				txn.FailureReason = "Block chứa giao dịch đã bị loại do reorg"
+				if err := tx.Save(txn).Error; err != nil {
+					return err
+				}
+				affected++
+				continue
+			}
+			reversed, err := reverseDeposit(tx, txn)
+			if err != nil {
+				return err
+			}
+			if reversed {
+				affected++
+			}
+		}
+
+		if err := tx.Where("number > ?", ancestor.Number).Delete(&models.IndexedBlock{}).Error; err != nil {
+			return err
+		}
+		return saveCursor(tx, cursorName, ancestor.Number, ancestor.Hash)
+	})
+	return affected, err
+}
+
+// TouchWallets cập nhật LastSyncAt cho mọi ví đã liên kết sau một lượt quét thành công
+func (r *DepositRepository) TouchWallets(at time.Time) error {
+	return r.db.Model(&models.UserWallet{}).
+		Where("wallet_address <> ''").
+		Update("last_sync_at", at).Error
+}
+
+// SetWalletAddress gán địa chỉ ví đã xác thực cho user (tạo ví nếu chưa có); address rỗng
+// nghĩa là ngừng theo dõi tiền nạp của user
+func SetWalletAddress(tx *gorm.DB, userID uint, address string) error {
+	wallet := models.UserWallet{UserID: userID, WalletAddress: strings.ToLower(address)}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"wallet_address", "updated_at"}),
+	}).Create(&wallet).Error
+}
+
+// recordDeposit tạo Deposit pending cho giao dịch trong block. Nếu user đã khai báo thủ công
+// cùng TxHash qua POST /wallet/deposits thì dùng lại giao dịch đó với số tiền thực tế trên chain.
+func recordDeposit(tx *gorm.DB, number uint64, hash string, d ChainDeposit) error {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.UserWallet{UserID: d.UserID}).Error; err != nil {
+		return err
+	}
+
+	if claimed, err := claimDeclaredDeposit(tx, number, hash, d); claimed || err != nil {
+		return err
+	}
+
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Transaction{
+		UserID:      d.UserID,
+		Amount:      d.Amount,
+		Type:        models.TransactionTypeDeposit,
+		Status:      models.TransactionStatusPending,
+		TxHash:      d.TxHash,
+		BlockNumber: number,
+		BlockHash:   hash,
+	})
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+	// Không chèn được: hoặc block đã được quét trước đó, hoặc user vừa khai báo cùng TxHash
+	// sau lần tìm ở trên (unique index Deposit pending) - khi đó nhận lại khai báo ấy
+	_, err := claimDeclaredDeposit(tx, number, hash, d)
+	return err
+}
+
+// claimDeclaredDeposit gán block và số tiền thực tế cho Deposit pending user đã khai báo thủ công
+// với TxHash của d, trả về false nếu không có khai báo nào
+func claimDeclaredDeposit(tx *gorm.DB, number uint64, hash string, d ChainDeposit) (bool, error) {
+	var claimed models.Transaction
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("type = ? AND status = ? AND tx_hash = ? AND block_hash = ''",
+			models.TransactionTypeDeposit, models.TransactionStatusPending, d.TxHash).
+		First(&claimed).Error
+	if err == gorm.ErrRecordNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	claimed.UserID = d.UserID
+	claimed.Amount = d.Amount
+	claimed.BlockNumber = number
+	claimed.BlockHash = hash
+	return true, tx.Save(&claimed).Error
+}
+
+// reverseDeposit ghi giao dịch DepositReversal đảo bút toán của một Deposit đã xác nhận.
+// Số dư ví có thể âm nếu user đã tiêu số xu này. Mỗi Deposit chỉ bị đảo một lần.
+func reverseDeposit(tx *gorm.DB, deposit *models.Transaction) (bool, error) {
+	var count int64
+	err := tx.Model(&models.Transaction{}).
+		Where("type = ? AND tx_hash = ? AND block_hash = ?",
+			models.TransactionTypeDepositReversal, deposit.TxHash, deposit.BlockHash).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return false, err
+	}
+
+	if err := lockWallet(tx, deposit.UserID); err != nil {
+		return false, err
+	}
+	reversal := models.Transaction{
+		UserID:      deposit.UserID,
+		Amount:      deposit.Amount,
+		Type:        models.TransactionTypeDepositReversal,
+		Status:      models.TransactionStatusPending,
+		TxHash:      deposit.TxHash,
+		BlockNumber: deposit.BlockNumber,
+		BlockHash:   deposit.BlockHash,
+	}
+	if err := tx.Create(&reversal).Error; err != nil {
+		return false, err
+	}
+	if err := postEntries(tx, &reversal, []models.LedgerEntry{
+		{Account: models.UserAccount(deposit.UserID), Amount: -deposit.Amount},
+		{Account: models.AccountDeposits, Amount: deposit.Amount},
+	}); err != nil {
+		return false, err
+	}
+	return true, refreshWalletBalance(tx, deposit.UserID)
+}
+
+// saveCursor lưu con trỏ block của tiến trình quét
+func saveCursor(tx *gorm.DB, name string, number uint64, hash string) error {
+	cursor := models.ChainCursor{Name: name, BlockNumber: number, BlockHash: hash}
+	return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&cursor).Error
+}
@@ -0,0 +1,252 @@
+// Package repository cho ví, sổ cái và giao dịch trong payment-service
+package repository
+
+import (
+	"errors"
+	"payment-service/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrTransactionNotFound = errors.New("giao dịch không tồn tại")
+	ErrInvalidTransition   = errors.New("không thể chuyển trạng thái giao dịch")
+	ErrInsufficientBalance = errors.New("số dư không đủ")
+	ErrAlreadyOwned        = errors.New("đã sở hữu nội dung này")
+	ErrDuplicateDeposit    = errors.New("giao dịch on-chain này đã được ghi nhận")
+)
+
+// LedgerRepository quản lý ví, giao dịch và sổ cái bút toán kép.
+// Mọi thay đổi số dư đều đi qua postEntries trong một DB transaction, sau khi khóa dòng
+// user_wallets của user (SELECT ... FOR UPDATE) để các giao dịch đồng thời của cùng user
+// được xử lý tuần tự.
+type LedgerRepository struct {
+	db *gorm.DB
+}
+
+// NewLedgerRepository tạo LedgerRepository với kết nối DB được truyền vào
+func NewLedgerRepository(db *gorm.DB) *LedgerRepository {
+	return &LedgerRepository{db: db}
+}
+
+// GetOrCreateWallet lấy ví của user, tự tạo ví rỗng nếu chưa có
+func (r *LedgerRepository) GetOrCreateWallet(userID uint) (*models.UserWallet, error) {
+	wallet := models.UserWallet{UserID: userID}
+	err := r.db.Where(models.UserWallet{UserID: userID}).FirstOrCreate(&wallet).Error
+	return &wallet, err
+}
+
+// GetBalance tính số dư ví từ sổ cái (tổng các bút toán của tài khoản user)
+func (r *LedgerRepository) GetBalance(userID uint) (int, error) {
+	return accountBalance(r.db, models.UserAccount(userID))
+}
+
+// GetTransactionByID lấy giao dịch theo ID, trả về nil nếu không tồn tại
+func (r *LedgerRepository) GetTransactionByID(id uint) (*models.Transaction, error) {
+	var txn models.Transaction
+
+	err := r.db.First(&txn, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &txn, nil
+}
+
+// ListTransactions lấy lịch sử giao dịch của user, mới nhất trước, kèm tổng số bản ghi
+func (r *LedgerRepository) ListTransactions(userID uint, page, limit int) ([]models.Transaction, int64, error) {
+	query := r.db.Model(&models.Transaction{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var txns []models.Transaction
+	err := query.Order("id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&txns).Error
+	return txns, total, err
+}
+
+// CreateDeposit tạo giao dịch nạp tiền ở trạng thái pending. Số dư chỉ tăng khi giao dịch
+// được xác nhận qua ConfirmTransaction. Một TxHash chỉ được khai báo một lần (trừ khi lần
+// trước đã failed), kể cả khi deposit indexer đã tự ghi nhận nó từ chain.
+func (r *LedgerRepository) CreateDeposit(userID uint, amount int, txHash string) (*models.Transaction, error) {
+	if _, err := r.GetOrCreateWallet(userID); err != nil {
+		return nil, err
+	}
+	if txHash != "" {
+		var count int64
+		err := r.db.Model(&models.Transaction{}).
+			Where("type = ? AND tx_hash = ? AND status <> ?",
+				models.TransactionTypeDeposit, txHash, models.TransactionStatusFailed).
+			Count(&count).Error
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, ErrDuplicateDeposit
+		}
+	}
+
+	txn := models.Transaction{
+		UserID: userID,
+		Amount: amount,
+		Type:   models.TransactionTypeDeposit,
+		Status: models.TransactionStatusPending,
+		TxHash: txHash,
+	}
+	if err := r.db.Create(&txn).Error; err != nil {
+		// Yêu cầu khác cùng TxHash vừa được tạo sau lần kiểm tra trên
+		if isUniqueViolation(err, pendingDepositIndex) {
+			return nil, ErrDuplicateDeposit
+		}
+		return nil, err
+	}
+	return &txn, nil
+}
+
+// ConfirmTransaction chuyển giao dịch nạp tiền pending sang confirmed và ghi bút toán
+// (ví user +amount, system:deposits -amount) trong cùng một DB transaction.
+// Deposit do indexer ghi nhận (có BlockHash) chỉ được xác nhận tự động khi đủ số xác nhận.
+func (r *LedgerRepository) ConfirmTransaction(id uint) (*models.Transaction, error) {
+	var txn models.Transaction
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockTransaction(tx, id, &txn); err != nil {
+			return err
+		}
+		if txn.Type != models.TransactionTypeDeposit || txn.BlockHash != "" ||
+			!txn.CanTransitionTo(models.TransactionStatusConfirmed) {
+			return ErrInvalidTransition
+		}
+		if txn.TxHash != "" {
+			var count int64
+			err := tx.Model(&models.Transaction{}).
+				Where("type = ? AND tx_hash = ? AND status = ? AND id <> ?",
+					models.TransactionTypeDeposit, txn.TxHash, models.TransactionStatusConfirmed, txn.ID).
+				Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return ErrDuplicateDeposit
+			}
+		}
+
+		if err := lockWallet(tx, txn.UserID); err != nil {
+			return err
+		}
+		if err := postEntries(tx, &txn, []models.LedgerEntry{
+			{Account: models.UserAccount(txn.UserID), Amount: txn.Amount},
+			{Account: models.AccountDeposits, Amount: -txn.Amount},
+		}); err != nil {
+			return err
+		}
+		return refreshWalletBalance(tx, txn.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &txn, nil
+}
+
+// FailTransaction chuyển giao dịch pending sang failed kèm lý do; không ghi bút toán nào
+func (r *LedgerRepository) FailTransaction(id uint, reason string) (*models.Transaction, error) {
+	var txn models.Transaction
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockTransaction(tx, id, &txn); err != nil {
+			return err
+		}
+		if !txn.CanTransitionTo(models.TransactionStatusFailed) {
+			return ErrInvalidTransition
+		}
+
+		txn.Status = models.TransactionStatusFailed
+		txn.FailureReason = reason
+		return tx.Save(&txn).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &txn, nil
+}
+
+// lockTransaction đọc giao dịch với khóa FOR UPDATE để hai request không cùng đổi trạng thái
+func lockTransaction(tx *gorm.DB, id uint, txn *models.Transaction) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(txn, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return ErrTransactionNotFound
+	}
+	return err
+}
+
+// lockWallet khóa dòng ví của user (tạo ví nếu chưa có) cho tới hết DB transaction
+func lockWallet(tx *gorm.DB, userID uint) error {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.UserWallet{UserID: userID}).Error; err != nil {
+		return err
+	}
+	var wallet models.UserWallet
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", userID).
+		First(&wallet).Error
+}
+
+// postEntries ghi các bút toán của giao dịch và chuyển nó sang confirmed.
+// Tổng Amount của các entry phải bằng 0 theo nguyên tắc bút toán kép.
+func postEntries(tx *gorm.DB, txn *models.Transaction, entries []models.LedgerEntry) error {
+	sum := 0
+	for i := range entries {
+		entries[i].TransactionID = txn.ID
+		sum += entries[i].Amount
+	}
+	if sum != 0 {
+		return errors.New("bút toán không cân bằng")
+	}
+	if err := tx.Create(&entries).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	txn.Status = models.TransactionStatusConfirmed
+	txn.ConfirmedAt = &now
+	return tx.Save(txn).Error
+}
+
+// refreshWalletBalance cập nhật bản cache BalanceInternal từ sổ cái
+func refreshWalletBalance(tx *gorm.DB, userID uint) error {
+	balance, err := accountBalance(tx, models.UserAccount(userID))
+	if err != nil {
+		return err
+	}
+	return tx.Model(&models.UserWallet{}).
+		Where("user_id = ?", userID).
+		Update("balance_internal", balance).Error
+}
+
+// accountBalance tính tổng bút toán của một tài khoản sổ cái
+func accountBalance(db *gorm.DB, account string) (int, error) {
+	var balance int
+	err := db.Model(&models.LedgerEntry{}).
+		Where("account = ?", account).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&balance).Error
+	return balance, err
+}
+
+// pendingDepositIndex là unique index chỉ cho phép một Deposit pending cho mỗi TxHash
+const pendingDepositIndex = "idx_transactions_pending_deposit"
+
+// isUniqueViolation cho biết err là lỗi vi phạm unique index index của Postgres
+func isUniqueViolation(err error, index string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == index
+}
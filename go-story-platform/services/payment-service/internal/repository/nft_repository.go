@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"payment-service/internal/models"
+	"strings"
+	"time"
+
+	"shared/events"
+	"shared/outbox"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PendingMint là một bản ghi mua chờ đúc token kèm ví sẽ nhận token
+type PendingMint struct {
+	Purchase      models.PurchasedBook
+	WalletAddress string
+}
+
+// NFTRepository quản lý trạng thái đúc token sở hữu và việc chuyển quyền đọc theo chủ token
+type NFTRepository struct {
+	db *gorm.DB
+}
+
+// NewNFTRepository tạo NFTRepository với kết nối DB được truyền vào
+func NewNFTRepository(db *gorm.DB) *NFTRepository {
+	return &NFTRepository{db: db}
+}
+
+// ClaimPendingMints nhận tối đa limit bản ghi pending của các user đã liên kết ví và chuyển
+// chúng sang minting trước khi gửi giao dịch, để hai minter không đúc trùng một lần mua
+func (r *NFTRepository) ClaimPendingMints(limit int) ([]PendingMint, error) {
+	var rows []struct {
+		models.PurchasedBook
+		WalletAddress string
+	}
+	err := r.db.Table("purchased_books").
+		Select("purchased_books.*, user_wallets.wallet_address").
+		Joins("JOIN user_wallets ON user_wallets.user_id = purchased_books.user_id").
+		Where("purchased_books.nft_status = ? AND purchased_books.deleted_at IS NULL", models.NFTStatusPending).
+		Where("user_wallets.wallet_address <> ''").
+		Order("purchased_books.id").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]PendingMint, 0, len(rows))
+	for _, row := range rows {
+		result := r.db.Model(&models.PurchasedBook{}).
+			Where("id = ? AND nft_status = ?", row.ID, models.NFTStatusPending).
+			Update("nft_status", models.NFTStatusMinting)
+		if result.Error != nil {
+			return claimed, result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		row.PurchasedBook.NFTStatus = models.NFTStatusMinting
+		claimed = append(claimed, PendingMint{Purchase: row.PurchasedBook, WalletAddress: row.WalletAddress})
+	}
+	return claimed, nil
+}
+
+// ReleaseMint trả bản ghi về pending khi gửi giao dịch đúc thất bại, để lần quét sau thử lại
+func (r *NFTRepository) ReleaseMint(id uint) error {
+	return r.db.Model(&models.PurchasedBook{}).
+		Where("id = ? AND nft_status = ? AND nft_tx_hash = ''", id, models.NFTStatusMinting).
+		Update("nft_status", models.NFTStatusPending).Error
+}
+
+// SetMintTxHash lưu hash giao dịch đúc đã gửi lên chain
+func (r *NFTRepository) SetMintTxHash(id uint, txHash string) error {
+	return r.db.Model(&models.PurchasedBook{}).
+		Where("id = ?", id).
+		Update("nft_tx_hash", txHash).Error
+}
+
+// ListMinting lấy các bản ghi đã gửi giao dịch đúc nhưng chưa có token ID
+func (r *NFTRepository) ListMinting(limit int) ([]models.PurchasedBook, error) {
+	var purchases []models.PurchasedBook
+	err := r.db.Where("nft_status = ? AND nft_tx_hash <> ''", models.NFTStatusMinting).
+		Order("id").
+		Limit(limit).
+		Find(&purchases).Error
+	return purchases, err
+}
+
+// MarkMinted lưu token ID và ví đang giữ token sau khi giao dịch đúc đủ xác nhận
+func (r *NFTRepository) MarkMinted(id uint, tokenID, owner string) error {
+	return r.db.Model(&models.PurchasedBook{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"nft_token_id":  tokenID,
+			"nft_status":    models.NFTStatusMinted,
+			"owner_address": strings.ToLower(owner),
+		}).Error
+}
+
+// MarkMintFailed đánh dấu giao dịch đúc bị revert
+func (r *NFTRepository) MarkMintFailed(id uint) error {
+	return r.db.Model(&models.PurchasedBook{}).
+		Where("id = ?", id).
+		Update("nft_status", models.NFTStatusFailed).Error
+}
+
+// GetPurchaseByID lấy bản ghi mua theo ID kể cả khi đã bị thu hồi, trả về nil nếu không tồn tại.
+// Metadata của token được phục vụ theo ID của lần mua gốc nên vẫn phải đọc được sau khi chuyển quyền.
+func (r *NFTRepository) GetPurchaseByID(id uint) (*models.PurchasedBook, error) {
+	var purchase models.PurchasedBook
+
+	err := r.db.Unscoped().First(&purchase, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &purchase, nil
+}
+
+// GetPurchaseByTokenID lấy bản ghi mới nhất gắn với token (kể cả đã bị thu hồi),
+// trả về nil nếu token không do hệ thống đúc
+func (r *NFTRepository) GetPurchaseByTokenID(tokenID string) (*models.PurchasedBook, error) {
+	var purchase models.PurchasedBook
+
+	err := r.db.Unscoped().
+		Where("nft_token_id = ?", tokenID).
+		Order("id DESC").
+		First(&purchase).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &purchase, nil
+}
+
+// FindWalletOwner trả về user đã liên kết địa chỉ ví, 0 nếu không có
+func (r *NFTRepository) FindWalletOwner(address string) (uint, error) {
+	var wallet models.UserWallet
+
+	err := r.db.Where("wallet_address = ?", strings.ToLower(address)).First(&wallet).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return wallet.UserID, nil
+}
+
+// TransferOwnership chuyển quyền đọc của token sang user đang giữ token (newUserID = 0 nếu
+// ví giữ token chưa liên kết tài khoản). Bản ghi cũ bị thu hồi, người nhận có bản ghi mới cùng
+// token và giao dịch gốc, trừ khi họ đã tự sở hữu nội dung này. Event PurchaseTransferred được
+// ghi trong cùng transaction. Trả về bản ghi đang có hiệu lực của token (nil nếu không có).
+func (r *NFTRepository) TransferOwnership(purchase *models.PurchasedBook, newUserID uint, ownerAddress string) (*models.PurchasedBook, error) {
+	ownerAddress = strings.ToLower(ownerAddress)
+	var current *models.PurchasedBook
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var locked models.PurchasedBook
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&locked, purchase.ID).Error; err != nil {
+			return err
+		}
+
+		active := !locked.DeletedAt.Valid
+		if active && locked.UserID == newUserID {
+			current = &locked
+			return tx.Model(&locked).Update("owner_address", ownerAddress).Error
+		}
+
+		if active {
+			if err := tx.Model(&locked).Update("owner_address", ownerAddress).Error; err != nil {
+				return err
+			}
+			if err := tx.Delete(&locked).Error; err != nil {
+				return err
+			}
+		}
+
+		event := events.PurchaseTransferred{
+			FromUserID:    locked.UserID,
+			ToUserID:      newUserID,
+			BookID:        locked.BookID,
+			ChapterID:     locked.ChapterID,
+			NFTTokenID:    locked.NFTTokenID,
+			OwnerAddress:  ownerAddress,
+			TransferredAt: time.Now(),
+		}
+
+		if newUserID != 0 {
+			owned, err := hasPurchase(tx, newUserID, locked.BookID, locked.ChapterID)
+			if err != nil {
+				return err
+			}
+			if !owned {
+				next := models.PurchasedBook{
+					UserID:        newUserID,
+					BookID:        locked.BookID,
+					ChapterID:     locked.ChapterID,
+					NFTTokenID:    locked.NFTTokenID,
+					NFTStatus:     models.NFTStatusMinted,
+					NFTTxHash:     locked.NFTTxHash,
+					OwnerAddress:  ownerAddress,
+					TransactionID: locked.TransactionID,
+					PurchasedAt:   event.TransferredAt,
+				}
+				if err := tx.Create(&next).Error; err != nil {
+					return err
+				}
+				current = &next
+				event.PurchaseID = next.ID
+			}
+		}
+
+		// Bản ghi đã bị thu hồi từ trước và người giữ token không nhận thêm quyền nào: không có gì thay đổi
+		if !active && event.PurchaseID == 0 {
+			return nil
+		}
+		return outbox.Enqueue(tx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return current, nil
+}
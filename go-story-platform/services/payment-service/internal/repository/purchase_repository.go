@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"errors"
+	"payment-service/internal/models"
+	"time"
+
+	"shared/events"
+	"shared/outbox"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PurchaseRepository quản lý việc mua truyện/chương và quyền sở hữu (bảng purchased_books)
+type PurchaseRepository struct {
+	db *gorm.DB
+}
+
+// NewPurchaseRepository tạo PurchaseRepository với kết nối DB được truyền vào
+func NewPurchaseRepository(db *gorm.DB) *PurchaseRepository {
+	return &PurchaseRepository{db: db}
+}
+
+// Purchase trừ tiền ví, ghi nhận quyền sở hữu và event ChapterPurchased trong một DB transaction.
+// chapterID = 0 nghĩa là mua trọn bộ truyện. Khi số dư không đủ, một giao dịch failed
+// vẫn được lưu lại để hiển thị trong lịch sử và trả về ErrInsufficientBalance.
+func (r *PurchaseRepository) Purchase(userID, bookID, chapterID uint, price int) (*models.PurchasedBook, error) {
+	var purchase models.PurchasedBook
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Khóa ví trước khi kiểm tra sở hữu và số dư để hai lần mua đồng thời không cùng vượt qua
+		if err := lockWallet(tx, userID); err != nil {
+			return err
+		}
+
+		owned, err := hasPurchase(tx, userID, bookID, chapterID)
+		if err != nil {
+			return err
+		}
+		if owned {
+			return ErrAlreadyOwned
+		}
+
+		balance, err := accountBalance(tx, models.UserAccount(userID))
+		if err != nil {
+			return err
+		}
+		if balance < price {
+			return ErrInsufficientBalance
+		}
+
+		txn := models.Transaction{
+			UserID:    userID,
+			Amount:    price,
+			Type:      models.TransactionTypePurchase,
+			Status:    models.TransactionStatusPending,
+			BookID:    bookID,
+			ChapterID: chapterID,
+		}
+		if err := tx.Create(&txn).Error; err != nil {
+			return err
+		}
+		if err := postEntries(tx, &txn, []models.LedgerEntry{
+			{Account: models.UserAccount(userID), Amount: -price},
+			{Account: models.AccountRevenue, Amount: price},
+		}); err != nil {
+			return err
+		}
+		if err := refreshWalletBalance(tx, userID); err != nil {
+			return err
+		}
+
+		purchase = models.PurchasedBook{
+			UserID:        userID,
+			BookID:        bookID,
+			ChapterID:     chapterID,
+			TransactionID: txn.ID,
+			PurchasedAt:   time.Now(),
+		}
+		if err := tx.Create(&purchase).Error; err != nil {
+			return err
+		}
+		return outbox.Enqueue(tx, events.ChapterPurchased{
+			PurchaseID:    purchase.ID,
+			TransactionID: txn.ID,
+			UserID:        userID,
+			BookID:        bookID,
+			ChapterID:     chapterID,
+			Price:         price,
+			PurchasedAt:   purchase.PurchasedAt,
+		})
+	})
+
+	if errors.Is(err, ErrInsufficientBalance) {
+		// Ví tạo trong transaction ở trên đã bị rollback, mà transactions.user_id có khóa
+		// ngoại tới user_wallets nên cần đảm bảo ví tồn tại trước
+		if createErr := r.db.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.UserWallet{UserID: userID}).Error; createErr != nil {
+			return nil, createErr
+		}
+		failed := models.Transaction{
+			UserID:        userID,
+			Amount:        price,
+			Type:          models.TransactionTypePurchase,
+			Status:        models.TransactionStatusFailed,
+			BookID:        bookID,
+			ChapterID:     chapterID,
+			FailureReason: ErrInsufficientBalance.Error(),
+		}
+		if createErr := r.db.Create(&failed).Error; createErr != nil {
+			return nil, createErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &purchase, nil
+}
+
+// ListPurchases lấy các truyện/chương user đã mua, mới nhất trước
+func (r *PurchaseRepository) ListPurchases(userID uint) ([]models.PurchasedBook, error) {
+	var purchases []models.PurchasedBook
+	err := r.db.Where("user_id = ?", userID).
+		Order("purchased_at DESC").
+		Find(&purchases).Error
+	return purchases, err
+}
+
+// FindEntitlement tìm bản ghi mua cho phép user đọc nội dung: ưu tiên mua trọn bộ,
+// sau đó tới mua lẻ chương (khi chapterID khác 0). Trả về nil nếu user chưa mua.
+func (r *PurchaseRepository) FindEntitlement(userID, bookID, chapterID uint) (*models.PurchasedBook, error) {
+	query := r.db.Where("user_id = ? AND book_id = ?", userID, bookID)
+	if chapterID == 0 {
+		query = query.Where("chapter_id = 0")
+	} else {
+		query = query.Where("chapter_id IN (0, ?)", chapterID)
+	}
+
+	var purchase models.PurchasedBook
+	err := query.Order("chapter_id ASC").First(&purchase).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &purchase, nil
+}
+
+// hasPurchase kiểm tra user đã sở hữu nội dung chưa. Mua trọn bộ (chapter_id = 0)
+// bao gồm mọi chương nên cũng được tính khi kiểm tra một chương.
+func hasPurchase(db *gorm.DB, userID, bookID, chapterID uint) (bool, error) {
+	query := db.Model(&models.PurchasedBook{}).
+		Where("user_id = ? AND book_id = ?", userID, bookID)
+	if chapterID == 0 {
+		query = query.Where("chapter_id = 0")
+	} else {
+		query = query.Where("chapter_id IN (0, ?)", chapterID)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,72 @@
+// Package grpc phục vụ API gRPC nội bộ của payment-service cho các service khác
+// (hợp đồng ở shared/rpc/paymentpb)
+package grpc
+
+import (
+	"context"
+	"payment-service/internal/repository"
+	"payment-service/internal/transport/http/dto"
+
+	"shared/rpc/paymentpb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PaymentServer implement paymentpb.PaymentServiceServer
+type PaymentServer struct {
+	paymentpb.UnimplementedPaymentServiceServer
+	ledger    *repository.LedgerRepository
+	purchases *repository.PurchaseRepository
+}
+
+// NewPaymentServer tạo PaymentServer với các repo được truyền vào
+func NewPaymentServer(ledger *repository.LedgerRepository, purchases *repository.PurchaseRepository) *PaymentServer {
+	return &PaymentServer{ledger: ledger, purchases: purchases}
+}
+
+// CheckEntitlement cho biết user đã mua truyện hoặc chương chưa, cùng quy tắc với GET /entitlements
+func (s *PaymentServer) CheckEntitlement(ctx context.Context, req *paymentpb.CheckEntitlementRequest) (*paymentpb.Entitlement, error) {
+	if req.GetUserId() == 0 || req.GetBookId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id và book_id không được để trống")
+	}
+
+	purchase, err := s.purchases.FindEntitlement(uint(req.GetUserId()), uint(req.GetBookId()), uint(req.GetChapterId()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Lỗi server")
+	}
+
+	resp := &paymentpb.Entitlement{}
+	if purchase != nil {
+		resp.Allowed = true
+		resp.Source = dto.EntitlementSourceBook
+		if purchase.ChapterID != 0 {
+			resp.Source = dto.EntitlementSourceChapter
+		}
+		resp.PurchaseId = uint64(purchase.ID)
+		resp.TransactionId = uint64(purchase.TransactionID)
+	}
+	return resp, nil
+}
+
+// GetBalance lấy số dư ví của user, tự tạo ví rỗng nếu user chưa có ví
+func (s *PaymentServer) GetBalance(ctx context.Context, req *paymentpb.GetBalanceRequest) (*paymentpb.Balance, error) {
+	if req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id không được để trống")
+	}
+
+	wallet, err := s.ledger.GetOrCreateWallet(uint(req.GetUserId()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Lỗi server")
+	}
+	balance, err := s.ledger.GetBalance(wallet.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Lỗi server")
+	}
+
+	return &paymentpb.Balance{
+		UserId:        uint64(wallet.UserID),
+		Balance:       int64(balance),
+		WalletAddress: wallet.WalletAddress,
+	}, nil
+}
@@ -0,0 +1,17 @@
+package dto
+
+// Nguồn cấp quyền đọc trong EntitlementResponse.Source
+const (
+	EntitlementSourceBook    = "book_purchase"
+	EntitlementSourceChapter = "chapter_purchase"
+)
+
+type EntitlementResponse struct {
+	UserID        uint   `json:"user_id"`
+	BookID        uint   `json:"book_id"`
+	ChapterID     uint   `json:"chapter_id,omitempty"`
+	Allowed       bool   `json:"allowed"`
+	Source        string `json:"source,omitempty"` // Rỗng khi Allowed = false
+	PurchaseID    uint   `json:"purchase_id,omitempty"`
+	TransactionID uint   `json:"transaction_id,omitempty"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+// NFTAttribute là một thuộc tính trong metadata ERC-721
+type NFTAttribute struct {
+	TraitType string      `json:"trait_type"`
+	Value     interface{} `json:"value"`
+}
+
+// NFTMetadataResponse là metadata của token sở hữu theo chuẩn ERC-721 Metadata JSON Schema,
+// trả về trực tiếp (không bọc ApiResponse) để ví và sàn NFT đọc được
+type NFTMetadataResponse struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	ExternalURL string         `json:"external_url"` // Truyện/chương trên content-service
+	Attributes  []NFTAttribute `json:"attributes"`
+}
+
+// NFTOwnershipResponse là kết quả đối soát chủ sở hữu token trên chain với bảng purchased_books
+type NFTOwnershipResponse struct {
+	TokenID      string `json:"token_id"`
+	OwnerAddress string `json:"owner_address"`            // Ví đang giữ token trên chain
+	HolderUserID uint   `json:"holder_user_id,omitempty"` // User đã liên kết ví đó, 0 nếu chưa có
+	PurchaseID   uint   `json:"purchase_id,omitempty"`    // Bản ghi mua đang có hiệu lực của token
+	BookID       uint   `json:"book_id"`
+	ChapterID    uint   `json:"chapter_id,omitempty"`
+	Transferred  bool   `json:"transferred"` // Quyền đọc vừa được chuyển sang người giữ token
+}
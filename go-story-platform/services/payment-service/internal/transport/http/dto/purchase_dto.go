@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+type PurchaseBookRequest struct {
+	BookID uint `json:"book_id"`
+}
+
+type PurchaseChapterRequest struct {
+	BookID    uint `json:"book_id"`
+	ChapterID uint `json:"chapter_id"`
+}
+
+type PurchaseResponse struct {
+	ID            uint      `json:"id"`
+	BookID        uint      `json:"book_id"`
+	ChapterID     uint      `json:"chapter_id,omitempty"` // 0 = mua trọn bộ
+	TransactionID uint      `json:"transaction_id"`
+	NFTTokenID    string    `json:"nft_token_id,omitempty"`
+	NFTStatus     string    `json:"nft_status"`              // pending, minting, minted, failed
+	OwnerAddress  string    `json:"owner_address,omitempty"` // Ví đang giữ token
+	PurchasedAt   time.Time `json:"purchased_at"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+type WalletResponse struct {
+	UserID        uint       `json:"user_id"`
+	Balance       int        `json:"balance"`                  // Số dư tính từ sổ cái
+	WalletAddress string     `json:"wallet_address,omitempty"` // Ví nhận tiền nạp on-chain
+	LastSyncAt    *time.Time `json:"last_sync_at,omitempty"`   // Lần cuối quét chain cho ví này
+}
+
+type DepositRequest struct {
+	Amount int    `json:"amount"`
+	TxHash string `json:"tx_hash"` // Hash giao dịch on-chain nếu nạp bằng blockchain
+}
+
+type FailTransactionRequest struct {
+	Reason string `json:"reason"`
+}
+
+type TransactionResponse struct {
+	ID            uint       `json:"id"`
+	Type          string     `json:"type"`
+	Status        string     `json:"status"`
+	Amount        int        `json:"amount"`
+	TxHash        string     `json:"tx_hash,omitempty"`
+	BlockNumber   uint64     `json:"block_number,omitempty"`
+	BookID        uint       `json:"book_id,omitempty"`
+	ChapterID     uint       `json:"chapter_id,omitempty"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ConfirmedAt   *time.Time `json:"confirmed_at,omitempty"`
+}
+
+type TransactionListResponse struct {
+	Items []TransactionResponse `json:"items"`
+	Total int64                 `json:"total"`
+	Page  int                   `json:"page"`
+	Limit int                   `json:"limit"`
+}
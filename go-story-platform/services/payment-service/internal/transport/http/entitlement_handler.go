@@ -0,0 +1,96 @@
+package http
+
+import (
+	"net/http"
+	"payment-service/internal/repository"
+	"payment-service/internal/transport/http/dto"
+	"payment-service/internal/transport/http/middleware"
+	"payment-service/pkg/auth"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EntitlementHandler trả lời câu hỏi "user U có được đọc chương C của truyện B không?"
+// dựa trên bảng purchased_books. Content-service gọi API này trước khi trả link nội dung
+// của chương premium.
+type EntitlementHandler struct {
+	purchases *repository.PurchaseRepository
+}
+
+// NewEntitlementHandler tạo EntitlementHandler với repo được truyền vào
+func NewEntitlementHandler(purchases *repository.PurchaseRepository) *EntitlementHandler {
+	return &EntitlementHandler{purchases: purchases}
+}
+
+// CheckEntitlement godoc
+// @Summary Kiểm tra quyền đọc truyện/chương
+// @Description Mặc định kiểm tra cho user đang đăng nhập. Truyền user_id của người khác cần quyền admin.
+// @Description Chỉ phản ánh việc đã mua; truyện/chương miễn phí do content-service tự quyết định.
+// @Tags Entitlements
+// @Produce json
+// @Security BearerAuth
+// @Param book_id query int true "Book ID"
+// @Param chapter_id query int false "Chapter ID, bỏ trống để kiểm tra quyền trọn bộ"
+// @Param user_id query int false "User ID (mặc định là user trong token)"
+// @Success 200 {object} dto.ApiResponse{data=dto.EntitlementResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Router /entitlements [get]
+func (h *EntitlementHandler) CheckEntitlement(c *gin.Context) {
+	bookID, err := strconv.ParseUint(c.Query("book_id"), 10, 64)
+	if err != nil || bookID == 0 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "book_id không hợp lệ"})
+		return
+	}
+
+	var chapterID uint64
+	if raw := c.Query("chapter_id"); raw != "" {
+		chapterID, err = strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "chapter_id không hợp lệ"})
+			return
+		}
+	}
+
+	userID := middleware.CurrentUserID(c)
+	if raw := c.Query("user_id"); raw != "" {
+		requested, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "user_id không hợp lệ"})
+			return
+		}
+		if uint(requested) != userID && !auth.HasPermission(c.GetString("userRole"), auth.PermEntitlementCheckAny) {
+			c.JSON(http.StatusForbidden, dto.ApiResponse{Success: false, Message: "Bạn không có quyền thực hiện thao tác này"})
+			return
+		}
+		userID = uint(requested)
+	}
+
+	purchase, err := h.purchases.FindEntitlement(userID, uint(bookID), uint(chapterID))
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+
+	data := dto.EntitlementResponse{
+		UserID:    userID,
+		BookID:    uint(bookID),
+		ChapterID: uint(chapterID),
+	}
+	if purchase != nil {
+		data.Allowed = true
+		data.Source = dto.EntitlementSourceBook
+		if purchase.ChapterID != 0 {
+			data.Source = dto.EntitlementSourceChapter
+		}
+		data.PurchaseID = purchase.ID
+		data.TransactionID = purchase.TransactionID
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    data,
+	})
+}
@@ -0,0 +1,17 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"payment-service/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serverError ghi lỗi nội bộ err vào log (kèm request_id, route và user_id của request) rồi trả 500
+// với message an toàn cho client. Chi tiết lỗi (câu SQL, email trong lỗi unique...) chỉ nằm trong log.
+func serverError(c *gin.Context, err error, message string) {
+	slog.ErrorContext(c.Request.Context(), message, "error", err)
+	c.JSON(http.StatusInternalServerError, dto.ApiResponse{Success: false, Message: message})
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"payment-service/internal/transport/http/dto"
+	"payment-service/pkg/auth"
+
+	jwtauth "shared/auth"
+	"shared/identity"
+	"shared/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthMiddleware xác thực access token do user-service cấp và lưu userID, userRole vào context.
+// Request đi qua API gateway được nhận bằng header danh tính do gateway ký; gateway nil thì luôn kiểm JWT.
+func AuthMiddleware(verifier *jwtauth.Verifier, gateway *identity.Signer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Lấy header Authorization: Bearer <token>
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ApiResponse{
+				Success: false,
+				Message: "Bạn cần đăng nhập để truy cập",
+			})
+			return
+		}
+		// Tách chuỗi để lấy token
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ApiResponse{
+				Success: false,
+				Message: "Định dạng Token phải là 'Bearer <token>'",
+			})
+			return
+		}
+		// Request đi qua API gateway: token đã được kiểm ở gateway, chỉ cần kiểm chữ ký header danh tính
+		gatewayIdentity, err := gateway.Verify(c.Request)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ApiResponse{
+				Success: false,
+				Message: "Danh tính từ gateway không hợp lệ",
+			})
+			return
+		}
+		if gatewayIdentity != nil {
+			logging.SetUserID(c.Request.Context(), gatewayIdentity.UserID)
+			c.Set("userID", gatewayIdentity.UserID)
+			c.Set("userRole", gatewayIdentity.Role)
+			c.Next()
+			return
+		}
+		//Gọi service để xác thực
+		claims, err := verifier.Verify(parts[1])
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ApiResponse{
+				Success: false,
+				Message: "Token không hợp lệ hoặc đã hết hạn",
+			})
+			return
+		}
+		// Lưu thông tin user vào context để các handler khác sử dụng, và gắn user vào log của request
+		logging.SetUserID(c.Request.Context(), claims.UserID)
+		c.Set("userID", claims.UserID)
+		c.Set("userRole", claims.Role)
+
+		c.Next()
+	}
+}
+
+// RequirePermission chặn request bằng 403 nếu vai trò của user không có quyền perm.
+// Phải đặt sau AuthMiddleware.
+func RequirePermission(perm auth.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !auth.HasPermission(c.GetString("userRole"), perm) {
+			c.AbortWithStatusJSON(http.StatusForbidden, dto.ApiResponse{
+				Success: false,
+				Message: "Bạn không có quyền thực hiện thao tác này",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// CurrentUserID lấy ID của user đang đăng nhập do AuthMiddleware lưu vào context
+func CurrentUserID(c *gin.Context) uint {
+	if v, exists := c.Get("userID"); exists {
+		if id, ok := v.(uint); ok {
+			return id
+		}
+	}
+	return 0
+}
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"payment-service/internal/catalog"
+	"payment-service/internal/chain"
+	"payment-service/internal/repository"
+	"payment-service/internal/transport/http/dto"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NFTHandler phục vụ metadata của token sở hữu và đối soát chủ token trên chain
+type NFTHandler struct {
+	nfts    *repository.NFTRepository
+	chain   chain.ChainClient
+	catalog catalog.Catalog
+}
+
+// NewNFTHandler tạo NFTHandler với repo, chain client và catalog được truyền vào
+func NewNFTHandler(nfts *repository.NFTRepository, chainClient chain.ChainClient, catalog catalog.Catalog) *NFTHandler {
+	return &NFTHandler{nfts: nfts, chain: chainClient, catalog: catalog}
+}
+
+// Metadata godoc
+// @Summary Metadata của token sở hữu
+// @Description tokenURI của token trỏ tới endpoint này. Trả về JSON theo chuẩn ERC-721 Metadata
+// @Description (không bọc ApiResponse) mô tả truyện/chương đã mua.
+// @Tags NFT
+// @Produce json
+// @Param purchase_id path int true "ID lần mua gốc"
+// @Success 200 {object} dto.NFTMetadataResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Failure 502 {object} dto.ApiResponse
+// @Router /nfts/metadata/{purchase_id} [get]
+func (h *NFTHandler) Metadata(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("purchase_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "ID không hợp lệ"})
+		return
+	}
+
+	purchase, err := h.nfts.GetPurchaseByID(uint(id))
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	if purchase == nil {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Token không tồn tại"})
+		return
+	}
+
+	book, err := h.catalog.GetBook(c.Request.Context(), purchase.BookID)
+	if err != nil {
+		writeCatalogError(c, err)
+		return
+	}
+
+	metadata := dto.NFTMetadataResponse{
+		Name:        book.Title,
+		Description: fmt.Sprintf("Quyền đọc trọn bộ truyện \"%s\"", book.Title),
+		ExternalURL: h.catalog.ResourceURL(book.ID, 0),
+		Attributes: []dto.NFTAttribute{
+			{TraitType: "book_id", Value: book.ID},
+			{TraitType: "purchase_id", Value: purchase.ID},
+		},
+	}
+	if purchase.ChapterID != 0 {
+		chapter, err := h.catalog.GetChapter(c.Request.Context(), purchase.BookID, purchase.ChapterID)
+		if err != nil {
+			writeCatalogError(c, err)
+			return
+		}
+		metadata.Name = fmt.Sprintf("%s - Chương %d", book.Title, chapter.ChapterNumber)
+		metadata.Description = fmt.Sprintf("Quyền đọc chương %d của truyện \"%s\"", chapter.ChapterNumber, book.Title)
+		metadata.ExternalURL = h.catalog.ResourceURL(book.ID, chapter.ChapterNumber)
+		metadata.Attributes = append(metadata.Attributes,
+			dto.NFTAttribute{TraitType: "chapter_id", Value: chapter.ID},
+			dto.NFTAttribute{TraitType: "chapter_number", Value: chapter.ChapterNumber},
+		)
+	}
+
+	c.JSON(http.StatusOK, metadata)
+}
+
+// VerifyOwnership godoc
+// @Summary Đối soát chủ sở hữu token
+// @Description Đọc chủ hiện tại của token trên chain và cập nhật bảng sở hữu: nếu token đã được bán
+// @Description sang ví khác, quyền đọc chuyển cho tài khoản đã liên kết ví đó và người bán mất quyền.
+// @Tags NFT
+// @Produce json
+// @Security BearerAuth
+// @Param token_id path string true "Token ID (thập phân)"
+// @Success 200 {object} dto.ApiResponse{data=dto.NFTOwnershipResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Failure 502 {object} dto.ApiResponse
+// @Router /nfts/{token_id}/verify [post]
+func (h *NFTHandler) VerifyOwnership(c *gin.Context) {
+	tokenID, ok := new(big.Int).SetString(c.Param("token_id"), 10)
+	if !ok || tokenID.Sign() < 0 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Token ID không hợp lệ"})
+		return
+	}
+
+	purchase, err := h.nfts.GetPurchaseByTokenID(tokenID.String())
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	if purchase == nil {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Token không do hệ thống phát hành"})
+		return
+	}
+
+	owner, err := h.chain.OwnerOf(c.Request.Context(), tokenID)
+	if errors.Is(err, chain.ErrNotFound) {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Token không còn tồn tại trên chain"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadGateway, dto.ApiResponse{Success: false, Message: "Không thể đọc dữ liệu từ blockchain"})
+		return
+	}
+
+	holderID, err := h.nfts.FindWalletOwner(owner)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	current, err := h.nfts.TransferOwnership(purchase, holderID, owner)
+	if err != nil {
+		serverError(c, err, "Không thể cập nhật quyền sở hữu")
+		return
+	}
+
+	// Bản ghi đã bị thu hồi từ lần đối soát trước và vẫn chưa ai nhận thì không tính là vừa chuyển
+	transferred := current == nil || current.ID != purchase.ID
+	if purchase.DeletedAt.Valid && current == nil {
+		transferred = false
+	}
+
+	resp := dto.NFTOwnershipResponse{
+		TokenID:      tokenID.String(),
+		OwnerAddress: owner,
+		HolderUserID: holderID,
+		BookID:       purchase.BookID,
+		ChapterID:    purchase.ChapterID,
+		Transferred:  transferred,
+	}
+	if current != nil {
+		resp.PurchaseID = current.ID
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Đối soát thành công",
+		Data:    resp,
+	})
+}
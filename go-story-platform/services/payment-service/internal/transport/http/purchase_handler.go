@@ -0,0 +1,165 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"payment-service/internal/catalog"
+	"payment-service/internal/models"
+	"payment-service/internal/repository"
+	"payment-service/internal/transport/http/dto"
+	"payment-service/internal/transport/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PurchaseHandler xử lý việc mua truyện/chương bằng xu trong ví
+type PurchaseHandler struct {
+	purchases *repository.PurchaseRepository
+	catalog   catalog.Catalog
+}
+
+// NewPurchaseHandler tạo PurchaseHandler với repo và catalog được truyền vào
+func NewPurchaseHandler(purchases *repository.PurchaseRepository, catalog catalog.Catalog) *PurchaseHandler {
+	return &PurchaseHandler{purchases: purchases, catalog: catalog}
+}
+
+// PurchaseBook godoc
+// @Summary Mua trọn bộ truyện
+// @Description Trừ xu theo giá truyện lấy từ content-service và ghi nhận quyền sở hữu
+// @Tags Purchases
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body dto.PurchaseBookRequest true "Truyện cần mua"
+// @Success 201 {object} dto.ApiResponse{data=dto.PurchaseResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 402 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Failure 409 {object} dto.ApiResponse
+// @Router /purchases/books [post]
+func (h *PurchaseHandler) PurchaseBook(c *gin.Context) {
+	var input dto.PurchaseBookRequest
+	if err := c.ShouldBindJSON(&input); err != nil || input.BookID == 0 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+
+	book, err := h.catalog.GetBook(c.Request.Context(), input.BookID)
+	if err != nil {
+		writeCatalogError(c, err)
+		return
+	}
+	if !book.IsPremium || book.Price <= 0 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Truyện miễn phí, không cần mua"})
+		return
+	}
+
+	h.purchase(c, input.BookID, 0, book.Price)
+}
+
+// PurchaseChapter godoc
+// @Summary Mua lẻ một chương
+// @Description Trừ xu theo giá chương lấy từ content-service và ghi nhận quyền sở hữu chương
+// @Tags Purchases
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body dto.PurchaseChapterRequest true "Chương cần mua"
+// @Success 201 {object} dto.ApiResponse{data=dto.PurchaseResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 402 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Failure 409 {object} dto.ApiResponse
+// @Router /purchases/chapters [post]
+func (h *PurchaseHandler) PurchaseChapter(c *gin.Context) {
+	var input dto.PurchaseChapterRequest
+	if err := c.ShouldBindJSON(&input); err != nil || input.BookID == 0 || input.ChapterID == 0 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+
+	chapter, err := h.catalog.GetChapter(c.Request.Context(), input.BookID, input.ChapterID)
+	if err != nil {
+		writeCatalogError(c, err)
+		return
+	}
+	if chapter.Price <= 0 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Chương này không bán lẻ"})
+		return
+	}
+
+	h.purchase(c, input.BookID, input.ChapterID, chapter.Price)
+}
+
+// ListPurchases godoc
+// @Summary Danh sách nội dung đã mua
+// @Tags Purchases
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.ApiResponse{data=[]dto.PurchaseResponse}
+// @Router /purchases [get]
+func (h *PurchaseHandler) ListPurchases(c *gin.Context) {
+	purchases, err := h.purchases.ListPurchases(middleware.CurrentUserID(c))
+	if err != nil {
+		serverError(c, err, "Không thể lấy danh sách")
+		return
+	}
+
+	items := make([]dto.PurchaseResponse, 0, len(purchases))
+	for i := range purchases {
+		items = append(items, toPurchaseResponse(&purchases[i]))
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    items,
+	})
+}
+
+// purchase thực hiện giao dịch mua và ghi response tương ứng
+func (h *PurchaseHandler) purchase(c *gin.Context, bookID, chapterID uint, price int) {
+	purchase, err := h.purchases.Purchase(middleware.CurrentUserID(c), bookID, chapterID, price)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrInsufficientBalance):
+			c.JSON(http.StatusPaymentRequired, dto.ApiResponse{Success: false, Message: "Số dư không đủ, vui lòng nạp thêm xu"})
+		case errors.Is(err, repository.ErrAlreadyOwned):
+			c.JSON(http.StatusConflict, dto.ApiResponse{Success: false, Message: "Bạn đã sở hữu nội dung này"})
+		default:
+			serverError(c, err, "Không thể thực hiện giao dịch")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Mua thành công",
+		Data:    toPurchaseResponse(purchase),
+	})
+}
+
+// writeCatalogError ghi response lỗi khi tra cứu giá ở content-service thất bại
+func writeCatalogError(c *gin.Context, err error) {
+	if errors.Is(err, catalog.ErrNotFound) {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Nội dung không tồn tại"})
+		return
+	}
+	slog.ErrorContext(c.Request.Context(), "Không lấy được thông tin giá từ content-service", "error", err)
+	c.JSON(http.StatusBadGateway, dto.ApiResponse{Success: false, Message: "Không thể lấy thông tin giá"})
+}
+
+// toPurchaseResponse chuyển models.PurchasedBook sang DTO trả về cho client
+func toPurchaseResponse(p *models.PurchasedBook) dto.PurchaseResponse {
+	return dto.PurchaseResponse{
+		ID:            p.ID,
+		BookID:        p.BookID,
+		ChapterID:     p.ChapterID,
+		TransactionID: p.TransactionID,
+		NFTTokenID:    p.NFTTokenID,
+		NFTStatus:     p.NFTStatus,
+		OwnerAddress:  p.OwnerAddress,
+		PurchasedAt:   p.PurchasedAt,
+	}
+}
@@ -0,0 +1,255 @@
+// Package http cho ví, giao dịch và mua nội dung trong payment-service
+package http
+
+import (
+	"errors"
+	"net/http"
+	"payment-service/internal/models"
+	"payment-service/internal/repository"
+	"payment-service/internal/transport/http/dto"
+	"payment-service/internal/transport/http/middleware"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WalletHandler xử lý các request liên quan đến ví và giao dịch
+type WalletHandler struct {
+	ledger *repository.LedgerRepository
+}
+
+// NewWalletHandler tạo WalletHandler với repo được truyền vào
+func NewWalletHandler(ledger *repository.LedgerRepository) *WalletHandler {
+	return &WalletHandler{ledger: ledger}
+}
+
+// GetWallet godoc
+// @Summary Xem số dư ví
+// @Description Số dư được tính từ tổng các bút toán trong sổ cái của user đang đăng nhập
+// @Tags Wallet
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.ApiResponse{data=dto.WalletResponse}
+// @Router /wallet [get]
+func (h *WalletHandler) GetWallet(c *gin.Context) {
+	userID := middleware.CurrentUserID(c)
+	wallet, err := h.ledger.GetOrCreateWallet(userID)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+
+	balance, err := h.ledger.GetBalance(userID)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    toWalletResponse(wallet, balance),
+	})
+}
+
+// ListTransactions godoc
+// @Summary Lịch sử giao dịch
+// @Tags Wallet
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Trang (mặc định 1)"
+// @Param limit query int false "Số bản ghi mỗi trang (mặc định 20, tối đa 100)"
+// @Success 200 {object} dto.ApiResponse{data=dto.TransactionListResponse}
+// @Router /wallet/transactions [get]
+func (h *WalletHandler) ListTransactions(c *gin.Context) {
+	page, limit := parsePagination(c)
+	txns, total, err := h.ledger.ListTransactions(middleware.CurrentUserID(c), page, limit)
+	if err != nil {
+		serverError(c, err, "Không thể lấy lịch sử giao dịch")
+		return
+	}
+
+	items := make([]dto.TransactionResponse, 0, len(txns))
+	for i := range txns {
+		items = append(items, toTransactionResponse(&txns[i]))
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data: dto.TransactionListResponse{
+			Items: items,
+			Total: total,
+			Page:  page,
+			Limit: limit,
+		},
+	})
+}
+
+// CreateDeposit godoc
+// @Summary Tạo yêu cầu nạp tiền
+// @Description Tạo giao dịch Deposit ở trạng thái pending; số dư chỉ tăng sau khi giao dịch được xác nhận.
+// @Description Tiền chuyển tới ví đã liên kết được deposit indexer tự ghi nhận, không cần gọi API này.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body dto.DepositRequest true "Số xu cần nạp"
+// @Success 201 {object} dto.ApiResponse{data=dto.TransactionResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 409 {object} dto.ApiResponse
+// @Router /wallet/deposits [post]
+func (h *WalletHandler) CreateDeposit(c *gin.Context) {
+	var input dto.DepositRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
+		return
+	}
+	if input.Amount <= 0 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Số tiền nạp phải lớn hơn 0"})
+		return
+	}
+
+	txn, err := h.ledger.CreateDeposit(middleware.CurrentUserID(c), input.Amount, input.TxHash)
+	if errors.Is(err, repository.ErrDuplicateDeposit) {
+		c.JSON(http.StatusConflict, dto.ApiResponse{Success: false, Message: err.Error()})
+		return
+	}
+	if err != nil {
+		serverError(c, err, "Không thể tạo giao dịch")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiResponse{
+		Success: true,
+		Message: "Đã tạo yêu cầu nạp tiền",
+		Data:    toTransactionResponse(txn),
+	})
+}
+
+// ConfirmTransaction godoc
+// @Summary Xác nhận giao dịch nạp tiền
+// @Description Chỉ admin. Chuyển giao dịch pending sang confirmed và ghi bút toán vào sổ cái.
+// @Tags Transactions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transaction ID"
+// @Success 200 {object} dto.ApiResponse{data=dto.TransactionResponse}
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Failure 409 {object} dto.ApiResponse
+// @Router /transactions/{id}/confirm [post]
+func (h *WalletHandler) ConfirmTransaction(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "ID không hợp lệ"})
+		return
+	}
+
+	txn, err := h.ledger.ConfirmTransaction(uint(id))
+	if err != nil {
+		writeTransitionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Đã xác nhận giao dịch",
+		Data:    toTransactionResponse(txn),
+	})
+}
+
+// FailTransaction godoc
+// @Summary Hủy giao dịch đang chờ
+// @Description Chỉ admin. Chuyển giao dịch pending sang failed, không ghi bút toán.
+// @Tags Transactions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transaction ID"
+// @Param body body dto.FailTransactionRequest false "Lý do"
+// @Success 200 {object} dto.ApiResponse{data=dto.TransactionResponse}
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Failure 409 {object} dto.ApiResponse
+// @Router /transactions/{id}/fail [post]
+func (h *WalletHandler) FailTransaction(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "ID không hợp lệ"})
+		return
+	}
+
+	var input dto.FailTransactionRequest
+	_ = c.ShouldBindJSON(&input)
+
+	txn, err := h.ledger.FailTransaction(uint(id), input.Reason)
+	if err != nil {
+		writeTransitionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Đã hủy giao dịch",
+		Data:    toTransactionResponse(txn),
+	})
+}
+
+// writeTransitionError ghi response lỗi khi đổi trạng thái giao dịch thất bại
+func writeTransitionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTransactionNotFound):
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: err.Error()})
+	case errors.Is(err, repository.ErrInvalidTransition), errors.Is(err, repository.ErrDuplicateDeposit):
+		c.JSON(http.StatusConflict, dto.ApiResponse{Success: false, Message: err.Error()})
+	default:
+		serverError(c, err, "Lỗi server")
+	}
+}
+
+// parsePagination đọc page/limit từ query, mặc định trang 1, 20 bản ghi, tối đa 100
+func parsePagination(c *gin.Context) (page, limit int) {
+	page, _ = strconv.Atoi(c.Query("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ = strconv.Atoi(c.Query("limit"))
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return page, limit
+}
+
+// toTransactionResponse chuyển models.Transaction sang DTO trả về cho client
+func toTransactionResponse(txn *models.Transaction) dto.TransactionResponse {
+	return dto.TransactionResponse{
+		ID:            txn.ID,
+		Type:          txn.Type,
+		Status:        txn.Status,
+		Amount:        txn.Amount,
+		TxHash:        txn.TxHash,
+		BlockNumber:   txn.BlockNumber,
+		BookID:        txn.BookID,
+		ChapterID:     txn.ChapterID,
+		FailureReason: txn.FailureReason,
+		CreatedAt:     txn.CreatedAt,
+		ConfirmedAt:   txn.ConfirmedAt,
+	}
+}
+
+// toWalletResponse chuyển models.UserWallet cùng số dư tính từ sổ cái sang DTO
+func toWalletResponse(wallet *models.UserWallet, balance int) dto.WalletResponse {
+	resp := dto.WalletResponse{
+		UserID:        wallet.UserID,
+		Balance:       balance,
+		WalletAddress: wallet.WalletAddress,
+	}
+	if !wallet.LastSyncAt.IsZero() {
+		resp.LastSyncAt = &wallet.LastSyncAt
+	}
+	return resp
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS processed_events;
+DROP TABLE IF EXISTS outbox_messages;
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS purchased_books;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS user_wallets;
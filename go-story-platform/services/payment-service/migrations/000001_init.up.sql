@@ -0,0 +1,83 @@
+-- Schema ban đầu, giống với những gì AutoMigrate đã tạo trước đây.
+-- Dùng IF NOT EXISTS để database cũ (tạo bằng AutoMigrate) có thể chuyển sang migration.
+CREATE TABLE IF NOT EXISTS user_wallets (
+    user_id          BIGINT PRIMARY KEY,
+    balance_internal BIGINT DEFAULT 0,
+    last_sync_at     TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ,
+    deleted_at       TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_user_wallets_deleted_at ON user_wallets (deleted_at);
+
+CREATE TABLE IF NOT EXISTS transactions (
+    id             BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT,
+    amount         BIGINT NOT NULL,
+    type           VARCHAR(20),
+    status         VARCHAR(20) DEFAULT 'pending',
+    tx_hash        TEXT,
+    book_id        BIGINT,
+    chapter_id     BIGINT,
+    failure_reason TEXT,
+    confirmed_at   TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ,
+    deleted_at     TIMESTAMPTZ,
+    CONSTRAINT fk_user_wallets_transactions FOREIGN KEY (user_id) REFERENCES user_wallets (user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_id ON transactions (user_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_tx_hash ON transactions (tx_hash);
+CREATE INDEX IF NOT EXISTS idx_transactions_book_id ON transactions (book_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_chapter_id ON transactions (chapter_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_deleted_at ON transactions (deleted_at);
+
+CREATE TABLE IF NOT EXISTS purchased_books (
+    id             BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT,
+    book_id        BIGINT,
+    chapter_id     BIGINT,
+    nft_token_id   TEXT,
+    transaction_id BIGINT,
+    purchased_at   TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ,
+    deleted_at     TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_purchased_books_user_id ON purchased_books (user_id);
+CREATE INDEX IF NOT EXISTS idx_purchased_books_book_id ON purchased_books (book_id);
+CREATE INDEX IF NOT EXISTS idx_purchased_books_chapter_id ON purchased_books (chapter_id);
+CREATE INDEX IF NOT EXISTS idx_purchased_books_nft_token_id ON purchased_books (nft_token_id);
+CREATE INDEX IF NOT EXISTS idx_purchased_books_transaction_id ON purchased_books (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_purchased_books_deleted_at ON purchased_books (deleted_at);
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id             BIGSERIAL PRIMARY KEY,
+    transaction_id BIGINT NOT NULL,
+    account        VARCHAR(64) NOT NULL,
+    amount         BIGINT NOT NULL,
+    created_at     TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction_id ON ledger_entries (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_account ON ledger_entries (account);
+
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    id           BIGSERIAL PRIMARY KEY,
+    event_id     VARCHAR(36) NOT NULL,
+    type         TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    payload      JSONB NOT NULL,
+    created_at   TIMESTAMPTZ,
+    published_at TIMESTAMPTZ,
+    attempts     BIGINT NOT NULL DEFAULT 0,
+    last_error   TEXT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_messages_event_id ON outbox_messages (event_id);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_published_at ON outbox_messages (published_at);
+
+CREATE TABLE IF NOT EXISTS processed_events (
+    consumer     VARCHAR(100) NOT NULL,
+    event_id     VARCHAR(36) NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (consumer, event_id)
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_outbox_messages_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_published_at ON outbox_messages (published_at);
+
+DROP INDEX IF EXISTS idx_purchased_books_owner_active;
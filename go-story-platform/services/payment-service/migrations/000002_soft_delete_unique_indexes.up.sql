@@ -0,0 +1,7 @@
+-- Mỗi user chỉ sở hữu một lần cùng một truyện/chương (chapter_id = 0 là trọn bộ).
+-- Trước đây chỉ được kiểm tra trong PurchaseRepository.
+CREATE UNIQUE INDEX idx_purchased_books_owner_active ON purchased_books (user_id, book_id, chapter_id) WHERE deleted_at IS NULL;
+
+-- Relay chỉ quét các event chưa gửi
+DROP INDEX IF EXISTS idx_outbox_messages_published_at;
+CREATE INDEX idx_outbox_messages_pending ON outbox_messages (id) WHERE published_at IS NULL;
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS indexed_blocks;
+DROP TABLE IF EXISTS chain_cursors;
+
+DROP INDEX IF EXISTS idx_transactions_deposit_block;
+DROP INDEX IF EXISTS idx_transactions_block_number;
+ALTER TABLE transactions DROP COLUMN IF EXISTS block_hash;
+ALTER TABLE transactions DROP COLUMN IF EXISTS block_number;
+
+DROP INDEX IF EXISTS idx_user_wallets_wallet_address;
+ALTER TABLE user_wallets DROP COLUMN IF EXISTS wallet_address;
@@ -0,0 +1,25 @@
+-- Ví đã xác thực của user, đồng bộ từ event user.wallet_changed của user-service
+ALTER TABLE user_wallets ADD COLUMN IF NOT EXISTS wallet_address varchar(42) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_user_wallets_wallet_address ON user_wallets (wallet_address) WHERE wallet_address <> '';
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS block_number bigint NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS block_hash varchar(66) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_transactions_block_number ON transactions (block_number);
+-- Indexer ghi mỗi giao dịch nạp tiền một lần cho mỗi block chứa nó; cùng tx được đưa lại
+-- vào block khác sau reorg sẽ là một dòng mới
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_deposit_block ON transactions (tx_hash, block_hash)
+    WHERE type = 'Deposit' AND block_hash <> '' AND deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS chain_cursors (
+    name varchar(64) PRIMARY KEY,
+    block_number bigint NOT NULL,
+    block_hash varchar(66) NOT NULL,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS indexed_blocks (
+    number bigint PRIMARY KEY,
+    hash varchar(66) NOT NULL,
+    parent_hash varchar(66) NOT NULL,
+    indexed_at timestamptz
+);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_purchased_books_nft_token_active;
+DROP INDEX IF EXISTS idx_purchased_books_nft_unminted;
+
+ALTER TABLE purchased_books DROP COLUMN IF EXISTS owner_address;
+ALTER TABLE purchased_books DROP COLUMN IF EXISTS nft_tx_hash;
+ALTER TABLE purchased_books DROP COLUMN IF EXISTS nft_status;
@@ -0,0 +1,10 @@
+ALTER TABLE purchased_books ADD COLUMN IF NOT EXISTS nft_status varchar(16) NOT NULL DEFAULT 'pending';
+ALTER TABLE purchased_books ADD COLUMN IF NOT EXISTS nft_tx_hash varchar(66) NOT NULL DEFAULT '';
+ALTER TABLE purchased_books ADD COLUMN IF NOT EXISTS owner_address varchar(42) NOT NULL DEFAULT '';
+
+-- Minter chỉ quét các bản ghi chưa đúc xong
+CREATE INDEX IF NOT EXISTS idx_purchased_books_nft_unminted ON purchased_books (id)
+    WHERE nft_status IN ('pending', 'minting') AND deleted_at IS NULL;
+-- Mỗi token chỉ ứng với một quyền sở hữu còn hiệu lực
+CREATE UNIQUE INDEX IF NOT EXISTS idx_purchased_books_nft_token_active ON purchased_books (nft_token_id)
+    WHERE nft_token_id <> '' AND deleted_at IS NULL;
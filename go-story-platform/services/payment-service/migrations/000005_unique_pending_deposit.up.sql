@@ -0,0 +1,6 @@
+-- Mỗi TxHash chỉ có một Deposit đang chờ ghi có: hai yêu cầu khai báo cùng lúc, hoặc khai báo
+-- chạy song song với indexer, không thể tạo hai giao dịch pending để rồi cùng được xác nhận.
+-- Deposit đã xác nhận không nằm trong index, nên giao dịch được đưa lại vào block khác sau reorg
+-- (Deposit pending cũ đã bị Rewind chuyển sang failed) vẫn được ghi nhận.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_pending_deposit ON transactions (tx_hash)
+    WHERE type = 'Deposit' AND status = 'pending' AND tx_hash <> '' AND deleted_at IS NULL;
@@ -0,0 +1,34 @@
+package auth
+
+// Các vai trò lưu trong User.Role của user-service
+const (
+	RoleReader = "reader"
+	RoleAuthor = "author"
+	RoleAdmin  = "admin"
+)
+
+// Permission là một quyền thao tác cụ thể, được gán cho vai trò qua rolePermissions
+type Permission string
+
+const (
+	PermTransactionSettle   Permission = "transaction:settle"    // Xác nhận/hủy giao dịch nạp tiền đang chờ
+	PermEntitlementCheckAny Permission = "entitlement:check_any" // Kiểm tra quyền đọc của user khác
+)
+
+// rolePermissions định nghĩa tập quyền của từng vai trò.
+// Ví và lịch sử giao dịch của chính mình không cần quyền riêng.
+var rolePermissions = map[string][]Permission{
+	RoleReader: {},
+	RoleAuthor: {},
+	RoleAdmin:  {PermTransactionSettle, PermEntitlementCheckAny},
+}
+
+// HasPermission kiểm tra vai trò có quyền perm không
+func HasPermission(role string, perm Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
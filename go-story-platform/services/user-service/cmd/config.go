@@ -0,0 +1,34 @@
+package main
+
+import (
+	"user-service/internal/mailer"
+	"user-service/internal/transport/http"
+
+	"shared/config"
+	"shared/eventbus"
+	"shared/identity"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/rpc"
+)
+
+// Config là toàn bộ cấu hình của user-service, nạp một lần lúc khởi động bằng config.MustLoad
+type Config struct {
+	HTTPPort int `env:"HTTP_PORT" default:"8080" min:"1"`
+	GRPCPort int `env:"GRPC_PORT" default:"9080" min:"1"`
+
+	Log       logging.Config
+	Lifecycle lifecycle.Config
+	Database  config.Database
+	JWT       config.JWT
+	CORS      config.CORS
+	EventBus  eventbus.Config
+	Gateway   identity.Config
+	GRPCTLS   rpc.TLSConfig
+
+	Mail     mailer.Config
+	Handlers http.Config
+
+	// Số nonce SIWE mỗi IP được lấy trong một phút; endpoint công khai và mỗi lần gọi ghi một dòng DB
+	SiweNonceRateLimit int `env:"SIWE_NONCE_RATE_LIMIT" default:"10" min:"1"`
+}
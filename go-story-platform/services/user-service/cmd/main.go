@@ -1,16 +1,36 @@
 package main
 
 import (
+	"log"
+	"os"
+	"time"
 	"user-service/internal/database"
+	"user-service/internal/mailer"
 	"user-service/internal/repository"
+	"user-service/internal/transport/grpc"
 	"user-service/internal/transport/http"
 	"user-service/internal/transport/http/middleware" // Import middleware của bạn
+	"user-service/migrations"
+	authz "user-service/pkg/auth"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 	_ "user-service/docs"
 
+	jwtauth "shared/auth"
+	"shared/config"
+	"shared/eventbus"
+	"shared/health"
+	"shared/identity"
+	"shared/lifecycle"
+	"shared/logging"
+	"shared/migrate"
+	"shared/outbox"
+	"shared/rpc"
+	"shared/rpc/userpb"
+
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -25,27 +45,83 @@ import (
 
 // @BasePath /
 func main() {
-	// 1. Kết nối DB
-	db := database.InitDB()
+	// Lệnh quản lý schema: go run ./cmd migrate up|down|status|create, chỉ cần cấu hình database
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		var dbCfg config.Database
+		config.MustLoad(&dbCfg)
+		connect := func() *gorm.DB { return database.Connect(dbCfg) }
+		if err := migrate.RunCLI(os.Args[2:], connect, migrations.FS, "migrations"); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// 0. Nạp và kiểm tra cấu hình, dừng ngay nếu thiếu biến bắt buộc hoặc secret quá yếu
+	var cfg Config
+	config.MustLoad(&cfg)
+	if err := logging.Setup("user-service", cfg.Log); err != nil {
+		log.Fatal("❌ Cấu hình log không hợp lệ: ", err)
+	}
+	config.LogEffective("User Service", &cfg)
+
+	// Các thành phần được dừng theo thứ tự ngược với lúc thêm vào app khi nhận SIGTERM:
+	// HTTP server trả lời xong request đang xử lý trước, database đóng sau cùng.
+	app := lifecycle.New("User Service", cfg.Lifecycle)
+
+	// 1. Kết nối DB và event bus. Relay đẩy các event trong outbox (UserRegistered,
+	// UserDeleted) lên broker cho các service khác.
+	db := database.InitDB(cfg.Database)
+	app.Add(lifecycle.Closer("database", func() error { return database.Close(db) }))
+	broker, err := eventbus.New(cfg.EventBus, cfg.Database)
+	if err != nil {
+		log.Fatal("❌ Không thể khởi tạo event bus:", err)
+	}
+	app.Add(lifecycle.Closer("event bus", broker.Close))
+	app.Add(lifecycle.Worker("outbox relay", outbox.NewRelay(db, broker, "user-service").Run))
 
 	// 2. Khởi tạo Repository & Handler
 	userRepo := repository.NewUserRepository(db)
-	userHandler := http.NewUserHandler(userRepo)
+	tokenRepo := repository.NewRefreshTokenRepository(db)
+	resetRepo := repository.NewPasswordResetRepository(db)
+	siweRepo := repository.NewSiweRepository(db)
+	// JWTService ký token khi đăng nhập; AuthMiddleware kiểm tra token bằng Verifier dùng chung
+	jwtSvc := authz.NewJWTService(cfg.JWT.Secret, cfg.JWT.Issuer)
+	verifier := jwtauth.NewVerifier(cfg.JWT)
+	userHandler := http.NewUserHandler(userRepo, tokenRepo, resetRepo, siweRepo, mailer.New(cfg.Mail), jwtSvc, cfg.Handlers)
+
+	// gRPC nội bộ cho các service khác (tra cứu user, ví), chạy song song với Gin
+	grpcServer, err := rpc.NewServer(cfg.GRPCTLS)
+	if err != nil {
+		log.Fatal("❌ Không thể khởi tạo gRPC server:", err)
+	}
+	userpb.RegisterUserServiceServer(grpcServer, grpc.NewUserServer(userRepo))
+	app.Add(lifecycle.GRPC("gRPC server", grpcServer, cfg.GRPCTLS.ListenAddr(cfg.GRPCPort)))
 
 	// 3. Khởi tạo Gin
-	r := gin.Default()
+	// Request ID và access log JSON thay cho logger mặc định của Gin; panic trả 500 với message chung
+	r := gin.New()
+	r.Use(logging.Middleware(), logging.Recovery())
 
 	// --- QUAN TRỌNG: Cấu hình CORS để UI có thể gọi API ---
 	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"http://localhost:3000", "http://127.0.0.1:3000"}, // URL của React/Vue
+		AllowOrigins:     cfg.CORS.AllowedOrigins, // URL của UI, cấu hình qua CORS_ALLOWED_ORIGINS
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", logging.HeaderRequestID},
+		ExposeHeaders:    []string{logging.HeaderRequestID},
 		AllowCredentials: true,
 	}))
 
 	// 4. Swagger endpoint
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Liveness và readiness cho orchestrator/load balancer; /readyz trả 503 ngay khi bắt đầu dừng
+	checker := health.New()
+	checker.Add("database", health.DB(db))
+	checker.Add("event bus", broker.Ping)
+	app.OnShutdown(checker.Drain)
+	r.GET("/healthz", gin.WrapF(checker.Liveness))
+	r.GET("/readyz", gin.WrapF(checker.Readiness))
+
 	// 5. Định nghĩa Routes
 
 	// Nhóm các route công khai (không cần login)
@@ -53,19 +129,31 @@ func main() {
 	{
 		auth.POST("/login", userHandler.Login)
 		auth.POST("/register", userHandler.CreateUser) // Thường register nằm ở auth hoặc công khai
+		auth.POST("/refresh", userHandler.RefreshToken)
+		auth.POST("/logout", userHandler.Logout)
+		auth.POST("/forgot-password", userHandler.ForgotPassword)
+		auth.POST("/reset-password", userHandler.ResetPassword)
+		auth.GET("/siwe/nonce", middleware.RateLimit(cfg.SiweNonceRateLimit, time.Minute), userHandler.SiweNonce)
+		auth.POST("/siwe/verify", userHandler.SiweVerify)
 	}
 
 	// Nhóm các route cần bảo mật (phải có Token)
 	users := r.Group("/users")
-	users.Use(middleware.AuthMiddleware()) // Áp dụng bảo vệ cho cả nhóm
+	users.Use(middleware.AuthMiddleware(verifier, identity.NewSigner(cfg.Gateway))) // Áp dụng bảo vệ cho cả nhóm
 	{
-		users.GET("/", userHandler.ListUsers)
-		users.GET("/email/:email", userHandler.GetUserByEmail)
-		users.GET("/username/:username", userHandler.GetUserByUsername)
-		users.PUT("/:id", userHandler.UpdateUser)
-		users.DELETE("/:id", userHandler.DeleteUser)
+		// Phân quyền theo vai trò (reader/author/admin) và quyền sở hữu, xem pkg/auth/policy.go
+		users.GET("/", middleware.Authorize(middleware.HasPermission(authz.PermUserList)), userHandler.ListUsers)
+		users.GET("/email/:email", middleware.Authorize(middleware.HasPermission(authz.PermUserRead)), userHandler.GetUserByEmail)
+		users.GET("/username/:username", middleware.Authorize(middleware.HasPermission(authz.PermUserRead)), userHandler.GetUserByUsername)
+		users.PUT("/:id", middleware.Authorize(middleware.SelfOr("id", authz.PermUserUpdateAny)), userHandler.UpdateUser)
+		users.DELETE("/:id", middleware.Authorize(middleware.SelfOr("id", authz.PermUserDeleteAny)), userHandler.DeleteUser)
+		// Chỉ chính chủ mới liên kết được ví vì chữ ký SIWE gắn với phiên đăng nhập hiện tại
+		users.POST("/:id/wallet", middleware.Authorize(middleware.IsSelf("id")), userHandler.LinkWallet)
 	}
 
-	// 6. Chạy Server
-	r.Run(":8080")
+	// 6. Chạy Server cùng các thành phần đã đăng ký cho tới khi nhận SIGINT/SIGTERM
+	app.Add(lifecycle.HTTP("HTTP server", cfg.HTTPPort, r))
+	if err := app.Run(); err != nil {
+		log.Fatal("❌ User Service dừng do lỗi: ", err)
+	}
 }
@@ -15,6 +15,46 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/auth/forgot-password": {
+            "post": {
+                "description": "Gửi link đặt lại mật khẩu tới email. Luôn trả về thành công để không lộ email nào đã đăng ký.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Quên mật khẩu",
+                "parameters": [
+                    {
+                        "description": "Email đăng ký",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/login": {
             "post": {
                 "consumes": [
@@ -48,8 +88,231 @@ const docTemplate = `{
                 }
             }
         },
+        "/auth/logout": {
+            "post": {
+                "description": "Thu hồi refresh token được gửi lên cùng toàn bộ token thuộc cùng phiên đăng nhập",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Đăng xuất",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Đổi refresh token lấy cặp token mới. Refresh token cũ bị thu hồi ngay (rotation);\nnếu một token đã thu hồi bị gửi lại thì toàn bộ family token bị thu hồi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Làm mới access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/reset-password": {
+            "post": {
+                "description": "Đặt mật khẩu mới bằng token trong email. Token chỉ dùng được một lần và mọi phiên đăng nhập cũ bị thu hồi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Đặt lại mật khẩu",
+                "parameters": [
+                    {
+                        "description": "Token và mật khẩu mới",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/siwe/nonce": {
+            "get": {
+                "description": "Cấp nonce dùng một lần để client đưa vào message EIP-4361 trước khi ký bằng ví",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Lấy nonce cho Sign-In with Ethereum",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-service_internal_transport_http_dto.SiweNonceResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/siwe/verify": {
+            "post": {
+                "description": "Xác thực message EIP-4361 và chữ ký personal_sign, sau đó cấp token giống /auth/login.\nVí phải được liên kết và xác thực với tài khoản trước qua POST /users/{id}/wallet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Đăng nhập bằng ví (Sign-In with Ethereum)",
+                "parameters": [
+                    {
+                        "description": "Message và chữ ký",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.SiweVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-service_internal_transport_http_dto.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/users/": {
             "get": {
+                "description": "Phân trang theo cursor: lấy next_cursor của trang trước truyền vào cursor để lấy trang tiếp.\nCursor gắn với sort/order nên phải giữ nguyên sort/order và bộ lọc khi lật trang.",
                 "produces": [
                     "application/json"
                 ],
@@ -57,14 +320,105 @@ const docTemplate = `{
                     "Users"
                 ],
                 "summary": "Lấy danh sách users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Số bản ghi mỗi trang (mặc định 20, tối đa 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor lấy từ next_cursor của trang trước",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "reader",
+                            "author",
+                            "admin"
+                        ],
+                        "type": "string",
+                        "description": "Lọc theo role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tạo từ thời điểm (RFC3339, bao gồm)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tạo trước thời điểm (RFC3339, không bao gồm)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lọc username bắt đầu bằng chuỗi này",
+                        "name": "username_prefix",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "username",
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Cột sắp xếp (mặc định created_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Chiều sắp xếp (mặc định desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Trả thêm tổng số bản ghi khớp bộ lọc",
+                        "name": "include_total",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/user-service_internal_models.User"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-service_internal_transport_http_dto.UserListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
                         }
                     },
                     "500": {
@@ -174,6 +528,7 @@ const docTemplate = `{
         },
         "/users/{id}": {
             "put": {
+                "description": "Ghi đè username, email, ví, role và avatar/bio của profile. Role chỉ đổi được khi có quyền đổi vai trò.",
                 "consumes": [
                     "application/json"
                 ],
@@ -193,18 +548,54 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "User data",
+                        "description": "Thông tin user",
                         "name": "user",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user-service_internal_models.User"
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.UpdateUserRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-service_internal_transport_http_dto.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
                         }
@@ -229,10 +620,84 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/wallet": {
+            "post": {
+                "description": "User đang đăng nhập ký message SIWE bằng ví để chứng minh sở hữu; địa chỉ ví trong message\nđược lưu vào WalletAddress và đánh dấu đã xác thực.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Liên kết và xác thực ví cho tài khoản",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Message và chữ ký",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.SiweVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-service_internal_transport_http_dto.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/user-service_internal_transport_http_dto.ApiResponse"
                         }
                     }
                 }
@@ -240,121 +705,234 @@ const docTemplate = `{
         }
     },
     "definitions": {
-        "user-service_internal_models.Profile": {
+        "user-service_internal_transport_http_dto.ApiResponse": {
             "type": "object",
             "properties": {
-                "avatar": {
+                "data": {
+                    "description": "interface giúp chứa bất kỳ dto nào"
+                },
+                "message": {
                     "type": "string"
                 },
-                "bio": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "user-service_internal_transport_http_dto.ForgotPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "user-service_internal_transport_http_dto.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
                     "type": "string"
                 },
-                "created_at": {
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "user-service_internal_transport_http_dto.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
                     "type": "string"
                 },
-                "id": {
+                "expires_in": {
+                    "description": "Thời hạn access token, tính bằng giây",
                     "type": "integer"
                 },
-                "preferences": {
-                    "description": "Preferences lưu trữ các cài đặt cá nhân dưới dạng JSON (ví dụ: ngôn ngữ, giao diện).",
+                "refesh_token": {
                     "type": "string"
                 },
-                "updated_at": {
+                "user": {
+                    "$ref": "#/definitions/user-service_internal_transport_http_dto.UserResponse"
+                }
+            }
+        },
+        "user-service_internal_transport_http_dto.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "user-service_internal_transport_http_dto.ProfileRequest": {
+            "type": "object",
+            "properties": {
+                "avatar": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "bio": {
+                    "type": "string"
                 }
             }
         },
-        "user-service_internal_models.User": {
+        "user-service_internal_transport_http_dto.ProfileResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "avatar": {
+                    "type": "string"
+                },
+                "bio": {
                     "type": "string"
                 },
+                "preferences": {
+                    "type": "string"
+                }
+            }
+        },
+        "user-service_internal_transport_http_dto.RefreshTokenRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "user-service_internal_transport_http_dto.RegisterRequest": {
+            "type": "object",
+            "properties": {
                 "email": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "password": {
+                    "type": "string"
                 },
                 "profile": {
-                    "description": "Quan hệ 1-1 với Profile",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/user-service_internal_models.Profile"
-                        }
-                    ]
+                    "$ref": "#/definitions/user-service_internal_transport_http_dto.ProfileRequest"
                 },
-                "role": {
+                "username": {
                     "type": "string"
                 },
-                "updated_at": {
+                "walletAddress": {
+                    "type": "string"
+                }
+            }
+        },
+        "user-service_internal_transport_http_dto.ResetPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "confirm_password": {
                     "type": "string"
                 },
-                "username": {
+                "new_password": {
                     "type": "string"
                 },
-                "wallet_address": {
-                    "description": "Liên kết Blockchain",
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "user-service_internal_transport_http_dto.ApiResponse": {
+        "user-service_internal_transport_http_dto.SiweNonceResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "description": "interface giúp chứa bất kỳ dto nào"
+                "chain_id": {
+                    "type": "integer"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "string"
                 },
+                "uri": {
+                    "description": "Origin mà URI trong message phải trùng scheme và host",
+                    "type": "string"
+                }
+            }
+        },
+        "user-service_internal_transport_http_dto.SiweVerifyRequest": {
+            "type": "object",
+            "properties": {
                 "message": {
+                    "description": "Message EIP-4361 nguyên văn mà ví đã ký",
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "signature": {
+                    "description": "Chữ ký personal_sign dạng hex 0x...",
+                    "type": "string"
                 }
             }
         },
-        "user-service_internal_transport_http_dto.LoginRequest": {
+        "user-service_internal_transport_http_dto.UpdateUserRequest": {
             "type": "object",
             "properties": {
-                "password": {
+                "email": {
+                    "type": "string"
+                },
+                "profile": {
+                    "$ref": "#/definitions/user-service_internal_transport_http_dto.ProfileRequest"
+                },
+                "role": {
                     "type": "string"
                 },
                 "username": {
                     "type": "string"
+                },
+                "wallet_address": {
+                    "type": "string"
                 }
             }
         },
-        "user-service_internal_transport_http_dto.ProfileRequest": {
+        "user-service_internal_transport_http_dto.UserListResponse": {
             "type": "object",
             "properties": {
-                "avatar": {
-                    "type": "string"
+                "has_more": {
+                    "type": "boolean"
                 },
-                "bio": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/user-service_internal_transport_http_dto.UserResponse"
+                    }
+                },
+                "next_cursor": {
+                    "description": "Rỗng khi đã hết dữ liệu",
                     "type": "string"
+                },
+                "total": {
+                    "description": "Chỉ có khi include_total=true",
+                    "type": "integer"
                 }
             }
         },
-        "user-service_internal_transport_http_dto.RegisterRequest": {
+        "user-service_internal_transport_http_dto.UserResponse": {
             "type": "object",
             "properties": {
-                "email": {
+                "created_at": {
                     "type": "string"
                 },
-                "password": {
+                "email": {
                     "type": "string"
                 },
-                "profile": {
-                    "$ref": "#/definitions/user-service_internal_transport_http_dto.ProfileRequest"
+                "id": {
+                    "type": "integer"
+                },
+                "profile_response": {
+                    "$ref": "#/definitions/user-service_internal_transport_http_dto.ProfileResponse"
+                },
+                "role": {
+                    "type": "string"
                 },
                 "username": {
                     "type": "string"
                 },
-                "walletAddress": {
+                "wallet_address": {
                     "type": "string"
+                },
+                "wallet_verified": {
+                    "type": "boolean"
                 }
             }
         }
@@ -1,37 +1,49 @@
 package database
 
 import (
-	"fmt"
 	"log"
-	"os"
-	"user-service/internal/models" // Thay bằng tên module của bạn
+	"log/slog"
+	"user-service/migrations"
+
+	"shared/config"
+	"shared/logging"
+	"shared/migrate"
 
-	"github.com/joho/godotenv"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func InitDB() *gorm.DB {
-	// Load biến môi trường
-	godotenv.Load()
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_NAME"),
-		os.Getenv("DB_PORT"),
-		os.Getenv("DB_SSLMODE"),
-	)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// Connect mở kết nối tới database của service theo cfg
+func Connect(cfg config.Database) *gorm.DB {
+	db, err := gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{Logger: logging.Gorm()})
 	if err != nil {
 		log.Fatal("❌ Không thể kết nối User DB:", err)
 	}
+	return db
+}
 
-	// Auto Migrate các bảng theo đúng model bạn đã tạo
-	db.AutoMigrate(&models.User{}, &models.Profile{})
+// Close đóng pool kết nối tới database
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// InitDB kết nối DB và dừng service nếu schema còn migration chưa chạy.
+// Schema được quản lý bằng các file SQL trong thư mục migrations, chạy bằng lệnh migrate up.
+func InitDB(cfg config.Database) *gorm.DB {
+	db := Connect(cfg)
+
+	m, err := migrate.New(db, migrations.FS)
+	if err != nil {
+		log.Fatal("❌ Không thể đọc migration:", err)
+	}
+	if err := m.RequireUpToDate(); err != nil {
+		log.Fatal("❌ ", err, ". Hãy chạy: go run ./cmd migrate up")
+	}
 
-	fmt.Println("✅ User Service: Database connected & Migrated")
+	slog.Info("Database đã kết nối, schema đã cập nhật")
 	return db
 }
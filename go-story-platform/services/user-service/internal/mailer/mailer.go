@@ -0,0 +1,88 @@
+// Package mailer định nghĩa interface gửi email cho user-service cùng các bản cài đặt
+// dùng khi phát triển local (ghi log hoặc ghi file). Bản cài đặt SMTP thật chỉ cần
+// thỏa mãn interface Mailer là có thể thay vào trong cmd/main.go.
+package mailer
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Message là một email cần gửi
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer là interface gửi email
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// Config chọn nơi gửi email khi phát triển local
+type Config struct {
+	// Có giá trị thì ghi mỗi email thành file .eml trong thư mục này, để trống thì chỉ in ra log
+	OutboxDir string `env:"MAIL_OUTBOX_DIR"`
+	// Bật thì LogMailer ghi cả nội dung email (có thể chứa link đặt lại mật khẩu) ở mức debug.
+	// Chỉ dùng khi chạy local.
+	LogBody bool `env:"MAIL_LOG_BODY"`
+}
+
+// New chọn Mailer theo cfg
+func New(cfg Config) Mailer {
+	if cfg.OutboxDir != "" {
+		return NewFileMailer(cfg.OutboxDir)
+	}
+	return NewLogMailer(cfg.LogBody)
+}
+
+// LogMailer ghi email ra log thay vì gửi thật. Nội dung email chứa token đặt lại mật khẩu
+// nên mặc định chỉ ghi người nhận và tiêu đề.
+type LogMailer struct {
+	logBody bool
+}
+
+// NewLogMailer tạo LogMailer; logBody bật thì ghi thêm nội dung email ở mức debug
+func NewLogMailer(logBody bool) *LogMailer {
+	return &LogMailer{logBody: logBody}
+}
+
+// Send ghi người nhận và tiêu đề email ra log, kèm nội dung khi được bật
+func (m *LogMailer) Send(msg Message) error {
+	slog.Info("Gửi email", "to", msg.To, "subject", msg.Subject)
+	if m.logBody {
+		slog.Debug("Nội dung email", "to", msg.To, "body", msg.Body)
+	}
+	return nil
+}
+
+// FileMailer ghi mỗi email thành một file .eml trong thư mục Dir
+type FileMailer struct {
+	Dir string
+}
+
+// NewFileMailer tạo FileMailer ghi vào thư mục dir
+func NewFileMailer(dir string) *FileMailer {
+	return &FileMailer{Dir: dir}
+}
+
+// Send ghi email ra file, tên file gồm thời điểm gửi và địa chỉ người nhận
+func (m *FileMailer) Send(msg Message) error {
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml",
+		time.Now().Format("20060102T150405.000000000"),
+		strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(msg.To),
+	)
+	content := fmt.Sprintf("To: %s\r\nSubject: %s\r\nDate: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		msg.To, msg.Subject, time.Now().Format(time.RFC1123Z), msg.Body)
+
+	return os.WriteFile(filepath.Join(m.Dir, name), []byte(content), 0o600)
+}
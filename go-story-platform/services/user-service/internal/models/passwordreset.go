@@ -0,0 +1,23 @@
+// Package models chứa các định nghĩa cấu trúc dữ liệu (struct) cho User Service,
+// được sử dụng để ánh xạ (mapping) với các bảng trong cơ sở dữ liệu PostgreSQL qua GORM.
+package models
+
+import (
+	"time"
+)
+
+// PasswordResetToken là token dùng một lần để đặt lại mật khẩu. Giống RefreshToken,
+// chỉ bản băm SHA-256 được lưu; token gốc chỉ xuất hiện trong email gửi cho user.
+type PasswordResetToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"index;not null" json:"user_id"`
+	TokenHash string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// IsUsable cho biết token chưa dùng và chưa hết hạn
+func (t *PasswordResetToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
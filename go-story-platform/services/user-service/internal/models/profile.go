@@ -11,7 +11,7 @@ import (
 // Profile đại diện cho thông tin chi tiết và tùy chọn của người dùng trong hệ thống.
 type Profile struct {
 	ID     uint   `gorm:"primaryKey" json:"id"`
-	UserID uint   `gorm:"unique;not null" json:"user_id"`
+	UserID uint   `gorm:"not null;uniqueIndex:idx_profiles_user_id_active,where:deleted_at IS NULL" json:"user_id"`
 	Avatar string `json:"avatar"`
 	Bio    string `gorm:"type:text" json:"bio"`
 	// Preferences lưu trữ các cài đặt cá nhân dưới dạng JSON (ví dụ: ngôn ngữ, giao diện).
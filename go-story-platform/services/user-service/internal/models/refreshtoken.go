@@ -0,0 +1,27 @@
+// Package models chứa các định nghĩa cấu trúc dữ liệu (struct) cho User Service,
+// được sử dụng để ánh xạ (mapping) với các bảng trong cơ sở dữ liệu PostgreSQL qua GORM.
+package models
+
+import (
+	"time"
+)
+
+// RefreshToken lưu refresh token đã cấp cho user. Chỉ lưu bản băm SHA-256 của token,
+// không bao giờ lưu token gốc. Các token sinh ra từ cùng một lần đăng nhập chung một FamilyID
+// để khi phát hiện token cũ bị dùng lại thì thu hồi được cả "họ" token.
+type RefreshToken struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"index;not null" json:"user_id"`
+	FamilyID   string     `gorm:"type:varchar(64);index;not null" json:"family_id"`
+	TokenHash  string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	ReplacedBy *uint      `json:"replaced_by"` // ID của token mới sau khi xoay vòng (rotation)
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// IsActive cho biết token còn dùng được hay không (chưa bị thu hồi và chưa hết hạn)
+func (t *RefreshToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
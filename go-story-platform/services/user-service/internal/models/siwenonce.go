@@ -0,0 +1,16 @@
+// Package models chứa các định nghĩa cấu trúc dữ liệu (struct) cho User Service,
+// được sử dụng để ánh xạ (mapping) với các bảng trong cơ sở dữ liệu PostgreSQL qua GORM.
+package models
+
+import (
+	"time"
+)
+
+// SiweNonce là nonce server cấp cho một lần Sign-In with Ethereum, chỉ dùng được một lần
+type SiweNonce struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	Nonce     string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"nonce"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
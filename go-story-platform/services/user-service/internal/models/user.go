@@ -10,14 +10,15 @@ import (
 
 // User người dùng để login khi tham gia sử dụng trang web
 type User struct {
-	ID            uint           `gorm:"primaryKey" json:"id"`
-	Username      string         `gorm:"unique;not null" json:"username"`
-	Email         string         `gorm:"unique;not null" json:"email"`
-	PasswordHash  string         `gorm:"not null" json:"-"`
-	WalletAddress string         `gorm:"index;not null" json:"wallet_address"` //Liên kết Blockchain
-	Role          string         `gorm:"not null;default:reader" json:"role"`
-	Profile       Profile        `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"profile"` //Quan hệ 1-1 với Profile
-	CreatedAt     time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt     time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+	ID               uint           `gorm:"primaryKey;index:idx_users_created_at_id,priority:2" json:"id"`
+	Username         string         `gorm:"not null;uniqueIndex:idx_users_username_active,where:deleted_at IS NULL" json:"username"`
+	Email            string         `gorm:"not null;uniqueIndex:idx_users_email_active,where:deleted_at IS NULL" json:"email"`
+	PasswordHash     string         `gorm:"not null" json:"-"`
+	WalletAddress    string         `gorm:"index;not null" json:"wallet_address"` //Liên kết Blockchain
+	WalletVerifiedAt *time.Time     `json:"wallet_verified_at"`                   // Khác nil khi đã chứng minh sở hữu ví bằng chữ ký SIWE
+	Role             string         `gorm:"not null;default:reader" json:"role"`
+	Profile          Profile        `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"profile"` //Quan hệ 1-1 với Profile
+	CreatedAt        time.Time      `gorm:"autoCreateTime;index:idx_users_created_at_id,priority:1" json:"created_at"`       // Index (created_at, id) phục vụ phân trang keyset
+	UpdatedAt        time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
 }
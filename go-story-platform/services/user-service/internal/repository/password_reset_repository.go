@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"user-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrResetTokenUsed được trả về khi token đặt lại mật khẩu đã được dùng (kể cả do request đồng thời)
+var ErrResetTokenUsed = errors.New("token đặt lại mật khẩu đã được sử dụng")
+
+// PasswordResetRepository giữ kết nối DB cho bảng password_reset_tokens
+type PasswordResetRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordResetRepository tạo PasswordResetRepository với kết nối DB được truyền vào
+func NewPasswordResetRepository(db *gorm.DB) *PasswordResetRepository {
+	return &PasswordResetRepository{db: db}
+}
+
+// CreateResetToken vô hiệu hóa các token cũ chưa dùng của user rồi lưu token mới,
+// đảm bảo tại một thời điểm chỉ link trong email gần nhất còn hiệu lực
+func (r *PasswordResetRepository) CreateResetToken(token *models.PasswordResetToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", token.UserID).
+			Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+// GetResetTokenByHash lấy token theo bản băm
+func (r *PasswordResetRepository) GetResetTokenByHash(hash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+
+	err := r.db.Where("token_hash = ?", hash).First(&token).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// ResetPassword đánh dấu token đã dùng, cập nhật mật khẩu và thu hồi mọi refresh token
+// của user trong cùng một transaction để các phiên đăng nhập cũ bị đăng xuất
+func (r *PasswordResetRepository) ResetPassword(token *models.PasswordResetToken, passwordHash string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		res := tx.Model(&models.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL", token.ID).
+			Update("used_at", now)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrResetTokenUsed
+		}
+
+		if err := tx.Model(&models.User{}).
+			Where("id = ?", token.UserID).
+			Update("password_hash", passwordHash).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", token.UserID).
+			Update("revoked_at", now).Error
+	})
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"user-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrRefreshTokenReused được trả về khi token đã bị xoay vòng/thu hồi trước đó nhưng vẫn được gửi lên
+var ErrRefreshTokenReused = errors.New("refresh token đã được sử dụng")
+
+// RefreshTokenRepository giữ kết nối DB cho bảng refresh_tokens
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository tạo RefreshTokenRepository với kết nối DB được truyền vào
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+// CreateRefreshToken lưu một refresh token mới (chỉ chứa bản băm)
+func (r *RefreshTokenRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetRefreshTokenByHash lấy token theo bản băm, kể cả token đã bị thu hồi để phát hiện dùng lại
+func (r *RefreshTokenRepository) GetRefreshTokenByHash(hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+
+	err := r.db.Where("token_hash = ?", hash).First(&token).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// RotateRefreshToken thu hồi token cũ và lưu token mới cùng family trong một transaction.
+// Việc thu hồi dùng điều kiện revoked_at IS NULL nên nếu hai request cùng xoay một token
+// thì chỉ một request thành công, request còn lại nhận ErrRefreshTokenReused.
+func (r *RefreshTokenRepository) RotateRefreshToken(old *models.RefreshToken, next *models.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		res := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", old.ID).
+			Update("revoked_at", now)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRefreshTokenReused
+		}
+
+		next.UserID = old.UserID
+		next.FamilyID = old.FamilyID
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.RefreshToken{}).
+			Where("id = ?", old.ID).
+			Update("replaced_by", next.ID).Error
+	})
+}
+
+// RevokeFamily thu hồi toàn bộ token còn hiệu lực thuộc cùng một family (dùng khi logout hoặc phát hiện dùng lại)
+func (r *RefreshTokenRepository) RevokeFamily(familyID string) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
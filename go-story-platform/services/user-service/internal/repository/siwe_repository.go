@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"time"
+	"user-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// SiweRepository giữ kết nối DB cho bảng siwe_nonces
+type SiweRepository struct {
+	db *gorm.DB
+}
+
+// NewSiweRepository tạo SiweRepository với kết nối DB được truyền vào
+func NewSiweRepository(db *gorm.DB) *SiweRepository {
+	return &SiweRepository{db: db}
+}
+
+// staleNonceBatch là số nonce hết hạn tối đa bị xóa mỗi lần cấp nonce mới. Lớn hơn 1 nên
+// tốc độ dọn luôn nhanh hơn tốc độ cấp và bảng chỉ còn lại các nonce chưa hết hạn.
+const staleNonceBatch = 100
+
+// CreateNonce lưu nonce mới cấp cho client và xóa bớt các nonce đã hết hạn (đã dùng hay chưa
+// đều không còn giá trị vì ConsumeNonce chỉ nhận nonce chưa hết hạn)
+func (r *SiweRepository) CreateNonce(nonce *models.SiweNonce) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`DELETE FROM siwe_nonces WHERE id IN (
+			SELECT id FROM siwe_nonces WHERE expires_at <= ? LIMIT ?)`, time.Now(), staleNonceBatch).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(nonce).Error
+	})
+}
+
+// ConsumeNonce đánh dấu nonce đã dùng nếu nó tồn tại, chưa dùng và chưa hết hạn.
+// Trả về false nếu nonce không hợp lệ; câu UPDATE có điều kiện đảm bảo nonce chỉ dùng được một lần.
+func (r *SiweRepository) ConsumeNonce(nonce string) (bool, error) {
+	now := time.Now()
+	res := r.db.Model(&models.SiweNonce{}).
+		Where("nonce = ? AND used_at IS NULL AND expires_at > ?", nonce, now).
+		Update("used_at", now)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidCursor được trả về khi cursor không giải mã được hoặc không khớp cách sắp xếp
+var ErrInvalidCursor = errors.New("cursor không hợp lệ")
+
+// UserSort là cột dùng để sắp xếp danh sách user
+type UserSort string
+
+const (
+	UserSortCreatedAt UserSort = "created_at"
+	UserSortUsername  UserSort = "username"
+	UserSortID        UserSort = "id"
+)
+
+// IsValid kiểm tra giá trị sort có được hỗ trợ không
+func (s UserSort) IsValid() bool {
+	switch s {
+	case UserSortCreatedAt, UserSortUsername, UserSortID:
+		return true
+	}
+	return false
+}
+
+// column trả về tên cột SQL, chỉ lấy từ danh sách cố định để tránh SQL injection
+func (s UserSort) column() string {
+	switch s {
+	case UserSortUsername:
+		return "username"
+	case UserSortID:
+		return "id"
+	default:
+		return "created_at"
+	}
+}
+
+// UserListQuery là bộ lọc, cách sắp xếp và vị trí trang khi liệt kê user
+type UserListQuery struct {
+	Role           string
+	CreatedFrom    *time.Time // Bao gồm mốc này
+	CreatedTo      *time.Time // Không bao gồm mốc này
+	UsernamePrefix string
+	SortBy         UserSort
+	Desc           bool
+	After          *UserCursor // nil = trang đầu
+	Limit          int
+}
+
+// apply thêm các điều kiện lọc (không gồm cursor) vào query
+func (q UserListQuery) apply(db *gorm.DB) *gorm.DB {
+	if q.Role != "" {
+		db = db.Where("role = ?", q.Role)
+	}
+	if q.CreatedFrom != nil {
+		db = db.Where("created_at >= ?", *q.CreatedFrom)
+	}
+	if q.CreatedTo != nil {
+		db = db.Where("created_at < ?", *q.CreatedTo)
+	}
+	if q.UsernamePrefix != "" {
+		db = db.Where("username LIKE ? ESCAPE '\\'", escapeLike(q.UsernamePrefix)+"%")
+	}
+	return db
+}
+
+// UserCursor ghi lại vị trí của bản ghi cuối trang trước. Client chỉ nhận chuỗi đã mã hóa
+// và gửi lại nguyên vẹn, không nên tự tạo hay phân tích nó.
+type UserCursor struct {
+	SortBy UserSort `json:"s"`
+	Desc   bool     `json:"d"`
+	Value  string   `json:"v"` // Giá trị cột sắp xếp của bản ghi cuối
+	ID     uint     `json:"i"`
+}
+
+// NewUserCursor tạo cursor trỏ sau bản ghi có giá trị sắp xếp và id cho trước
+func NewUserCursor(sortBy UserSort, desc bool, id uint, username string, createdAt time.Time) *UserCursor {
+	cursor := &UserCursor{SortBy: sortBy, Desc: desc, ID: id}
+	switch sortBy {
+	case UserSortUsername:
+		cursor.Value = username
+	case UserSortCreatedAt:
+		cursor.Value = createdAt.UTC().Format(time.RFC3339Nano)
+	}
+	return cursor
+}
+
+// Encode mã hóa cursor thành chuỗi base64 an toàn cho URL
+func (c *UserCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeUserCursor giải mã cursor và kiểm tra nó được tạo với cùng cách sắp xếp
+func DecodeUserCursor(encoded string, sortBy UserSort, desc bool) (*UserCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor UserCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.SortBy != sortBy || cursor.Desc != desc || cursor.ID == 0 {
+		return nil, ErrInvalidCursor
+	}
+	if _, err := cursor.sortValue(sortBy); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// sortValue chuyển Value về đúng kiểu của cột sắp xếp để so sánh trong SQL
+func (c *UserCursor) sortValue(sortBy UserSort) (interface{}, error) {
+	switch sortBy {
+	case UserSortUsername:
+		return c.Value, nil
+	case UserSortID:
+		return c.ID, nil
+	default:
+		t, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return t, nil
+	}
+}
+
+// escapeLike thoát các ký tự đặc biệt của LIKE để prefix được so khớp nguyên văn
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -2,11 +2,31 @@
 package repository
 
 import (
+	"errors"
+	"strings"
+	"time"
 	"user-service/internal/models"
 
+	"shared/events"
+	"shared/outbox"
+
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
+var (
+	ErrWalletTaken   = errors.New("ví đã được liên kết với tài khoản khác")
+	ErrUsernameTaken = errors.New("username đã tồn tại")
+	ErrEmailTaken    = errors.New("email đã được sử dụng")
+)
+
+// Các unique index của bảng users, dùng để nhận ra lỗi vi phạm khi ghi đồng thời
+const (
+	verifiedWalletIndex = "idx_users_verified_wallet"
+	usernameIndex       = "idx_users_username_active"
+	emailIndex          = "idx_users_email_active"
+)
+
 // UserRepository Giữ kết nối với db
 type UserRepository struct {
 	db *gorm.DB
@@ -17,10 +37,22 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 	return &UserRepository{db: db}
 }
 
-// CreateUser : Thêm User mới (Tự động tạo luôn Profile trống)
+// CreateUser : Thêm User mới (Tự động tạo luôn Profile trống) và ghi event UserRegistered
+// vào outbox trong cùng transaction
 func (r *UserRepository) CreateUser(user *models.User) error {
-	// GORM sẽ tự động chèn dữ liệu vào cả 2 bảng users và profiles nếu user.Profile được khởi tạo
-	return r.db.Create(user).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// GORM sẽ tự động chèn dữ liệu vào cả 2 bảng users và profiles nếu user.Profile được khởi tạo
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return outbox.Enqueue(tx, events.UserRegistered{
+			UserID:        user.ID,
+			Username:      user.Username,
+			WalletAddress: user.WalletAddress,
+			Role:          user.Role,
+			RegisteredAt:  user.CreatedAt,
+		})
+	})
 }
 
 // GetUserByID Hàm này lấy User theo ID từ DB, tự động load Profile, và trả về user + lỗi
@@ -30,15 +62,62 @@ func (r *UserRepository) GetUserByID(id uint) (*models.User, error) {
 	return &user, err
 }
 
-// UpdateUser Hàm này cập nhật User và toàn bộ dữ liệu liên quan, nhờ FullSaveAssociations: true
+// GetUsersByIDs lấy các user theo danh sách ID kèm Profile, ID không tồn tại bị bỏ qua
+func (r *UserRepository) GetUsersByIDs(ids []uint) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Preload("Profile").Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
+// UpdateUser ghi username, email, ví, role của user và avatar/bio trong profile của chính user đó;
+// các cột khác (mật khẩu, thời điểm tạo...) không bị đụng tới. Nếu địa chỉ ví thay đổi thì ghi
+// event UserWalletChanged trong cùng transaction. Trả về ErrUsernameTaken/ErrEmailTaken khi trùng.
 func (r *UserRepository) UpdateUser(user *models.User) error {
-	return r.db.Session(&gorm.Session{FullSaveAssociations: true}).Save(user).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var previous string
+		if err := tx.Model(&models.User{}).Where("id = ?", user.ID).
+			Pluck("wallet_address", &previous).Error; err != nil {
+			return err
+		}
+		err := tx.Model(user).
+			Select("username", "email", "wallet_address", "wallet_verified_at", "role").
+			Updates(user).Error
+		switch {
+		case isUniqueViolation(err, usernameIndex):
+			return ErrUsernameTaken
+		case isUniqueViolation(err, emailIndex):
+			return ErrEmailTaken
+		case err != nil:
+			return err
+		}
+		if err := tx.Model(&models.Profile{}).Where("user_id = ?", user.ID).
+			Select("avatar", "bio").
+			Updates(&models.Profile{Avatar: user.Profile.Avatar, Bio: user.Profile.Bio}).Error; err != nil {
+			return err
+		}
+		if strings.EqualFold(previous, user.WalletAddress) {
+			return nil
+		}
+		return outbox.Enqueue(tx, events.UserWalletChanged{
+			UserID:        user.ID,
+			WalletAddress: user.WalletAddress,
+			Verified:      user.WalletVerifiedAt != nil,
+			ChangedAt:     time.Now(),
+		})
+	})
 }
 
-// DeleteUser đánh dấu User là đã xóa bằng deleted_at, không xóa khỏi DB
+// DeleteUser đánh dấu User là đã xóa bằng deleted_at, không xóa khỏi DB.
+// Event UserDeleted chỉ được ghi khi thực sự có user bị xóa.
 func (r *UserRepository) DeleteUser(id uint) error {
-	// GORM sẽ đánh dấu DeletedAt thay vì xóa vĩnh viễn
-	return r.db.Delete(&models.User{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// GORM sẽ đánh dấu DeletedAt thay vì xóa vĩnh viễn
+		result := tx.Delete(&models.User{}, id)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return outbox.Enqueue(tx, events.UserDeleted{UserID: id, DeletedAt: time.Now()})
+	})
 }
 
 // UpdateProfileByUserID Hàm này cập nhật Profile theo user_id, chỉ thay đổi các field có giá trị
@@ -46,12 +125,49 @@ func (r *UserRepository) UpdateProfileByUserID(profile *models.Profile) error {
 	return r.db.Model(&models.Profile{}).Where("user_id = ?", profile.UserID).Updates(profile).Error
 }
 
-// GetAllUsers lấy danh sách toàn bộ người dùng kèm thông tin Profile
-func (r *UserRepository) GetAllUsers() ([]models.User, error) {
-	var users []models.User
+// ListUsers lấy một trang người dùng kèm Profile theo keyset pagination.
+// Lấy dư một bản ghi để biết còn trang sau hay không; hasMore = true khi còn.
+func (r *UserRepository) ListUsers(query UserListQuery) (users []models.User, hasMore bool, err error) {
+	column := query.SortBy.column()
+	direction := "ASC"
+	op := ">"
+	if query.Desc {
+		direction = "DESC"
+		op = "<"
+	}
+
+	db := query.apply(r.db.Model(&models.User{}))
+	if query.After != nil {
+		value, err := query.After.sortValue(query.SortBy)
+		if err != nil {
+			return nil, false, err
+		}
+		// So sánh theo cặp (cột sắp xếp, id) để thứ tự ổn định khi giá trị trùng nhau
+		db = db.Where("("+column+", id) "+op+" (?, ?)", value, query.After.ID)
+	}
+
 	// Preload giúp nạp thông tin từ bảng profiles để tránh lỗi N+1 query
-	err := r.db.Preload("Profile").Find(&users).Error
-	return users, err
+	err = db.Preload("Profile").
+		Order(column + " " + direction).
+		Order("id " + direction).
+		Limit(query.Limit + 1).
+		Find(&users).Error
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(users) > query.Limit {
+		return users[:query.Limit], true, nil
+	}
+	return users, false, nil
+}
+
+// CountUsers đếm số người dùng khớp bộ lọc (bỏ qua cursor). Chỉ gọi khi client yêu cầu
+// vì COUNT trên bảng lớn tốn kém.
+func (r *UserRepository) CountUsers(query UserListQuery) (int64, error) {
+	var total int64
+	err := query.apply(r.db.Model(&models.User{})).Count(&total).Error
+	return total, err
 }
 
 // GetUserByEmail lấy user theo email
@@ -89,3 +205,54 @@ func (r *UserRepository) GetUserByUsername(username string) (*models.User, error
 
 	return &user, nil
 }
+
+// GetUserByVerifiedWallet lấy user đã xác thực sở hữu địa chỉ ví (không phân biệt hoa/thường)
+func (r *UserRepository) GetUserByVerifiedWallet(address string) (*models.User, error) {
+	var user models.User
+
+	err := r.db.Preload("Profile").
+		Where("LOWER(wallet_address) = LOWER(?) AND wallet_verified_at IS NOT NULL", address).
+		First(&user).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// LinkWallet gán địa chỉ ví đã xác thực chữ ký cho user và ghi event UserWalletChanged
+// để payment-service bắt đầu theo dõi tiền nạp vào ví này. Trả về ErrWalletTaken nếu ví
+// đã được xác thực cho user khác (unique index idx_users_verified_wallet).
+func (r *UserRepository) LinkWallet(userID uint, address string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.User{}).
+			Where("id = ?", userID).
+			Updates(map[string]interface{}{
+				"wallet_address":     address,
+				"wallet_verified_at": now,
+			})
+		if isUniqueViolation(result.Error, verifiedWalletIndex) {
+			return ErrWalletTaken
+		}
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return outbox.Enqueue(tx, events.UserWalletChanged{
+			UserID:        userID,
+			WalletAddress: address,
+			Verified:      true,
+			ChangedAt:     now,
+		})
+	})
+}
+
+// isUniqueViolation cho biết err là lỗi vi phạm unique index index của Postgres
+func isUniqueViolation(err error, index string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == index
+}
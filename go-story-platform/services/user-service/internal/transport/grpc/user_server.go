@@ -0,0 +1,95 @@
+// Package grpc phục vụ API gRPC nội bộ của user-service cho các service khác
+// (hợp đồng ở shared/rpc/userpb)
+package grpc
+
+import (
+	"context"
+	"errors"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+
+	"shared/rpc/userpb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// maxBatchUsers là số ID tối đa mỗi lần gọi BatchGetUsers
+const maxBatchUsers = 100
+
+// UserServer implement userpb.UserServiceServer
+type UserServer struct {
+	userpb.UnimplementedUserServiceServer
+	repo *repository.UserRepository
+}
+
+// NewUserServer tạo UserServer với repo được truyền vào
+func NewUserServer(repo *repository.UserRepository) *UserServer {
+	return &UserServer{repo: repo}
+}
+
+// GetUser lấy thông tin công khai của user theo ID
+func (s *UserServer) GetUser(ctx context.Context, req *userpb.GetUserRequest) (*userpb.User, error) {
+	user, err := s.repo.GetUserByID(uint(req.GetId()))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "Người dùng không tồn tại")
+		}
+		return nil, status.Error(codes.Internal, "Lỗi server")
+	}
+	return toUserMessage(user), nil
+}
+
+// BatchGetUsers lấy nhiều user một lần, ID không tồn tại bị bỏ qua
+func (s *UserServer) BatchGetUsers(ctx context.Context, req *userpb.BatchGetUsersRequest) (*userpb.BatchGetUsersResponse, error) {
+	if len(req.GetIds()) > maxBatchUsers {
+		return nil, status.Errorf(codes.InvalidArgument, "Tối đa %d ID mỗi lần", maxBatchUsers)
+	}
+	if len(req.GetIds()) == 0 {
+		return &userpb.BatchGetUsersResponse{}, nil
+	}
+
+	ids := make([]uint, len(req.GetIds()))
+	for i, id := range req.GetIds() {
+		ids[i] = uint(id)
+	}
+	users, err := s.repo.GetUsersByIDs(ids)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Lỗi server")
+	}
+
+	resp := &userpb.BatchGetUsersResponse{Users: make([]*userpb.User, len(users))}
+	for i := range users {
+		resp.Users[i] = toUserMessage(&users[i])
+	}
+	return resp, nil
+}
+
+// ResolveWallet tìm user đã xác thực sở hữu địa chỉ ví
+func (s *UserServer) ResolveWallet(ctx context.Context, req *userpb.ResolveWalletRequest) (*userpb.User, error) {
+	if req.GetAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Địa chỉ ví không được để trống")
+	}
+
+	user, err := s.repo.GetUserByVerifiedWallet(req.GetAddress())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Lỗi server")
+	}
+	if user == nil {
+		return nil, status.Error(codes.NotFound, "Chưa có người dùng liên kết ví này")
+	}
+	return toUserMessage(user), nil
+}
+
+// toUserMessage chuyển models.User sang message gRPC, bỏ email và mật khẩu
+func toUserMessage(user *models.User) *userpb.User {
+	return &userpb.User{
+		Id:             uint64(user.ID),
+		Username:       user.Username,
+		Role:           user.Role,
+		WalletAddress:  user.WalletAddress,
+		WalletVerified: user.WalletVerifiedAt != nil,
+		Avatar:         user.Profile.Avatar,
+	}
+}
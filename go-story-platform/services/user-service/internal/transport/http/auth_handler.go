@@ -1,13 +1,17 @@
 package http
 
 import (
+	"errors"
 	"net/http"
-	"os"
+	"time"
+	"user-service/internal/models"
+	"user-service/internal/repository"
 	"user-service/internal/transport/http/dto"
 	"user-service/pkg/auth"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 // Login User godoc
@@ -21,42 +25,23 @@ import (
 func (h *UserHandler) Login(c *gin.Context) {
 	var loginReq dto.LoginRequest
 	if err := c.ShouldBindJSON(&loginReq); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		invalidInput(c, err, "Dữ liệu không hợp lệ")
 		return
 	}
 	user, err := h.repo.GetUserByUsername(loginReq.Username)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi server: " + err.Error()})
+		serverError(c, err, "Lỗi server")
 		return
 	}
 	if user == nil || !checkPasswordHash(loginReq.Password, user.PasswordHash) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Email hoặc mật khẩu không đúng"})
+		c.JSON(http.StatusUnauthorized, dto.ApiResponse{Success: false, Message: "Email hoặc mật khẩu không đúng"})
 		return
 	}
-	jwtSvc := auth.NewJWTService(
-		os.Getenv("JWT_SECRET"),
-		os.Getenv("ISSUER"),
-	)
-	token, err := jwtSvc.GenerateToken(user.ID, user.Role)
+	data, err := h.issueLoginTokens(user)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể tạo token: " + err.Error()})
+		serverError(c, err, "Không thể tạo token")
 		return
 	}
-	data := dto.LoginResponse{
-		AccessToken: token,
-		User: dto.UserResponse{
-			Username:      user.Username,
-			Email:         user.Email,
-			WalletAddress: user.WalletAddress,
-			Role:          user.Role,
-			Profile: dto.ProfileResponse{
-				Avatar:      user.Profile.Avatar,
-				Bio:         user.Profile.Bio,
-				Preferences: user.Profile.Preferences,
-			},
-			CreatedAt: user.CreatedAt,
-		},
-	}
 	c.JSON(http.StatusOK, dto.ApiResponse{
 		Success: true,
 		Message: "Đăng nhập thành công",
@@ -64,6 +49,175 @@ func (h *UserHandler) Login(c *gin.Context) {
 	})
 }
 
+// RefreshToken godoc
+// @Summary Làm mới access token
+// @Description Đổi refresh token lấy cặp token mới. Refresh token cũ bị thu hồi ngay (rotation);
+// @Description nếu một token đã thu hồi bị gửi lại thì toàn bộ family token bị thu hồi.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body dto.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} dto.ApiResponse
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 401 {object} dto.ApiResponse
+// @Router /auth/refresh [post]
+func (h *UserHandler) RefreshToken(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.RefreshToken == "" {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{
+			Success: false,
+			Message: "Refresh token không được để trống",
+		})
+		return
+	}
+
+	stored, err := h.tokenRepo.GetRefreshTokenByHash(auth.HashToken(req.RefreshToken))
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	if stored == nil {
+		c.JSON(http.StatusUnauthorized, dto.ApiResponse{Success: false, Message: "Refresh token không hợp lệ"})
+		return
+	}
+
+	// Token đã bị thu hồi mà vẫn được gửi lên => có thể đã bị đánh cắp, thu hồi cả family
+	if stored.RevokedAt != nil {
+		h.revokeReusedFamily(c, stored.FamilyID)
+		return
+	}
+	if !stored.IsActive(time.Now()) {
+		c.JSON(http.StatusUnauthorized, dto.ApiResponse{Success: false, Message: "Refresh token đã hết hạn"})
+		return
+	}
+
+	user, err := h.repo.GetUserByID(stored.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			_ = h.tokenRepo.RevokeFamily(stored.FamilyID)
+			c.JSON(http.StatusUnauthorized, dto.ApiResponse{Success: false, Message: "Người dùng không tồn tại"})
+			return
+		}
+		serverError(c, err, "Lỗi server")
+		return
+	}
+
+	refreshToken, refreshHash, expiresAt, err := h.jwtSvc.GenerateRefreshToken()
+	if err != nil {
+		serverError(c, err, "Không thể tạo token")
+		return
+	}
+	next := models.RefreshToken{TokenHash: refreshHash, ExpiresAt: expiresAt}
+	if err := h.tokenRepo.RotateRefreshToken(stored, &next); err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenReused) {
+			h.revokeReusedFamily(c, stored.FamilyID)
+			return
+		}
+		serverError(c, err, "Không thể tạo token")
+		return
+	}
+
+	accessToken, err := h.jwtSvc.GenerateToken(user.ID, user.Role)
+	if err != nil {
+		serverError(c, err, "Không thể tạo token")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Làm mới token thành công",
+		Data: dto.RefreshTokenResponse{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+			ExpiresIn:    h.jwtSvc.TokenDuration * 60,
+		},
+	})
+}
+
+// Logout godoc
+// @Summary Đăng xuất
+// @Description Thu hồi refresh token được gửi lên cùng toàn bộ token thuộc cùng phiên đăng nhập
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body dto.LogoutRequest true "Refresh token"
+// @Success 200 {object} dto.ApiResponse
+// @Failure 400 {object} dto.ApiResponse
+// @Router /auth/logout [post]
+func (h *UserHandler) Logout(c *gin.Context) {
+	var req dto.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.RefreshToken == "" {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{
+			Success: false,
+			Message: "Refresh token không được để trống",
+		})
+		return
+	}
+
+	stored, err := h.tokenRepo.GetRefreshTokenByHash(auth.HashToken(req.RefreshToken))
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	// Token không tồn tại vẫn trả về thành công để logout luôn idempotent
+	if stored != nil {
+		if err := h.tokenRepo.RevokeFamily(stored.FamilyID); err != nil {
+			serverError(c, err, "Lỗi server")
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Đăng xuất thành công",
+	})
+}
+
+// issueLoginTokens cấp access token và mở family refresh token mới cho user vừa đăng nhập
+// (bằng mật khẩu hoặc bằng ví qua SIWE)
+func (h *UserHandler) issueLoginTokens(user *models.User) (*dto.LoginResponse, error) {
+	token, err := h.jwtSvc.GenerateToken(user.ID, user.Role)
+	if err != nil {
+		return nil, err
+	}
+	// Mỗi lần đăng nhập mở một family refresh token mới
+	familyID, err := auth.NewTokenFamily()
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, refreshHash, expiresAt, err := h.jwtSvc.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := h.tokenRepo.CreateRefreshToken(&models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: refreshHash,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResponse{
+		AccessToken: token,
+		RefeshToken: refreshToken,
+		ExpiresIn:   h.jwtSvc.TokenDuration * 60,
+		User:        toUserResponse(user),
+	}, nil
+}
+
+// revokeReusedFamily thu hồi cả family khi phát hiện refresh token bị dùng lại và trả về 401
+func (h *UserHandler) revokeReusedFamily(c *gin.Context, familyID string) {
+	if err := h.tokenRepo.RevokeFamily(familyID); err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	c.JSON(http.StatusUnauthorized, dto.ApiResponse{
+		Success: false,
+		Message: "Refresh token đã được sử dụng, vui lòng đăng nhập lại",
+	})
+}
+
 // checkPasswordHash kiểm tra mật khẩu đã băm với mật khẩu gốc
 func checkPasswordHash(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
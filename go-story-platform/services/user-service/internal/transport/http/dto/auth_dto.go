@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type LoginRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
@@ -8,9 +10,37 @@ type LoginRequest struct {
 type LoginResponse struct {
 	AccessToken string       `json:"access_token"`
 	RefeshToken string       `json:"refesh_token"`
+	ExpiresIn   int64        `json:"expires_in"` // Thời hạn access token, tính bằng giây
 	User        UserResponse `json:"user"`
 }
 
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RefreshTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type SiweNonceResponse struct {
+	Nonce     string    `json:"nonce"`
+	Domain    string    `json:"domain"`
+	URI       string    `json:"uri"` // Origin mà URI trong message phải trùng scheme và host
+	ChainID   int64     `json:"chain_id,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type SiweVerifyRequest struct {
+	Message   string `json:"message"`   // Message EIP-4361 nguyên văn mà ví đã ký
+	Signature string `json:"signature"` // Chữ ký personal_sign dạng hex 0x...
+}
+
 type ForgotPasswordRequest struct {
 	Email string `json:"email"`
 }
@@ -10,11 +10,30 @@ type RegisterRequest struct {
 	Profile       ProfileRequest `string:"profile"`
 }
 
+// UpdateUserRequest là thông tin user được sửa qua PUT /users/{id}. Role chỉ có hiệu lực với
+// người có quyền đổi vai trò; đổi địa chỉ ví thì ví mất trạng thái đã xác thực.
+type UpdateUserRequest struct {
+	Username      string         `json:"username"`
+	Email         string         `json:"email"`
+	WalletAddress string         `json:"wallet_address"`
+	Role          string         `json:"role"`
+	Profile       ProfileRequest `json:"profile"`
+}
+
 type UserResponse struct {
-	Username      string          `json:"username"`
-	Email         string          `json:"email"`
-	WalletAddress string          `json:"wallet_address"`
-	Role          string          `json:"role"`
-	Profile       ProfileResponse `json:"profile_response"`
-	CreatedAt     time.Time       `json:"created_at"`
+	ID             uint            `json:"id"`
+	Username       string          `json:"username"`
+	Email          string          `json:"email"`
+	WalletAddress  string          `json:"wallet_address"`
+	WalletVerified bool            `json:"wallet_verified"`
+	Role           string          `json:"role"`
+	Profile        ProfileResponse `json:"profile_response"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type UserListResponse struct {
+	Items      []UserResponse `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty"` // Rỗng khi đã hết dữ liệu
+	HasMore    bool           `json:"has_more"`
+	Total      *int64         `json:"total,omitempty"` // Chỉ có khi include_total=true
 }
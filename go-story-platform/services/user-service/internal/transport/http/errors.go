@@ -0,0 +1,24 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"user-service/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serverError ghi lỗi nội bộ err vào log (kèm request_id, route và user_id của request) rồi trả 500
+// với message an toàn cho client. Chi tiết lỗi (câu SQL, email trong lỗi unique...) chỉ nằm trong log.
+func serverError(c *gin.Context, err error, message string) {
+	slog.ErrorContext(c.Request.Context(), message, "error", err)
+	c.JSON(http.StatusInternalServerError, dto.ApiResponse{Success: false, Message: message})
+}
+
+// invalidInput trả 400 với message cố định cho client; chi tiết lỗi đọc body (tên field, kiểu dữ liệu...)
+// được gắn vào request và ghi trong access log
+func invalidInput(c *gin.Context, err error, message string) {
+	_ = c.Error(err)
+	c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: message})
+}
@@ -2,16 +2,20 @@ package middleware
 
 import (
 	"net/http"
-	"os"
 	"strings"
 
 	"user-service/internal/transport/http/dto"
-	"user-service/pkg/auth"
+
+	"shared/auth"
+	"shared/identity"
+	"shared/logging"
 
 	"github.com/gin-gonic/gin"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+// AuthMiddleware xác thực access token và lưu userID, userRole vào context.
+// Request đi qua API gateway được nhận bằng header danh tính do gateway ký; gateway nil thì luôn kiểm JWT.
+func AuthMiddleware(verifier *auth.Verifier, gateway *identity.Signer) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Lấy header Authorization: Bearer <token>
 		authHeader := c.GetHeader("Authorization")
@@ -31,20 +35,33 @@ func AuthMiddleware() gin.HandlerFunc {
 			})
 			return
 		}
+		// Request đi qua API gateway: token đã được kiểm ở gateway, chỉ cần kiểm chữ ký header danh tính
+		gatewayIdentity, err := gateway.Verify(c.Request)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ApiResponse{
+				Success: false,
+				Message: "Danh tính từ gateway không hợp lệ",
+			})
+			return
+		}
+		if gatewayIdentity != nil {
+			logging.SetUserID(c.Request.Context(), gatewayIdentity.UserID)
+			c.Set("userID", gatewayIdentity.UserID)
+			c.Set("userRole", gatewayIdentity.Role)
+			c.Next()
+			return
+		}
 		//Gọi service để xác thực
-		jwtSvc := auth.NewJWTService(
-			os.Getenv("JWT_SECRET"),
-			os.Getenv("ISSUER"),
-		)
-		claims, err := jwtSvc.ValidateToken(parts[1])
+		claims, err := verifier.Verify(parts[1])
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ApiResponse{
 				Success: false,
-				Message: "Token không hợp lệ: " + err.Error(),
+				Message: "Token không hợp lệ hoặc đã hết hạn",
 			})
 			return
 		}
-		// Lưu thông tin user vào context để các handler khác sử dụng
+		// Lưu thông tin user vào context để các handler khác sử dụng, và gắn user vào log của request
+		logging.SetUserID(c.Request.Context(), claims.UserID)
 		c.Set("userID", claims.UserID)
 		c.Set("userRole", claims.Role)
 
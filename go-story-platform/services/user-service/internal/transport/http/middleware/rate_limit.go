@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"user-service/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit giới hạn mỗi IP client tối đa limit request trong mỗi khoảng window, vượt quá thì trả 429.
+// Bộ đếm nằm trong bộ nhớ nên mỗi instance đếm riêng; dùng cho các endpoint công khai có ghi DB.
+func RateLimit(limit int, window time.Duration) gin.HandlerFunc {
+	type counter struct {
+		start time.Time
+		count int
+	}
+	var mu sync.Mutex
+	counters := make(map[string]*counter)
+	lastSweep := time.Now()
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		ip := c.ClientIP()
+
+		mu.Lock()
+		// Mỗi window dọn các bộ đếm đã hết hạn để map không lớn dần theo số IP
+		if now.Sub(lastSweep) >= window {
+			for key, ct := range counters {
+				if now.Sub(ct.start) >= window {
+					delete(counters, key)
+				}
+			}
+			lastSweep = now
+		}
+		ct := counters[ip]
+		if ct == nil || now.Sub(ct.start) >= window {
+			ct = &counter{start: now}
+			counters[ip] = ct
+		}
+		ct.count++
+		retryAfter := window - now.Sub(ct.start)
+		allowed := ct.count <= limit
+		mu.Unlock()
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, dto.ApiResponse{
+				Success: false,
+				Message: "Bạn gửi quá nhiều yêu cầu, vui lòng thử lại sau",
+			})
+			return
+		}
+		c.Next()
+	}
+}
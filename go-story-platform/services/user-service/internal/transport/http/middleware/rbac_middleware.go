@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"user-service/internal/transport/http/dto"
+	"user-service/pkg/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Rule là một điều kiện phân quyền được đánh giá trên request hiện tại.
+// Các Rule được ghép với nhau bằng AnyOf/AllOf rồi gắn vào route qua Authorize.
+type Rule func(c *gin.Context) bool
+
+// Authorize trả về middleware chặn request bằng 403 nếu rule không thỏa.
+// Phải đặt sau AuthMiddleware vì cần userID và userRole trong context.
+func Authorize(rule Rule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, exists := c.Get("userRole"); !exists {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ApiResponse{
+				Success: false,
+				Message: "Bạn cần đăng nhập để truy cập",
+			})
+			return
+		}
+		if !rule(c) {
+			c.AbortWithStatusJSON(http.StatusForbidden, dto.ApiResponse{
+				Success: false,
+				Message: "Bạn không có quyền thực hiện thao tác này",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// HasPermission thỏa khi vai trò của user có quyền perm
+func HasPermission(perm auth.Permission) Rule {
+	return func(c *gin.Context) bool {
+		return auth.HasPermission(c.GetString("userRole"), perm)
+	}
+}
+
+// HasRole thỏa khi user có một trong các vai trò được liệt kê
+func HasRole(roles ...string) Rule {
+	return func(c *gin.Context) bool {
+		role := c.GetString("userRole")
+		for _, r := range roles {
+			if r == role {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// IsSelf thỏa khi path param (ví dụ ":id") trùng với ID của user đang đăng nhập
+func IsSelf(param string) Rule {
+	return func(c *gin.Context) bool {
+		id, err := strconv.ParseUint(c.Param(param), 10, 64)
+		if err != nil {
+			return false
+		}
+		return CurrentUserID(c) == uint(id)
+	}
+}
+
+// AnyOf thỏa khi ít nhất một rule thỏa
+func AnyOf(rules ...Rule) Rule {
+	return func(c *gin.Context) bool {
+		for _, rule := range rules {
+			if rule(c) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AllOf thỏa khi tất cả rule đều thỏa
+func AllOf(rules ...Rule) Rule {
+	return func(c *gin.Context) bool {
+		for _, rule := range rules {
+			if !rule(c) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// SelfOr là chính sách "chính chủ hoặc có quyền perm", dùng cho các route /users/:id
+func SelfOr(param string, perm auth.Permission) Rule {
+	return AnyOf(IsSelf(param), HasPermission(perm))
+}
+
+// CurrentUserID lấy ID của user đang đăng nhập do AuthMiddleware lưu vào context
+func CurrentUserID(c *gin.Context) uint {
+	if v, exists := c.Get("userID"); exists {
+		if id, ok := v.(uint); ok {
+			return id
+		}
+	}
+	return 0
+}
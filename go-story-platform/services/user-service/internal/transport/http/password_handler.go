@@ -0,0 +1,157 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+	"user-service/internal/mailer"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+	"user-service/internal/transport/http/dto"
+	"user-service/pkg/auth"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// resetTokenTTL là thời gian sống của link đặt lại mật khẩu
+const resetTokenTTL = 30 * time.Minute
+
+// ForgotPassword godoc
+// @Summary Quên mật khẩu
+// @Description Gửi link đặt lại mật khẩu tới email. Luôn trả về thành công để không lộ email nào đã đăng ký.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body dto.ForgotPasswordRequest true "Email đăng ký"
+// @Success 200 {object} dto.ApiResponse
+// @Failure 400 {object} dto.ApiResponse
+// @Router /auth/forgot-password [post]
+func (h *UserHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Email == "" {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{
+			Success: false,
+			Message: "Email không được để trống",
+		})
+		return
+	}
+
+	okResponse := dto.ApiResponse{
+		Success: true,
+		Message: "Nếu email đã được đăng ký, bạn sẽ nhận được hướng dẫn đặt lại mật khẩu",
+	}
+
+	user, err := h.repo.GetUserByEmail(req.Email)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	if user == nil {
+		c.JSON(http.StatusOK, okResponse)
+		return
+	}
+
+	token, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	if err := h.resetRepo.CreateResetToken(&models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(resetTokenTTL),
+	}); err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+
+	// Lỗi gửi mail chỉ ghi log, response vẫn giống hệt trường hợp email không tồn tại
+	if err := h.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Đặt lại mật khẩu",
+		Body: "Xin chào " + user.Username + ",\n\n" +
+			"Bấm vào link sau để đặt lại mật khẩu (hết hạn sau 30 phút):\n" +
+			h.resetPasswordLink(token) + "\n\n" +
+			"Nếu bạn không yêu cầu, hãy bỏ qua email này.",
+	}); err != nil {
+		slog.ErrorContext(c.Request.Context(), "Không thể gửi email đặt lại mật khẩu", "error", err)
+	}
+
+	c.JSON(http.StatusOK, okResponse)
+}
+
+// ResetPassword godoc
+// @Summary Đặt lại mật khẩu
+// @Description Đặt mật khẩu mới bằng token trong email. Token chỉ dùng được một lần và mọi phiên đăng nhập cũ bị thu hồi.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body dto.ResetPasswordRequest true "Token và mật khẩu mới"
+// @Success 200 {object} dto.ApiResponse
+// @Failure 400 {object} dto.ApiResponse
+// @Router /auth/reset-password [post]
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Token == "" {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{
+			Success: false,
+			Message: "Token không được để trống",
+		})
+		return
+	}
+	if req.NewPassword != req.ConfirmPassword {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{
+			Success: false,
+			Message: "Mật khẩu xác nhận không khớp",
+		})
+		return
+	}
+	if msg := validatePassword(req.NewPassword); msg != "" {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: msg})
+		return
+	}
+
+	token, err := h.resetRepo.GetResetTokenByHash(auth.HashToken(req.Token))
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	if token == nil || !token.IsUsable(time.Now()) {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{
+			Success: false,
+			Message: "Token không hợp lệ hoặc đã hết hạn",
+		})
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		serverError(c, err, "Không thể xử lý mật khẩu")
+		return
+	}
+
+	if err := h.resetRepo.ResetPassword(token, string(hashedPassword)); err != nil {
+		if errors.Is(err, repository.ErrResetTokenUsed) {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{
+				Success: false,
+				Message: "Token không hợp lệ hoặc đã hết hạn",
+			})
+			return
+		}
+		serverError(c, err, "Lỗi server")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Đặt lại mật khẩu thành công, vui lòng đăng nhập lại",
+	})
+}
+
+// resetPasswordLink ghép token vào URL trang đặt lại mật khẩu của frontend (RESET_PASSWORD_URL)
+func (h *UserHandler) resetPasswordLink(token string) string {
+	return h.cfg.ResetPasswordURL + "?token=" + url.QueryEscape(token)
+}
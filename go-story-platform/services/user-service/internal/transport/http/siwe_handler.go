@@ -0,0 +1,217 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+	"user-service/internal/transport/http/dto"
+	"user-service/internal/transport/http/middleware"
+	"user-service/pkg/auth"
+	"user-service/pkg/siwe"
+
+	"github.com/gin-gonic/gin"
+)
+
+// siweNonceTTL là thời gian client có để ký và gửi message sau khi lấy nonce
+const siweNonceTTL = 10 * time.Minute
+
+// SiweNonce godoc
+// @Summary Lấy nonce cho Sign-In with Ethereum
+// @Description Cấp nonce dùng một lần để client đưa vào message EIP-4361 trước khi ký bằng ví
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} dto.ApiResponse{data=dto.SiweNonceResponse}
+// @Failure 429 {object} dto.ApiResponse
+// @Router /auth/siwe/nonce [get]
+func (h *UserHandler) SiweNonce(c *gin.Context) {
+	nonce, err := newSiweNonce()
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+
+	record := models.SiweNonce{Nonce: nonce, ExpiresAt: time.Now().Add(siweNonceTTL)}
+	if err := h.siweRepo.CreateNonce(&record); err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+
+	domain, chainID := h.cfg.SiweDomain, h.cfg.SiweChainID
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy nonce thành công",
+		Data: dto.SiweNonceResponse{
+			Nonce:     record.Nonce,
+			Domain:    domain,
+			URI:       h.cfg.SiweURI,
+			ChainID:   chainID,
+			ExpiresAt: record.ExpiresAt,
+		},
+	})
+}
+
+// SiweVerify godoc
+// @Summary Đăng nhập bằng ví (Sign-In with Ethereum)
+// @Description Xác thực message EIP-4361 và chữ ký personal_sign, sau đó cấp token giống /auth/login.
+// @Description Ví phải được liên kết và xác thực với tài khoản trước qua POST /users/{id}/wallet.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body dto.SiweVerifyRequest true "Message và chữ ký"
+// @Success 200 {object} dto.ApiResponse{data=dto.LoginResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 401 {object} dto.ApiResponse
+// @Router /auth/siwe/verify [post]
+func (h *UserHandler) SiweVerify(c *gin.Context) {
+	address, ok := h.verifySiwe(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.repo.GetUserByVerifiedWallet(address)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, dto.ApiResponse{
+			Success: false,
+			Message: "Ví chưa được liên kết với tài khoản nào",
+		})
+		return
+	}
+
+	data, err := h.issueLoginTokens(user)
+	if err != nil {
+		serverError(c, err, "Không thể tạo token")
+		return
+	}
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Đăng nhập thành công",
+		Data:    data,
+	})
+}
+
+// LinkWallet godoc
+// @Summary Liên kết và xác thực ví cho tài khoản
+// @Description User đang đăng nhập ký message SIWE bằng ví để chứng minh sở hữu; địa chỉ ví trong message
+// @Description được lưu vào WalletAddress và đánh dấu đã xác thực.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param body body dto.SiweVerifyRequest true "Message và chữ ký"
+// @Success 200 {object} dto.ApiResponse{data=dto.UserResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 409 {object} dto.ApiResponse
+// @Router /users/{id}/wallet [post]
+func (h *UserHandler) LinkWallet(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "ID không hợp lệ"})
+		return
+	}
+
+	address, ok := h.verifySiwe(c)
+	if !ok {
+		return
+	}
+
+	owner, err := h.repo.GetUserByVerifiedWallet(address)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	if owner != nil && owner.ID != middleware.CurrentUserID(c) {
+		c.JSON(http.StatusConflict, dto.ApiResponse{
+			Success: false,
+			Message: "Ví đã được liên kết với tài khoản khác",
+		})
+		return
+	}
+
+	if err := h.repo.LinkWallet(uint(id), address); err != nil {
+		if errors.Is(err, repository.ErrWalletTaken) {
+			c.JSON(http.StatusConflict, dto.ApiResponse{Success: false, Message: err.Error()})
+			return
+		}
+		serverError(c, err, "Lỗi server")
+		return
+	}
+	user, err := h.repo.GetUserByID(uint(id))
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Liên kết ví thành công",
+		Data:    toUserResponse(user),
+	})
+}
+
+// verifySiwe đọc body SiweVerifyRequest, kiểm tra message, xác thực chữ ký rồi tiêu thụ nonce.
+// Trả về địa chỉ ví dạng checksum; khi thất bại đã tự ghi response lỗi và trả về ok = false.
+func (h *UserHandler) verifySiwe(c *gin.Context) (address string, ok bool) {
+	var req dto.SiweVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Message == "" || req.Signature == "" {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{
+			Success: false,
+			Message: "Message và chữ ký không được để trống",
+		})
+		return "", false
+	}
+
+	msg, err := siwe.ParseMessage(req.Message)
+	if err != nil {
+		invalidInput(c, err, siwe.ErrInvalidMessage.Error())
+		return "", false
+	}
+
+	domain, chainID := h.cfg.SiweDomain, h.cfg.SiweChainID
+	if err := msg.Validate(siwe.ValidateOptions{
+		Domain:  domain,
+		URI:     h.cfg.SiweURI,
+		ChainID: chainID,
+		Now:     time.Now(),
+	}); err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ApiResponse{Success: false, Message: err.Error()})
+		return "", false
+	}
+
+	// Kiểm tra chữ ký trước khi tiêu thụ nonce để request giả mạo không đốt được nonce của người khác
+	if err := siwe.VerifySignature(req.Message, req.Signature, msg.Address); err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ApiResponse{Success: false, Message: err.Error()})
+		return "", false
+	}
+
+	consumed, err := h.siweRepo.ConsumeNonce(msg.Nonce)
+	if err != nil {
+		serverError(c, err, "Lỗi server")
+		return "", false
+	}
+	if !consumed {
+		c.JSON(http.StatusUnauthorized, dto.ApiResponse{
+			Success: false,
+			Message: "Nonce không tồn tại, đã được dùng hoặc đã hết hạn",
+		})
+		return "", false
+	}
+
+	return siwe.ChecksumAddress(msg.Address), true
+}
+
+// newSiweNonce tạo nonce ngẫu nhiên chữ và số theo yêu cầu của EIP-4361 (tối thiểu 8 ký tự)
+func newSiweNonce() (string, error) {
+	token, _, err := auth.NewOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	return token[:32], nil
+}
@@ -2,26 +2,56 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 	"unicode"
+	"user-service/internal/mailer"
 	"user-service/internal/models"
 	"user-service/internal/repository"
 	"user-service/internal/transport/http/dto"
+	"user-service/pkg/auth"
+	"user-service/pkg/siwe"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Config là các URL và tham số đăng nhập mà handler cần biết về frontend
+type Config struct {
+	// Trang đặt lại mật khẩu của frontend, token được ghép vào query ?token=
+	ResetPasswordURL string `env:"RESET_PASSWORD_URL" default:"http://localhost:3000/reset-password"`
+	// Domain, origin của URI và chain ID mà thông điệp SIWE phải khớp; chain ID 0 là không kiểm tra
+	SiweDomain  string `env:"SIWE_DOMAIN" default:"localhost:3000"`
+	SiweURI     string `env:"SIWE_URI" default:"http://localhost:3000"`
+	SiweChainID int64  `env:"SIWE_CHAIN_ID"`
+}
+
 // UserHandler  Xử lý các request liên quan đến User
 type UserHandler struct {
-	repo *repository.UserRepository
+	repo      *repository.UserRepository
+	tokenRepo *repository.RefreshTokenRepository
+	resetRepo *repository.PasswordResetRepository
+	siweRepo  *repository.SiweRepository
+	mailer    mailer.Mailer
+	jwtSvc    *auth.JWTService
+	cfg       Config
 }
 
-// NewUserHandler tạo UserHandler với repo được truyền vào
-func NewUserHandler(repo *repository.UserRepository) *UserHandler {
-	return &UserHandler{repo: repo}
+// NewUserHandler tạo UserHandler với các repo, mailer, JWTService và cấu hình được truyền vào
+func NewUserHandler(
+	repo *repository.UserRepository,
+	tokenRepo *repository.RefreshTokenRepository,
+	resetRepo *repository.PasswordResetRepository,
+	siweRepo *repository.SiweRepository,
+	mail mailer.Mailer,
+	jwtSvc *auth.JWTService,
+	cfg Config,
+) *UserHandler {
+	return &UserHandler{repo: repo, tokenRepo: tokenRepo, resetRepo: resetRepo, siweRepo: siweRepo, mailer: mail, jwtSvc: jwtSvc, cfg: cfg}
 }
 
 // CreateUser : POST /users Tạo user mới và profile trống kèm theo
@@ -42,87 +72,69 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 	// Bind JSON từ request body vào biến input
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		invalidInput(c, err, "Dữ liệu không hợp lệ")
 		return
 	}
 
 	// Username không được để trống
 	if len(input.Username) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Username không được để trống"})
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Username không được để trống"})
 		return
 	}
 
 	// Username phải có ít nhất 8 ký tự
 	if len(input.Username) < 8 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Username phải có ít nhất 8 ký tự"})
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Username phải có ít nhất 8 ký tự"})
 		return
 	}
 
 	// Username đã tồn tại
 	existingUserByUsername, err := h.repo.GetUserByUsername(input.Username)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi server"})
+		serverError(c, err, "Lỗi server")
 		return
 	}
 	if existingUserByUsername != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Username đã tồn tại"})
-		return
-	}
-
-	// Mật khẩu không được để trống
-	if len(input.Password) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Mật khẩu không được để trống"})
-		return
-	}
-
-	// Mật khẩu phải có ít nhất 8 ký tự
-	if len(input.Password) < 8 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Mật khẩu phải có ít nhất 8 ký tự"})
-		return
-	}
-
-	// Mật khẩu phải có ít nhất 1 chữ hoa
-	hasUpper := false
-	for _, c := range input.Password {
-		if unicode.IsUpper(c) {
-			hasUpper = true
-			break
-		}
-	}
-	if !hasUpper {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Mật khẩu phải có ít nhất 1 chữ hoa và 1 ký tự đặc biệt"})
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Username đã tồn tại"})
 		return
 	}
 
-	// Mật khẩu phải có ký tự đặc biệt
-	specialCharRegex := regexp.MustCompile(`[^a-zA-Z0-9]`)
-	if !specialCharRegex.MatchString(input.Password) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Mật khẩu phải có ít nhất 1 chữ hoa và 1 ký tự đặc biệt"})
+	// Kiểm tra độ mạnh mật khẩu
+	if msg := validatePassword(input.Password); msg != "" {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: msg})
 		return
 	}
 
 	// Email phải đúng định dạng
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	if !emailRegex.MatchString(input.Email) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Email không hợp lệ"})
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Email không hợp lệ"})
 		return
 	}
 
 	// Email phải là duy nhất
 	existingUser, err := h.repo.GetUserByEmail(input.Email)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi server"})
+		serverError(c, err, "Lỗi server")
 		return
 	}
 	if existingUser != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Email đã được sử dụng"})
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Email đã được sử dụng"})
 		return
 	}
 
+	// Địa chỉ ví (nếu có) phải đúng định dạng; ví chỉ được coi là đã xác thực sau khi ký SIWE
+	if input.WalletAddress != "" {
+		if !siwe.IsValidAddress(input.WalletAddress) {
+			c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Địa chỉ ví không hợp lệ"})
+			return
+		}
+		input.WalletAddress = siwe.ChecksumAddress(input.WalletAddress)
+	}
+
 	// 1. Mã hóa mật khẩu
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể xử lý mật khẩu"})
+		serverError(c, err, "Không thể xử lý mật khẩu")
 		return
 	}
 
@@ -139,22 +151,11 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	}
 
 	if err := h.repo.CreateUser(&user); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		serverError(c, err, "Không thể tạo tài khoản")
 		return
 	}
 
-	data := dto.UserResponse{
-		Username:      user.Username,
-		Email:         user.Email,
-		WalletAddress: user.WalletAddress,
-		Role:          user.Role,
-		Profile: dto.ProfileResponse{
-			Avatar:      user.Profile.Avatar,
-			Bio:         user.Profile.Bio,
-			Preferences: user.Profile.Preferences,
-		},
-		CreatedAt: user.CreatedAt,
-	}
+	data := toUserResponse(&user)
 
 	c.JSON(http.StatusCreated, dto.ApiResponse{
 		Success: true,
@@ -165,45 +166,99 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 // UpdateUser godoc
 // @Summary Cập nhật user
+// @Description Ghi đè username, email, ví, role và avatar/bio của profile. Role chỉ đổi được khi có quyền đổi vai trò.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
-// @Param user body models.User true "User data"
-// @Success 200 {object} dto.ApiResponse
+// @Param user body dto.UpdateUserRequest true "Thông tin user"
+// @Success 200 {object} dto.ApiResponse{data=dto.UserResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
+// @Failure 404 {object} dto.ApiResponse
+// @Failure 409 {object} dto.ApiResponse
 // @Router /users/{id} [put]
 func (h *UserHandler) UpdateUser(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID không hợp lệ"})
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "ID không hợp lệ"})
+		return
+	}
+	var input dto.UpdateUserRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Dữ liệu không hợp lệ"})
 		return
 	}
-	var user models.User
-	if err := c.ShouldBindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": " Cập nhật thất bại" + err.Error()})
+
+	existing, err := h.repo.GetUserByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Người dùng không tồn tại"})
 		return
 	}
 
-	user.ID = uint(id)
-	if err := h.repo.UpdateUser(&user); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Cập nhật thất bại" + err.Error()})
+	if len(input.Username) < 8 {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Username phải có ít nhất 8 ký tự"})
+		return
+	}
+	if input.Username != existing.Username {
+		taken, err := h.repo.GetUserByUsername(input.Username)
+		if err != nil {
+			serverError(c, err, "Lỗi server")
+			return
+		}
+		if taken != nil {
+			c.JSON(http.StatusConflict, dto.ApiResponse{Success: false, Message: "Username đã tồn tại"})
+			return
+		}
+	}
+	if !emailRegex.MatchString(input.Email) {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Email không hợp lệ"})
 		return
 	}
+	if !strings.EqualFold(input.Email, existing.Email) {
+		taken, err := h.repo.GetUserByEmail(input.Email)
+		if err != nil {
+			serverError(c, err, "Lỗi server")
+			return
+		}
+		if taken != nil {
+			c.JSON(http.StatusConflict, dto.ApiResponse{Success: false, Message: "Email đã được sử dụng"})
+			return
+		}
+	}
+	// Trạng thái xác thực ví chỉ được đặt qua SIWE; đổi địa chỉ ví thì mất trạng thái xác thực
+	if !strings.EqualFold(input.WalletAddress, existing.WalletAddress) {
+		if input.WalletAddress != "" {
+			if !siwe.IsValidAddress(input.WalletAddress) {
+				c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Địa chỉ ví không hợp lệ"})
+				return
+			}
+			input.WalletAddress = siwe.ChecksumAddress(input.WalletAddress)
+		}
+		existing.WalletAddress = input.WalletAddress
+		existing.WalletVerifiedAt = nil
+	}
+	// Chỉ người có quyền đổi vai trò mới được sửa Role, tránh user tự nâng quyền cho chính mình
+	if auth.HasPermission(c.GetString("userRole"), auth.PermUserSetRole) && auth.IsValidRole(input.Role) {
+		existing.Role = input.Role
+	}
 
-	data := dto.UserResponse{
-		Username:      user.Username,
-		Email:         user.Email,
-		WalletAddress: user.WalletAddress,
-		Role:          user.Role,
-		Profile: dto.ProfileResponse{
-			Avatar:      user.Profile.Avatar,
-			Bio:         user.Profile.Bio,
-			Preferences: user.Profile.Preferences,
-		},
-		CreatedAt: user.CreatedAt,
+	existing.Username = input.Username
+	existing.Email = input.Email
+	existing.Profile.Avatar = input.Profile.Avatar
+	existing.Profile.Bio = input.Profile.Bio
+	if err := h.repo.UpdateUser(existing); err != nil {
+		if errors.Is(err, repository.ErrUsernameTaken) || errors.Is(err, repository.ErrEmailTaken) {
+			c.JSON(http.StatusConflict, dto.ApiResponse{Success: false, Message: "Username hoặc email đã được sử dụng"})
+			return
+		}
+		serverError(c, err, "Cập nhật thất bại")
+		return
 	}
 
+	data := toUserResponse(existing)
+
 	c.JSON(http.StatusOK, dto.ApiResponse{
 		Success: true,
 		Message: "Cập nhật thành công",
@@ -215,34 +270,142 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 // @Summary Xóa user
 // @Tags Users
 // @Param id path int true "User ID"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
 // @Router /users/{id} [delete]
 func (h *UserHandler) DeleteUser(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
 
 	if err := h.repo.DeleteUser(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Xóa thất bại"})
+		serverError(c, err, "Xóa thất bại")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Đã xóa user ID " + strconv.Itoa(id)})
+	c.JSON(http.StatusOK, dto.ApiResponse{Success: true, Message: "Đã xóa user ID " + strconv.Itoa(id)})
 }
 
 // ListUsers godoc
 // @Summary Lấy danh sách users
+// @Description Phân trang theo cursor: lấy next_cursor của trang trước truyền vào cursor để lấy trang tiếp.
+// @Description Cursor gắn với sort/order nên phải giữ nguyên sort/order và bộ lọc khi lật trang.
 // @Tags Users
 // @Produce json
-// @Success 200 {array} models.User
+// @Param limit query int false "Số bản ghi mỗi trang (mặc định 20, tối đa 100)"
+// @Param cursor query string false "Cursor lấy từ next_cursor của trang trước"
+// @Param role query string false "Lọc theo role" Enums(reader, author, admin)
+// @Param created_from query string false "Tạo từ thời điểm (RFC3339, bao gồm)"
+// @Param created_to query string false "Tạo trước thời điểm (RFC3339, không bao gồm)"
+// @Param username_prefix query string false "Lọc username bắt đầu bằng chuỗi này"
+// @Param sort query string false "Cột sắp xếp (mặc định created_at)" Enums(created_at, username, id)
+// @Param order query string false "Chiều sắp xếp (mặc định desc)" Enums(asc, desc)
+// @Param include_total query bool false "Trả thêm tổng số bản ghi khớp bộ lọc"
+// @Success 200 {object} dto.ApiResponse{data=dto.UserListResponse}
+// @Failure 400 {object} dto.ApiResponse
+// @Failure 403 {object} dto.ApiResponse
 // @Failure 500 {object} dto.ApiResponse
 // @Router /users/ [get]
 func (h *UserHandler) ListUsers(c *gin.Context) {
-	users, err := h.repo.GetAllUsers()
+	query, msg := parseUserListQuery(c)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: msg})
+		return
+	}
+
+	users, hasMore, err := h.repo.ListUsers(query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể lấy danh sách người dùng"})
+		serverError(c, err, "Không thể lấy danh sách người dùng")
 		return
 	}
 
-	c.JSON(http.StatusOK, users)
+	data := dto.UserListResponse{
+		Items:   make([]dto.UserResponse, 0, len(users)),
+		HasMore: hasMore,
+	}
+	for i := range users {
+		data.Items = append(data.Items, toUserResponse(&users[i]))
+	}
+	if hasMore {
+		last := users[len(users)-1]
+		data.NextCursor = repository.NewUserCursor(query.SortBy, query.Desc, last.ID, last.Username, last.CreatedAt).Encode()
+	}
+
+	if includeTotal, _ := strconv.ParseBool(c.Query("include_total")); includeTotal {
+		total, err := h.repo.CountUsers(query)
+		if err != nil {
+			serverError(c, err, "Không thể lấy danh sách người dùng")
+			return
+		}
+		data.Total = &total
+	}
+
+	c.JSON(http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Message: "Lấy thành công",
+		Data:    data,
+	})
+}
+
+// parseUserListQuery đọc tham số phân trang/lọc/sắp xếp từ query string.
+// Trả về thông báo lỗi khác rỗng nếu tham số không hợp lệ.
+func parseUserListQuery(c *gin.Context) (repository.UserListQuery, string) {
+	query := repository.UserListQuery{
+		Role:           c.Query("role"),
+		UsernamePrefix: c.Query("username_prefix"),
+		SortBy:         repository.UserSort(c.DefaultQuery("sort", string(repository.UserSortCreatedAt))),
+		Limit:          20,
+	}
+
+	if query.Role != "" && !auth.IsValidRole(query.Role) {
+		return query, "Role không hợp lệ"
+	}
+	if !query.SortBy.IsValid() {
+		return query, "Cột sắp xếp không hợp lệ"
+	}
+
+	switch c.DefaultQuery("order", "desc") {
+	case "desc":
+		query.Desc = true
+	case "asc":
+		query.Desc = false
+	default:
+		return query, "Chiều sắp xếp không hợp lệ"
+	}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return query, "Limit không hợp lệ"
+		}
+		if limit > 100 {
+			limit = 100
+		}
+		query.Limit = limit
+	}
+
+	for param, target := range map[string]**time.Time{
+		"created_from": &query.CreatedFrom,
+		"created_to":   &query.CreatedTo,
+	} {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return query, param + " phải theo định dạng RFC3339"
+		}
+		*target = &t
+	}
+
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := repository.DecodeUserCursor(raw, query.SortBy, query.Desc)
+		if err != nil {
+			return query, "Cursor không hợp lệ hoặc không khớp cách sắp xếp"
+		}
+		query.After = cursor
+	}
+
+	return query, ""
 }
 
 // GetUserByEmail godoc
@@ -254,30 +417,19 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 func (h *UserHandler) GetUserByEmail(c *gin.Context) {
 	email := c.Param("email")
 	if email == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Username không được trống"})
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Email không được trống"})
 		return
 	}
 	user, err := h.repo.GetUserByEmail(email)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi server"})
+		serverError(c, err, "Lỗi server")
 		return
 	}
 	if user == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Người dùng không tồn tại"})
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Người dùng không tồn tại"})
 		return
 	}
-	data := dto.UserResponse{
-		Username:      user.Username,
-		Email:         user.Email,
-		WalletAddress: user.WalletAddress,
-		Role:          user.Role,
-		Profile: dto.ProfileResponse{
-			Avatar:      user.Profile.Avatar,
-			Bio:         user.Profile.Bio,
-			Preferences: user.Profile.Preferences,
-		},
-		CreatedAt: user.CreatedAt,
-	}
+	data := toUserResponse(user)
 
 	c.JSON(http.StatusOK, dto.ApiResponse{
 		Success: true,
@@ -295,31 +447,20 @@ func (h *UserHandler) GetUserByEmail(c *gin.Context) {
 func (h *UserHandler) GetUserByUsername(c *gin.Context) {
 	username := c.Param("username")
 	if username == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Username không được trống"})
+		c.JSON(http.StatusBadRequest, dto.ApiResponse{Success: false, Message: "Username không được trống"})
 		return
 	}
 
 	user, err := h.repo.GetUserByUsername(username)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi server"})
+		serverError(c, err, "Lỗi server")
 		return
 	}
 	if user == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Người dùng không tồn tại"})
+		c.JSON(http.StatusNotFound, dto.ApiResponse{Success: false, Message: "Người dùng không tồn tại"})
 		return
 	}
-	data := dto.UserResponse{
-		Username:      user.Username,
-		Email:         user.Email,
-		WalletAddress: user.WalletAddress,
-		Role:          user.Role,
-		Profile: dto.ProfileResponse{
-			Avatar:      user.Profile.Avatar,
-			Bio:         user.Profile.Bio,
-			Preferences: user.Profile.Preferences,
-		},
-		CreatedAt: user.CreatedAt,
-	}
+	data := toUserResponse(user)
 
 	c.JSON(http.StatusOK, dto.ApiResponse{
 		Success: true,
@@ -328,3 +469,58 @@ func (h *UserHandler) GetUserByUsername(c *gin.Context) {
 	})
 
 }
+
+// validatePassword kiểm tra độ mạnh mật khẩu, trả về thông báo lỗi hoặc chuỗi rỗng nếu hợp lệ.
+// Dùng chung cho đăng ký và đặt lại mật khẩu.
+func validatePassword(password string) string {
+	// Mật khẩu không được để trống
+	if len(password) == 0 {
+		return "Mật khẩu không được để trống"
+	}
+
+	// Mật khẩu phải có ít nhất 8 ký tự
+	if len(password) < 8 {
+		return "Mật khẩu phải có ít nhất 8 ký tự"
+	}
+
+	// Mật khẩu phải có ít nhất 1 chữ hoa
+	hasUpper := false
+	for _, c := range password {
+		if unicode.IsUpper(c) {
+			hasUpper = true
+			break
+		}
+	}
+	if !hasUpper {
+		return "Mật khẩu phải có ít nhất 1 chữ hoa và 1 ký tự đặc biệt"
+	}
+
+	// Mật khẩu phải có ký tự đặc biệt
+	if !specialCharRegex.MatchString(password) {
+		return "Mật khẩu phải có ít nhất 1 chữ hoa và 1 ký tự đặc biệt"
+	}
+
+	return ""
+}
+
+var specialCharRegex = regexp.MustCompile(`[^a-zA-Z0-9]`)
+
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+// toUserResponse chuyển models.User sang DTO trả về cho client
+func toUserResponse(user *models.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:             user.ID,
+		Username:       user.Username,
+		Email:          user.Email,
+		WalletAddress:  user.WalletAddress,
+		WalletVerified: user.WalletVerifiedAt != nil,
+		Role:           user.Role,
+		Profile: dto.ProfileResponse{
+			Avatar:      user.Profile.Avatar,
+			Bio:         user.Profile.Bio,
+			Preferences: user.Profile.Preferences,
+		},
+		CreatedAt: user.CreatedAt,
+	}
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS outbox_messages;
+DROP TABLE IF EXISTS siwe_nonces;
+DROP TABLE IF EXISTS password_reset_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS profiles;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_outbox_messages_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_published_at ON outbox_messages (published_at);
+
+DROP INDEX IF EXISTS idx_profiles_user_id_active;
+ALTER TABLE profiles ADD CONSTRAINT uni_profiles_user_id UNIQUE (user_id);
+
+DROP INDEX IF EXISTS idx_users_email_active;
+DROP INDEX IF EXISTS idx_users_username_active;
+ALTER TABLE users ADD CONSTRAINT uni_users_email UNIQUE (email);
+ALTER TABLE users ADD CONSTRAINT uni_users_username UNIQUE (username);
@@ -0,0 +1,13 @@
+-- Ràng buộc UNIQUE cũ tính cả user đã soft delete nên không thể đăng ký lại username/email
+-- của tài khoản đã xóa. Thay bằng unique index chỉ áp dụng cho các dòng chưa xóa.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS uni_users_username;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS uni_users_email;
+CREATE UNIQUE INDEX idx_users_username_active ON users (username) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_users_email_active ON users (email) WHERE deleted_at IS NULL;
+
+ALTER TABLE profiles DROP CONSTRAINT IF EXISTS uni_profiles_user_id;
+CREATE UNIQUE INDEX idx_profiles_user_id_active ON profiles (user_id) WHERE deleted_at IS NULL;
+
+-- Relay chỉ quét các event chưa gửi
+DROP INDEX IF EXISTS idx_outbox_messages_published_at;
+CREATE INDEX idx_outbox_messages_pending ON outbox_messages (id) WHERE published_at IS NULL;
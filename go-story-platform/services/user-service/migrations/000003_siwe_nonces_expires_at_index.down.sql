@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_siwe_nonces_expires_at;
@@ -0,0 +1,2 @@
+-- CreateNonce xóa dần các nonce đã hết hạn theo expires_at
+CREATE INDEX IF NOT EXISTS idx_siwe_nonces_expires_at ON siwe_nonces (expires_at);
@@ -0,0 +1,12 @@
+-- Một ví đã xác thực chỉ thuộc về một tài khoản: đăng nhập SIWE, ResolveWallet và việc ghi có
+-- tiền nạp đều tra user theo ví. Nếu dữ liệu cũ có ví được xác thực cho nhiều tài khoản thì giữ
+-- tài khoản xác thực sớm nhất, các tài khoản còn lại mất trạng thái xác thực và phải liên kết lại.
+UPDATE users u SET wallet_verified_at = NULL
+WHERE u.wallet_verified_at IS NOT NULL AND u.deleted_at IS NULL AND EXISTS (
+    SELECT 1 FROM users o
+    WHERE lower(o.wallet_address) = lower(u.wallet_address)
+      AND o.wallet_verified_at IS NOT NULL AND o.deleted_at IS NULL
+      AND (o.wallet_verified_at, o.id) < (u.wallet_verified_at, u.id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_verified_wallet ON users (lower(wallet_address))
+    WHERE wallet_verified_at IS NOT NULL AND deleted_at IS NULL;
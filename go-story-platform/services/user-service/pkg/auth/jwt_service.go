@@ -1,37 +1,38 @@
 package auth
 
 import (
-	"fmt"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
+	jwtauth "shared/auth"
+
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// JWTService cấp access token và refresh token khi đăng nhập. Việc kiểm tra access token
+// dùng auth.Verifier của shared giống các service khác.
 type JWTService struct {
-	SecretKey     string
-	Issuer        string
-	TokenDuration int64 // in minutes
-}
-
-// CustomClaims định nghĩa các thông tin bổ sung trong JWT
-type CustomClaims struct {
-	UserID uint   `json:"user_id"`
-	Role   string `json:"role"`
-	jwt.RegisteredClaims
+	SecretKey            string
+	Issuer               string
+	TokenDuration        int64 // in minutes
+	RefreshTokenDuration int64 // in minutes
 }
 
-// NewJWTService khởi tạo JWTService với thời hạn 1 ngày
+// NewJWTService khởi tạo JWTService với access token 15 phút và refresh token 30 ngày
 func NewJWTService(secretKey, issuer string) *JWTService {
 	return &JWTService{
-		SecretKey:     secretKey,
-		Issuer:        issuer,
-		TokenDuration: 1440, // 1 ngày
+		SecretKey:            secretKey,
+		Issuer:               issuer,
+		TokenDuration:        15,    // 15 phút
+		RefreshTokenDuration: 43200, // 30 ngày
 	}
 }
 
 // GenerateToken tạo JWT cho user với ID và vai trò
 func (j *JWTService) GenerateToken(userID uint, role string) (string, error) {
-	claims := &CustomClaims{
+	claims := &jwtauth.Claims{
 		UserID: userID,
 		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
@@ -44,28 +45,38 @@ func (j *JWTService) GenerateToken(userID uint, role string) (string, error) {
 	return token.SignedString([]byte(j.SecretKey))
 }
 
-// ValidateToken kiểm tra tính hợp lệ của JWT và trả về các thông tin trong token
-// ValidateToken kiểm tra tính hợp lệ và giải mã token
-func (j *JWTService) ValidateToken(tokenString string) (*CustomClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// Kiểm tra thuật toán ký để tránh lỗi bảo mật "None" attack
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(j.SecretKey), nil
-	})
-
+// GenerateRefreshToken tạo refresh token ngẫu nhiên (không phải JWT) và trả về token gốc,
+// bản băm để lưu DB cùng thời điểm hết hạn
+func (j *JWTService) GenerateRefreshToken() (token string, hash string, expiresAt time.Time, err error) {
+	token, hash, err = NewOpaqueToken()
 	if err != nil {
-		return nil, err
+		return "", "", time.Time{}, err
 	}
+	expiresAt = time.Now().Add(time.Duration(j.RefreshTokenDuration) * time.Minute)
+	return token, hash, expiresAt, nil
+}
 
-	// Kiểm tra ép kiểu và tính hợp lệ của Issuer
-	if claims, ok := token.Claims.(*CustomClaims); ok && token.Valid {
-		if claims.Issuer != j.Issuer {
-			return nil, fmt.Errorf("invalid issuer")
-		}
-		return claims, nil
+// NewOpaqueToken tạo token ngẫu nhiên 256 bit dạng hex cùng bản băm của nó
+func NewOpaqueToken() (token string, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", err
 	}
+	token = hex.EncodeToString(buf)
+	return token, HashToken(token), nil
+}
+
+// NewTokenFamily tạo ID ngẫu nhiên cho một chuỗi refresh token sinh ra từ cùng một lần đăng nhập
+func NewTokenFamily() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
 
-	return nil, fmt.Errorf("invalid token claims")
+// HashToken băm token bằng SHA-256 để lưu và tra cứu trong DB
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
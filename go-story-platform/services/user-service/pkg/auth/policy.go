@@ -0,0 +1,49 @@
+package auth
+
+// Các vai trò lưu trong models.User.Role
+const (
+	RoleReader = "reader"
+	RoleAuthor = "author"
+	RoleAdmin  = "admin"
+)
+
+// Permission là một quyền thao tác cụ thể, được gán cho vai trò qua rolePermissions
+type Permission string
+
+const (
+	PermUserRead      Permission = "user:read"       // Xem thông tin công khai của user khác
+	PermUserList      Permission = "user:list"       // Xem danh sách toàn bộ user
+	PermUserUpdateAny Permission = "user:update:any" // Sửa thông tin của bất kỳ user nào
+	PermUserDeleteAny Permission = "user:delete:any" // Xóa bất kỳ user nào
+	PermUserSetRole   Permission = "user:role:set"   // Đổi vai trò của user
+)
+
+// rolePermissions định nghĩa tập quyền của từng vai trò.
+// Quyền trên dữ liệu của chính mình (self) được kiểm tra riêng bằng ownership, không nằm ở đây.
+var rolePermissions = map[string][]Permission{
+	RoleReader: {PermUserRead},
+	RoleAuthor: {PermUserRead},
+	RoleAdmin: {
+		PermUserRead,
+		PermUserList,
+		PermUserUpdateAny,
+		PermUserDeleteAny,
+		PermUserSetRole,
+	},
+}
+
+// IsValidRole kiểm tra vai trò có được hệ thống hỗ trợ không
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// HasPermission kiểm tra vai trò có quyền perm không
+func HasPermission(role string, perm Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+// Package siwe cài đặt Sign-In with Ethereum (EIP-4361): phân tích message do ví ký,
+// kiểm tra các trường (domain, URI, nonce, thời hạn) và xác thực chữ ký personal_sign secp256k1.
+package siwe
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const headerSuffix = " wants you to sign in with your Ethereum account:"
+
+// maxClockSkew là độ lệch đồng hồ tối đa giữa ví và server được chấp nhận khi kiểm tra Issued At
+const maxClockSkew = time.Minute
+
+var (
+	ErrInvalidMessage = errors.New("message SIWE không đúng định dạng EIP-4361")
+	ErrDomainMismatch = errors.New("domain trong message không khớp")
+	ErrURIMismatch    = errors.New("URI trong message không khớp")
+	ErrChainMismatch  = errors.New("chain ID trong message không khớp")
+	ErrNonceMismatch  = errors.New("nonce trong message không khớp")
+	ErrExpired        = errors.New("message SIWE đã hết hạn")
+	ErrNotYetValid    = errors.New("message SIWE chưa có hiệu lực")
+	ErrIssuedInFuture = errors.New("thời điểm tạo message SIWE nằm ở tương lai")
+
+	addressRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	nonceRegex   = regexp.MustCompile(`^[a-zA-Z0-9]{8,}$`)
+)
+
+// Message là nội dung một message EIP-4361 sau khi phân tích
+type Message struct {
+	Domain         string
+	Address        string
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        int64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// ParseMessage phân tích message dạng văn bản theo EIP-4361
+func ParseMessage(raw string) (*Message, error) {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	if len(lines) < 2 || !strings.HasSuffix(lines[0], headerSuffix) {
+		return nil, ErrInvalidMessage
+	}
+
+	msg := &Message{
+		Domain:  strings.TrimSuffix(lines[0], headerSuffix),
+		Address: lines[1],
+	}
+	if msg.Domain == "" || !IsValidAddress(msg.Address) {
+		return nil, ErrInvalidMessage
+	}
+
+	// Sau địa chỉ là statement (không bắt buộc), được bao bởi các dòng trống
+	i := 2
+	for i < len(lines) && lines[i] == "" {
+		i++
+	}
+	if i < len(lines) && !strings.HasPrefix(lines[i], "URI: ") {
+		msg.Statement = lines[i]
+		i++
+		for i < len(lines) && lines[i] == "" {
+			i++
+		}
+	}
+
+	inResources := false
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if line == "" {
+			continue
+		}
+		if inResources {
+			if !strings.HasPrefix(line, "- ") {
+				return nil, ErrInvalidMessage
+			}
+			msg.Resources = append(msg.Resources, strings.TrimPrefix(line, "- "))
+			continue
+		}
+		if line == "Resources:" {
+			inResources = true
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			return nil, ErrInvalidMessage
+		}
+		if err := msg.setField(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if msg.URI == "" || msg.Version != "1" || msg.ChainID == 0 || msg.IssuedAt.IsZero() {
+		return nil, ErrInvalidMessage
+	}
+	if !nonceRegex.MatchString(msg.Nonce) {
+		return nil, ErrInvalidMessage
+	}
+	if u, err := url.Parse(msg.URI); err != nil || !u.IsAbs() {
+		return nil, fmt.Errorf("%w: URI", ErrInvalidMessage)
+	}
+
+	return msg, nil
+}
+
+// setField gán giá trị cho trường tương ứng với khóa trong message
+func (m *Message) setField(key, value string) error {
+	var err error
+	switch key {
+	case "URI":
+		m.URI = value
+	case "Version":
+		m.Version = value
+	case "Chain ID":
+		m.ChainID, err = strconv.ParseInt(value, 10, 64)
+	case "Nonce":
+		m.Nonce = value
+	case "Issued At":
+		m.IssuedAt, err = time.Parse(time.RFC3339, value)
+	case "Expiration Time":
+		var t time.Time
+		t, err = time.Parse(time.RFC3339, value)
+		m.ExpirationTime = &t
+	case "Not Before":
+		var t time.Time
+		t, err = time.Parse(time.RFC3339, value)
+		m.NotBefore = &t
+	case "Request ID":
+		m.RequestID = value
+	default:
+		return fmt.Errorf("%w: trường không hỗ trợ %q", ErrInvalidMessage, key)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidMessage, key)
+	}
+	return nil
+}
+
+// ValidateOptions là các giá trị server mong đợi trong message
+type ValidateOptions struct {
+	Domain  string
+	URI     string // Origin của server (ví dụ https://example.com); URI trong message phải cùng scheme và host, "" = không kiểm tra
+	ChainID int64  // 0 = không kiểm tra
+	Nonce   string // "" = không so sánh, khi nonce được kiểm tra riêng (ví dụ tiêu thụ trong DB)
+	Now     time.Time
+}
+
+// Validate kiểm tra domain, URI, chain ID, nonce và khoảng thời gian hiệu lực của message.
+// Issued At được phép lệch tối đa maxClockSkew về phía tương lai so với Now.
+func (m *Message) Validate(opts ValidateOptions) error {
+	if m.Domain != opts.Domain {
+		return ErrDomainMismatch
+	}
+	if opts.URI != "" && !sameOrigin(m.URI, opts.URI) {
+		return ErrURIMismatch
+	}
+	if opts.ChainID != 0 && m.ChainID != opts.ChainID {
+		return ErrChainMismatch
+	}
+	if opts.Nonce != "" && m.Nonce != opts.Nonce {
+		return ErrNonceMismatch
+	}
+	if m.IssuedAt.After(opts.Now.Add(maxClockSkew)) {
+		return ErrIssuedInFuture
+	}
+	if m.ExpirationTime != nil && !opts.Now.Before(*m.ExpirationTime) {
+		return ErrExpired
+	}
+	if m.NotBefore != nil && opts.Now.Before(*m.NotBefore) {
+		return ErrNotYetValid
+	}
+	return nil
+}
+
+// sameOrigin cho biết hai URI có cùng scheme và host (kể cả port) không
+func sameOrigin(a, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	return ua.Scheme != "" && strings.EqualFold(ua.Scheme, ub.Scheme) && strings.EqualFold(ua.Host, ub.Host)
+}
+
+// IsValidAddress kiểm tra chuỗi có phải địa chỉ Ethereum dạng 0x + 40 ký tự hex không
+func IsValidAddress(address string) bool {
+	return addressRegex.MatchString(address)
+}
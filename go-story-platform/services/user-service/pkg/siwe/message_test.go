@@ -0,0 +1,178 @@
+package siwe
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// specMessage là ví dụ message trong đặc tả EIP-4361
+const specMessage = `service.org wants you to sign in with your Ethereum account:
+0xe5A12547fe4E872D192E3eCecb76F2Ce1aeA4946
+
+I accept the ServiceOrg Terms of Service: https://service.org/tos
+
+URI: https://service.org/login
+Version: 1
+Chain ID: 1
+Nonce: 32891756
+Issued At: 2021-09-30T16:25:24Z
+Resources:
+- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/
+- https://example.com/my-web2-claim.json`
+
+func TestParseMessageSpecExample(t *testing.T) {
+	msg, err := ParseMessage(specMessage)
+	if err != nil {
+		t.Fatalf("ParseMessage: %v", err)
+	}
+
+	issuedAt := time.Date(2021, 9, 30, 16, 25, 24, 0, time.UTC)
+	switch {
+	case msg.Domain != "service.org":
+		t.Errorf("Domain = %q", msg.Domain)
+	case msg.Address != "0xe5A12547fe4E872D192E3eCecb76F2Ce1aeA4946":
+		t.Errorf("Address = %q", msg.Address)
+	case msg.Statement != "I accept the ServiceOrg Terms of Service: https://service.org/tos":
+		t.Errorf("Statement = %q", msg.Statement)
+	case msg.URI != "https://service.org/login":
+		t.Errorf("URI = %q", msg.URI)
+	case msg.Version != "1" || msg.ChainID != 1 || msg.Nonce != "32891756":
+		t.Errorf("Version/ChainID/Nonce = %q/%d/%q", msg.Version, msg.ChainID, msg.Nonce)
+	case !msg.IssuedAt.Equal(issuedAt):
+		t.Errorf("IssuedAt = %v", msg.IssuedAt)
+	case len(msg.Resources) != 2 || msg.Resources[1] != "https://example.com/my-web2-claim.json":
+		t.Errorf("Resources = %q", msg.Resources)
+	}
+}
+
+func TestParseMessageOptionalFields(t *testing.T) {
+	raw := `example.com:3000 wants you to sign in with your Ethereum account:
+0xe5A12547fe4E872D192E3eCecb76F2Ce1aeA4946
+
+URI: http://example.com:3000
+Version: 1
+Chain ID: 31337
+Nonce: abcdef123456
+Issued At: 2024-01-01T00:00:00Z
+Expiration Time: 2024-01-01T00:10:00Z
+Not Before: 2024-01-01T00:00:30Z
+Request ID: req-1`
+
+	msg, err := ParseMessage(raw)
+	if err != nil {
+		t.Fatalf("ParseMessage: %v", err)
+	}
+	if msg.Statement != "" || msg.Domain != "example.com:3000" || msg.RequestID != "req-1" {
+		t.Errorf("Statement/Domain/RequestID = %q/%q/%q", msg.Statement, msg.Domain, msg.RequestID)
+	}
+	if msg.ExpirationTime == nil || msg.NotBefore == nil {
+		t.Fatalf("ExpirationTime/NotBefore = %v/%v", msg.ExpirationTime, msg.NotBefore)
+	}
+}
+
+func TestParseMessageInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"rỗng", ""},
+		{"sai header", "service.org wants you to sign in:\n0xe5A12547fe4E872D192E3eCecb76F2Ce1aeA4946"},
+		{"sai địa chỉ", replaceLine(specMessage, 1, "0x1234")},
+		{"thiếu URI", removeLine(specMessage, "URI: ")},
+		{"URI không tuyệt đối", replacePrefix(specMessage, "URI: ", "URI: /login")},
+		{"sai version", replacePrefix(specMessage, "Version: ", "Version: 2")},
+		{"chain ID không phải số", replacePrefix(specMessage, "Chain ID: ", "Chain ID: mainnet")},
+		{"nonce quá ngắn", replacePrefix(specMessage, "Nonce: ", "Nonce: abc")},
+		{"thiếu Issued At", removeLine(specMessage, "Issued At: ")},
+		{"sai định dạng thời gian", replacePrefix(specMessage, "Issued At: ", "Issued At: 30/09/2021")},
+		{"trường lạ", replacePrefix(specMessage, "Issued At: ", "Issued At: 2021-09-30T16:25:24Z\nFoo: bar")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseMessage(tt.raw); !errors.Is(err, ErrInvalidMessage) {
+				t.Errorf("err = %v, muốn ErrInvalidMessage", err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	issuedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiration := issuedAt.Add(10 * time.Minute)
+	notBefore := issuedAt.Add(time.Minute)
+	valid := func() *Message {
+		return &Message{
+			Domain:         "example.com",
+			Address:        "0xe5A12547fe4E872D192E3eCecb76F2Ce1aeA4946",
+			URI:            "https://example.com/login",
+			Version:        "1",
+			ChainID:        1,
+			Nonce:          "abcdef123456",
+			IssuedAt:       issuedAt,
+			ExpirationTime: &expiration,
+			NotBefore:      &notBefore,
+		}
+	}
+	opts := ValidateOptions{
+		Domain:  "example.com",
+		URI:     "https://example.com",
+		ChainID: 1,
+		Nonce:   "abcdef123456",
+		Now:     issuedAt.Add(5 * time.Minute),
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(m *Message, o *ValidateOptions)
+		want   error
+	}{
+		{"hợp lệ", func(m *Message, o *ValidateOptions) {}, nil},
+		{"domain khác", func(m *Message, o *ValidateOptions) { m.Domain = "evil.com" }, ErrDomainMismatch},
+		{"URI khác host", func(m *Message, o *ValidateOptions) { m.URI = "https://evil.com/login" }, ErrURIMismatch},
+		{"URI khác scheme", func(m *Message, o *ValidateOptions) { m.URI = "http://example.com/login" }, ErrURIMismatch},
+		{"URI khác port", func(m *Message, o *ValidateOptions) { m.URI = "https://example.com:8443/login" }, ErrURIMismatch},
+		{"không kiểm tra URI", func(m *Message, o *ValidateOptions) { m.URI = "https://evil.com"; o.URI = "" }, nil},
+		{"chain ID khác", func(m *Message, o *ValidateOptions) { m.ChainID = 5 }, ErrChainMismatch},
+		{"không kiểm tra chain ID", func(m *Message, o *ValidateOptions) { m.ChainID = 5; o.ChainID = 0 }, nil},
+		{"nonce khác", func(m *Message, o *ValidateOptions) { m.Nonce = "zzzzzzzz1234" }, ErrNonceMismatch},
+		{"không so sánh nonce", func(m *Message, o *ValidateOptions) { m.Nonce = "zzzzzzzz1234"; o.Nonce = "" }, nil},
+		{"đã hết hạn", func(m *Message, o *ValidateOptions) { o.Now = expiration }, ErrExpired},
+		{"chưa có hiệu lực", func(m *Message, o *ValidateOptions) { o.Now = notBefore.Add(-time.Second) }, ErrNotYetValid},
+		{"tạo ở tương lai", func(m *Message, o *ValidateOptions) { m.IssuedAt = o.Now.Add(time.Hour); m.NotBefore = nil }, ErrIssuedInFuture},
+		{"lệch đồng hồ cho phép", func(m *Message, o *ValidateOptions) { m.IssuedAt = o.Now.Add(30 * time.Second) }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, o := valid(), opts
+			tt.tamper(m, &o)
+			if err := m.Validate(o); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, muốn %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// replaceLine thay dòng thứ i của message
+func replaceLine(raw string, i int, line string) string {
+	lines := strings.Split(raw, "\n")
+	lines[i] = line
+	return strings.Join(lines, "\n")
+}
+
+// replacePrefix thay dòng bắt đầu bằng prefix
+func replacePrefix(raw, prefix, line string) string {
+	lines := strings.Split(raw, "\n")
+	for i := range lines {
+		if strings.HasPrefix(lines[i], prefix) {
+			lines[i] = line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// removeLine bỏ dòng bắt đầu bằng prefix
+func removeLine(raw, prefix string) string {
+	return replacePrefix(raw, prefix, "")
+}
@@ -0,0 +1,88 @@
+package siwe
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+// ErrInvalidSignature được trả về khi chữ ký sai định dạng hoặc không khớp với địa chỉ
+var ErrInvalidSignature = errors.New("chữ ký không hợp lệ")
+
+// Keccak256 băm dữ liệu bằng Keccak-256 (bản gốc Ethereum dùng, khác SHA3-256 chuẩn)
+func Keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// hashPersonalMessage băm message theo chuẩn personal_sign (EIP-191)
+func hashPersonalMessage(message string) []byte {
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(message))
+	return Keccak256([]byte(prefix), []byte(message))
+}
+
+// RecoverAddress khôi phục địa chỉ (dạng checksum EIP-55) đã ký message bằng personal_sign.
+// Chữ ký là 65 byte r || s || v dạng hex, v có thể là 0/1 hoặc 27/28.
+func RecoverAddress(message, signatureHex string) (string, error) {
+	sig, err := hex.DecodeString(strings.TrimPrefix(signatureHex, "0x"))
+	if err != nil || len(sig) != 65 {
+		return "", ErrInvalidSignature
+	}
+
+	v := sig[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return "", ErrInvalidSignature
+	}
+
+	// Chuyển sang định dạng compact của secp256k1: <27 + recid><R><S>
+	compact := make([]byte, 65)
+	compact[0] = 27 + v
+	copy(compact[1:], sig[:64])
+
+	pub, _, err := ecdsa.RecoverCompact(compact, hashPersonalMessage(message))
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+
+	// Địa chỉ = 20 byte cuối của keccak256(pubkey không nén, bỏ byte 0x04 đầu)
+	addr := Keccak256(pub.SerializeUncompressed()[1:])[12:]
+	return ChecksumAddress("0x" + hex.EncodeToString(addr)), nil
+}
+
+// VerifySignature kiểm tra chữ ký personal_sign của message được tạo bởi address
+func VerifySignature(message, signatureHex, address string) error {
+	recovered, err := RecoverAddress(message, signatureHex)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(recovered, address) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// ChecksumAddress chuẩn hóa địa chỉ về dạng checksum hoa/thường theo EIP-55
+func ChecksumAddress(address string) string {
+	lower := strings.ToLower(strings.TrimPrefix(address, "0x"))
+	hash := hex.EncodeToString(Keccak256([]byte(lower)))
+
+	out := make([]byte, len(lower))
+	for i := 0; i < len(lower); i++ {
+		c := lower[i]
+		if c >= 'a' && c <= 'f' && hash[i] >= '8' {
+			c -= 'a' - 'A'
+		}
+		out[i] = c
+	}
+	return "0x" + string(out)
+}
@@ -0,0 +1,97 @@
+package siwe
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// Khóa và địa chỉ mẫu trong tài liệu web3.js (web3.eth.accounts)
+const (
+	testPrivateKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	testAddress    = "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"
+)
+
+func TestChecksumAddress(t *testing.T) {
+	// Các địa chỉ mẫu trong đặc tả EIP-55
+	for _, want := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		if got := ChecksumAddress(strings.ToLower(want)); got != want {
+			t.Errorf("ChecksumAddress(%q) = %q", strings.ToLower(want), got)
+		}
+	}
+}
+
+func TestRecoverAddressKnownVector(t *testing.T) {
+	// web3.eth.accounts.sign("Some data", testPrivateKey)
+	signature := "0xb91467e570a6466aa9e9876cbcd013baba02900b8979d43fe208a4a4f339f5fd6007e74cd82e037b800186422fc2da167c747ef045e5d18a5f5d4300f8e1a0291c"
+	got, err := RecoverAddress("Some data", signature)
+	if err != nil {
+		t.Fatalf("RecoverAddress: %v", err)
+	}
+	if got != testAddress {
+		t.Errorf("RecoverAddress = %q, muốn %q", got, testAddress)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	message := strings.Replace(specMessage, "0xe5A12547fe4E872D192E3eCecb76F2Ce1aeA4946", testAddress, 1)
+	signature := sign(t, message)
+
+	tests := []struct {
+		name      string
+		message   string
+		signature string
+		address   string
+		want      error
+	}{
+		{"hợp lệ", message, signature, testAddress, nil},
+		{"địa chỉ chữ thường", message, signature, strings.ToLower(testAddress), nil},
+		{"v dạng 0/1", message, signature[:len(signature)-2] + vByte(t, signature, -27), testAddress, nil},
+		{"sửa domain", strings.Replace(message, "service.org wants", "evil.org wants", 1), signature, testAddress, ErrInvalidSignature},
+		{"sửa nonce", strings.Replace(message, "Nonce: 32891756", "Nonce: 32891757", 1), signature, testAddress, ErrInvalidSignature},
+		{"thêm hạn", message + "\nExpiration Time: 2030-01-01T00:00:00Z", signature, testAddress, ErrInvalidSignature},
+		{"sửa chain ID", strings.Replace(message, "Chain ID: 1", "Chain ID: 5", 1), signature, testAddress, ErrInvalidSignature},
+		{"địa chỉ khác", message, signature, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", ErrInvalidSignature},
+		{"chữ ký không phải hex", message, "0xzz", testAddress, ErrInvalidSignature},
+		{"chữ ký thiếu byte", message, signature[:len(signature)-2], testAddress, ErrInvalidSignature},
+		{"v sai", message, signature[:len(signature)-2] + "1d", testAddress, ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := VerifySignature(tt.message, tt.signature, tt.address); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, muốn %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// sign ký message bằng testPrivateKey theo personal_sign, trả về r || s || v (v = 27/28) dạng hex
+func sign(t *testing.T, message string) string {
+	t.Helper()
+	raw, err := hex.DecodeString(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compact := ecdsa.SignCompact(secp256k1.PrivKeyFromBytes(raw), hashPersonalMessage(message), false)
+	sig := append(compact[1:], compact[0])
+	return "0x" + hex.EncodeToString(sig)
+}
+
+// vByte trả về byte v cuối chữ ký cộng thêm delta, dạng hex
+func vByte(t *testing.T, signature string, delta int) string {
+	t.Helper()
+	v, err := hex.DecodeString(signature[len(signature)-2:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString([]byte{byte(int(v[0]) + delta)})
+}
@@ -0,0 +1,58 @@
+// Package auth định nghĩa access token JWT dùng chung giữa các service: user-service ký token với
+// Claims khi đăng nhập, gateway và mọi service kiểm tra token bằng Verifier. Claims và luật kiểm tra
+// chỉ nằm ở đây để mọi service chấp nhận đúng cùng một loại token.
+package auth
+
+import (
+	"errors"
+
+	"shared/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims là nội dung access token do user-service cấp
+type Claims struct {
+	UserID uint   `json:"user_id"`
+	Role   string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// ErrInvalidToken trả về khi token sai chữ ký, sai issuer, hết hạn hoặc thiếu thông tin user
+var ErrInvalidToken = errors.New("token không hợp lệ")
+
+// Verifier kiểm tra access token bằng secret và issuer dùng chung (JWT_SECRET, ISSUER)
+type Verifier struct {
+	secret []byte
+	issuer string
+	parser *jwt.Parser
+}
+
+// NewVerifier tạo Verifier theo cfg
+func NewVerifier(cfg config.JWT) *Verifier {
+	return &Verifier{
+		secret: []byte(cfg.Secret),
+		issuer: cfg.Issuer,
+		// Chỉ nhận HS256 để token ký bằng thuật toán khác (kể cả "none") không lọt qua
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+			jwt.WithIssuer(cfg.Issuer),
+			jwt.WithExpirationRequired(),
+		),
+	}
+}
+
+// Verify kiểm tra token và trả về claims. Mọi lỗi đều bọc ErrInvalidToken, chi tiết chỉ để ghi log.
+func (v *Verifier) Verify(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := v.parser.ParseWithClaims(tokenString, claims, func(*jwt.Token) (interface{}, error) {
+		return v.secret, nil
+	})
+	if err != nil {
+		return nil, errors.Join(ErrInvalidToken, err)
+	}
+	if claims.UserID == 0 {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
@@ -0,0 +1,278 @@
+// Package config nạp cấu hình của service vào struct có kiểu và kiểm tra ngay lúc khởi động,
+// thay cho việc gọi os.Getenv rải rác trong code. Mỗi trường khai báo nguồn bằng tag:
+//
+//	Secret string `env:"JWT_SECRET" required:"true" secret:"true" minlen:"32"`
+//
+// Các tag được hỗ trợ:
+//   - env: tên biến môi trường (bắt buộc để trường được nạp; struct lồng nhau không cần tag)
+//   - default: giá trị khi không nguồn nào đặt biến
+//   - required: "true" thì báo lỗi khi giá trị cuối cùng rỗng
+//   - secret: "true" thì che giá trị khi in cấu hình hiệu lực
+//   - minlen: độ dài tối thiểu của chuỗi khi có giá trị (độ mạnh của secret)
+//   - min: giá trị nhỏ nhất cho số và thời lượng
+//
+// Kiểu hỗ trợ: string, bool, int*, uint*, time.Duration (dạng "30s" hoặc số giây) và []string
+// (các phần tử cách nhau bởi dấu phẩy).
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Load điền cfg (con trỏ tới struct). Giá trị của mỗi biến lấy theo thứ tự ưu tiên:
+//  1. biến môi trường <TÊN>, kể cả biến nạp từ file .env trong thư mục làm việc
+//  2. nội dung file tại <TÊN>_FILE (Docker/Kubernetes secret), bỏ khoảng trắng hai đầu
+//  3. file YAML tại CONFIG_FILE, là map phẳng từ tên biến tới giá trị
+//  4. tag default
+//
+// Lỗi của mọi trường được gộp lại để sửa một lần.
+func Load(cfg interface{}) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return errors.New("config.Load cần con trỏ tới struct")
+	}
+
+	// .env không ghi đè biến môi trường đã có
+	_ = godotenv.Load()
+
+	file, err := loadYAML(os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	walk(v.Elem(), func(field reflect.StructField, value reflect.Value) {
+		if err := loadField(field, value, file); err != nil {
+			errs = append(errs, err)
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// MustLoad giống Load nhưng dừng service nếu cấu hình không hợp lệ
+func MustLoad(cfg interface{}) {
+	if err := Load(cfg); err != nil {
+		log.Fatal("❌ Cấu hình không hợp lệ:\n", err)
+	}
+}
+
+// Describe trả về cấu hình hiệu lực dạng "TÊN=giá trị", trường secret được che
+func Describe(cfg interface{}) []string {
+	var lines []string
+	walk(reflect.Indirect(reflect.ValueOf(cfg)), func(field reflect.StructField, value reflect.Value) {
+		lines = append(lines, field.Tag.Get("env")+"="+display(field, value))
+	})
+	return lines
+}
+
+// LogEffective in cấu hình hiệu lực của service lúc khởi động, trường secret được che
+func LogEffective(service string, cfg interface{}) {
+	slog.Info(service+": cấu hình hiệu lực", "config", Describe(cfg))
+}
+
+// walk gọi fn cho mọi trường có tag env, đi sâu vào struct lồng nhau
+func walk(v reflect.Value, fn func(reflect.StructField, reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Tag.Get("env") == "" {
+			if field.Type.Kind() == reflect.Struct {
+				walk(v.Field(i), fn)
+			}
+			continue
+		}
+		fn(field, v.Field(i))
+	}
+}
+
+// loadField tìm giá trị của trường theo thứ tự ưu tiên rồi gán và kiểm tra
+func loadField(field reflect.StructField, value reflect.Value, file map[string]string) error {
+	name := field.Tag.Get("env")
+
+	// Biến đặt nhưng rỗng được coi như chưa đặt, giống cách các service vẫn đọc env trước đây
+	raw := os.Getenv(name)
+	if raw == "" {
+		if path := os.Getenv(name + "_FILE"); path != "" {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("%s_FILE: không đọc được %s: %w", name, path, err)
+			}
+			raw = strings.TrimSpace(string(content))
+		}
+	}
+	if raw == "" {
+		raw = file[name]
+	}
+	if raw == "" {
+		raw = field.Tag.Get("default")
+	}
+
+	if raw == "" {
+		if field.Tag.Get("required") == "true" {
+			return fmt.Errorf("%s: bắt buộc phải đặt", name)
+		}
+		return nil
+	}
+	if err := setValue(value, raw); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return validate(field, value)
+}
+
+// setValue chuyển chuỗi raw sang kiểu của trường
+func setValue(value reflect.Value, raw string) error {
+	if value.Type() == durationType {
+		d, err := parseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(d))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("phải là true/false, nhận %q", raw)
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("phải là số nguyên, nhận %q", raw)
+		}
+		value.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, value.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("phải là số nguyên không âm, nhận %q", raw)
+		}
+		value.SetUint(n)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("kiểu %s không được hỗ trợ", value.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("kiểu %s không được hỗ trợ", value.Type())
+	}
+	return nil
+}
+
+// validate kiểm tra các tag minlen và min sau khi gán giá trị
+func validate(field reflect.StructField, value reflect.Value) error {
+	name := field.Tag.Get("env")
+
+	if raw := field.Tag.Get("minlen"); raw != "" {
+		n, _ := strconv.Atoi(raw)
+		if value.Kind() == reflect.String && len(value.String()) < n {
+			return fmt.Errorf("%s: phải dài ít nhất %d ký tự", name, n)
+		}
+	}
+
+	raw := field.Tag.Get("min")
+	if raw == "" {
+		return nil
+	}
+	min := reflect.New(value.Type()).Elem()
+	if err := setValue(min, raw); err != nil {
+		return fmt.Errorf("%s: tag min không hợp lệ: %w", name, err)
+	}
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value.Int() < min.Int() {
+			return fmt.Errorf("%s: phải lớn hơn hoặc bằng %s", name, raw)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value.Uint() < min.Uint() {
+			return fmt.Errorf("%s: phải lớn hơn hoặc bằng %s", name, raw)
+		}
+	}
+	return nil
+}
+
+// parseDuration nhận thời lượng kiểu Go ("1m30s") hoặc số giây ("90")
+func parseDuration(raw string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(raw); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("phải là thời lượng như 30s hoặc số giây, nhận %q", raw)
+	}
+	return d, nil
+}
+
+// display định dạng giá trị của trường để in, che trường secret
+func display(field reflect.StructField, value reflect.Value) string {
+	if field.Tag.Get("secret") == "true" {
+		if value.IsZero() {
+			return ""
+		}
+		return "******"
+	}
+	if value.Type() == durationType {
+		return value.Interface().(time.Duration).String()
+	}
+	if value.Kind() == reflect.Slice {
+		return strings.Join(value.Interface().([]string), ",")
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// loadYAML đọc file YAML phẳng dạng "TÊN_BIẾN: giá trị". Danh sách được nối bằng dấu phẩy.
+func loadYAML(path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("CONFIG_FILE: không đọc được %s: %w", path, err)
+	}
+
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("CONFIG_FILE: %s không phải YAML hợp lệ: %w", path, err)
+	}
+
+	values := make(map[string]string, len(doc))
+	for key, raw := range doc {
+		switch v := raw.(type) {
+		case nil:
+		case []interface{}:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			values[key] = strings.Join(items, ",")
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,37 @@
+package config
+
+import "fmt"
+
+// Database là kết nối PostgreSQL của service
+type Database struct {
+	Host     string `env:"DB_HOST" default:"localhost"`
+	Port     int    `env:"DB_PORT" default:"5432" min:"1"`
+	User     string `env:"DB_USER" required:"true"`
+	Password string `env:"DB_PASSWORD" secret:"true"`
+	Name     string `env:"DB_NAME" required:"true"`
+	SSLMode  string `env:"DB_SSLMODE" default:"disable"`
+}
+
+// DSN dựng chuỗi kết nối cho driver postgres
+func (d Database) DSN() string {
+	return d.DSNFor(d.Name)
+}
+
+// DSNFor dựng chuỗi kết nối tới database dbName trên cùng server, dùng cho database dùng chung
+// giữa các service như event bus
+func (d Database) DSNFor(dbName string) string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s",
+		d.Host, d.User, d.Password, dbName, d.Port, d.SSLMode)
+}
+
+// JWT là secret và issuer dùng chung với user-service để ký/kiểm tra access token.
+// Secret ngắn hơn 32 ký tự bị từ chối để không ký token bằng khóa yếu hoặc rỗng.
+type JWT struct {
+	Secret string `env:"JWT_SECRET" required:"true" secret:"true" minlen:"32"`
+	Issuer string `env:"ISSUER" required:"true"`
+}
+
+// CORS là danh sách origin của UI được phép gọi API
+type CORS struct {
+	AllowedOrigins []string `env:"CORS_ALLOWED_ORIGINS" default:"http://localhost:3000,http://127.0.0.1:3000"`
+}
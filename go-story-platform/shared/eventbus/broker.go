@@ -0,0 +1,77 @@
+// Package eventbus là lớp trung gian phát/nhận domain event giữa các service.
+// Broker là interface để có thể thay bằng Kafka/NATS... sau này mà không đổi code nghiệp vụ.
+// Việc giao nhận là at-least-once nên consumer phải idempotent (xem Idempotent).
+package eventbus
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"shared/config"
+)
+
+// Message là một event trên broker
+type Message struct {
+	ID          string          `json:"id"`     // EventID duy nhất, dùng để chống xử lý trùng
+	Type        string          `json:"type"`   // Một trong các events.Type*
+	Source      string          `json:"source"` // Service phát event
+	AggregateID string          `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+}
+
+// Handler xử lý một message. Trả lỗi để broker giao lại message sau.
+type Handler func(ctx context.Context, msg Message) error
+
+// Broker phát và phân phối message tới các consumer đã đăng ký
+type Broker interface {
+	// Publish gửi message lên broker. Gửi lại cùng Message.ID không tạo bản sao.
+	Publish(ctx context.Context, msg Message) error
+	// Subscribe đăng ký consumer nhận các loại event eventTypes cho tới khi ctx bị hủy.
+	// Tên consumer phải cố định giữa các lần khởi động để broker nhớ vị trí đã đọc.
+	Subscribe(ctx context.Context, consumer string, eventTypes []string, handler Handler) error
+	// SubscribeLocal đăng ký consumer chỉ của process này, ví dụ xóa cache trong bộ nhớ: mỗi
+	// replica nhận đủ các event phát sau lúc đăng ký, và không nhớ vị trí giữa các lần khởi động.
+	SubscribeLocal(ctx context.Context, consumer string, eventTypes []string, handler Handler) error
+	// Ping kiểm tra broker còn dùng được, dùng cho /readyz
+	Ping(ctx context.Context) error
+	// Close chờ các consumer đã dừng (ctx của Subscribe bị hủy) rồi giải phóng tài nguyên của broker
+	Close() error
+}
+
+// Config chọn broker cho event bus
+type Config struct {
+	// "postgres": PostgresBroker trên database DBName, cùng host/user/password với database của service.
+	// Các service phải trỏ chung một database này.
+	// "memory": MemoryBroker, chỉ giao event trong cùng process.
+	Driver string `env:"EVENT_BUS_DRIVER" default:"memory"`
+	DBName string `env:"EVENT_BUS_DB_NAME" default:"event_bus"`
+}
+
+// New tạo Broker theo cfg; db là kết nối database của service, dùng lại host/user/password
+func New(cfg Config, db config.Database) (Broker, error) {
+	switch cfg.Driver {
+	case "postgres":
+		return NewPostgresBroker(db.DSNFor(cfg.DBName))
+	case "memory":
+		slog.Warn("Event bus: dùng broker trong bộ nhớ, event không được gửi sang service khác")
+		return NewMemoryBroker(), nil
+	default:
+		return nil, fmt.Errorf("EVENT_BUS_DRIVER không hỗ trợ: %q", cfg.Driver)
+	}
+}
+
+// NewEventID tạo ID ngẫu nhiên dạng UUID v4 cho event
+func NewEventID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,38 @@
+package eventbus
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProcessedEvent ghi lại event mà một consumer đã xử lý, nằm trong database của service consumer
+type ProcessedEvent struct {
+	Consumer    string    `gorm:"primaryKey;size:100"`
+	EventID     string    `gorm:"primaryKey;size:36"`
+	ProcessedAt time.Time `gorm:"not null"`
+}
+
+// Idempotent bọc handle để mỗi event chỉ có tác dụng một lần với consumer, kể cả khi broker
+// giao lại. Dòng processed_events và thay đổi của handle nằm chung một DB transaction:
+// handle lỗi thì cả hai cùng rollback và event sẽ được xử lý lại.
+func Idempotent(db *gorm.DB, consumer string, handle func(tx *gorm.DB, msg Message) error) Handler {
+	return func(ctx context.Context, msg Message) error {
+		return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&ProcessedEvent{
+				Consumer:    consumer,
+				EventID:     msg.ID,
+				ProcessedAt: time.Now(),
+			})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return nil // Đã xử lý trước đó
+			}
+			return handle(tx, msg)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// MemoryBroker giao message đồng bộ tới các subscriber trong cùng process.
+// Dùng khi chạy một mình một service ở local hoặc khi kiểm thử.
+type MemoryBroker struct {
+	mu   sync.RWMutex
+	subs []*memorySubscription
+	seen map[string]bool // message ID đã nhận, để Publish lại cùng ID không giao lần nữa
+}
+
+type memorySubscription struct {
+	ctx        context.Context
+	consumer   string
+	eventTypes map[string]bool
+	handler    Handler
+}
+
+// NewMemoryBroker tạo MemoryBroker rỗng
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{seen: make(map[string]bool)}
+}
+
+// Publish gọi handler của mọi subscriber quan tâm tới msg.Type. Nếu có handler lỗi,
+// message không được đánh dấu đã nhận để lần Publish sau (relay thử lại) được giao lại.
+func (b *MemoryBroker) Publish(ctx context.Context, msg Message) error {
+	b.mu.RLock()
+	if b.seen[msg.ID] {
+		b.mu.RUnlock()
+		return nil
+	}
+	subs := make([]*memorySubscription, 0, len(b.subs))
+	for _, sub := range b.subs {
+		if sub.ctx.Err() == nil && sub.eventTypes[msg.Type] {
+			subs = append(subs, sub)
+		}
+	}
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subs {
+		if err := sub.handler(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	b.mu.Lock()
+	b.seen[msg.ID] = true
+	b.mu.Unlock()
+	return nil
+}
+
+// Subscribe đăng ký handler; subscriber tự bị bỏ qua khi ctx bị hủy
+func (b *MemoryBroker) Subscribe(ctx context.Context, consumer string, eventTypes []string, handler Handler) error {
+	types := make(map[string]bool, len(eventTypes))
+	for _, t := range eventTypes {
+		types[t] = true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs = append(b.subs, &memorySubscription{ctx: ctx, consumer: consumer, eventTypes: types, handler: handler})
+	return nil
+}
+
+// SubscribeLocal giống Subscribe vì MemoryBroker chỉ giao message trong process
+func (b *MemoryBroker) SubscribeLocal(ctx context.Context, consumer string, eventTypes []string, handler Handler) error {
+	return b.Subscribe(ctx, consumer, eventTypes, handler)
+}
+
+// Ping luôn thành công vì MemoryBroker nằm trong process
+func (b *MemoryBroker) Ping(ctx context.Context) error {
+	return nil
+}
+
+// Close không cần giải phóng gì với MemoryBroker
+func (b *MemoryBroker) Close() error {
+	return nil
+}
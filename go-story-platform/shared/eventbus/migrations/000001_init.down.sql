@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bus_consumer_offsets;
+DROP TABLE IF EXISTS bus_messages;
@@ -0,0 +1,19 @@
+-- Bảng message và vị trí đọc của consumer cho PostgresBroker
+CREATE TABLE IF NOT EXISTS bus_messages (
+    id           BIGSERIAL PRIMARY KEY,
+    event_id     VARCHAR(36) NOT NULL,
+    type         TEXT NOT NULL,
+    source       TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    payload      JSONB NOT NULL,
+    occurred_at  TIMESTAMPTZ NOT NULL,
+    inserted_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bus_messages_event_id ON bus_messages (event_id);
+CREATE INDEX IF NOT EXISTS idx_bus_messages_type ON bus_messages (type);
+
+CREATE TABLE IF NOT EXISTS bus_consumer_offsets (
+    consumer        TEXT PRIMARY KEY,
+    last_message_id BIGINT NOT NULL DEFAULT 0,
+    updated_at      TIMESTAMPTZ
+);
@@ -0,0 +1,4 @@
+ALTER TABLE bus_consumer_offsets DROP COLUMN IF EXISTS last_txid;
+
+DROP INDEX IF EXISTS idx_bus_messages_txid_id;
+ALTER TABLE bus_messages DROP COLUMN IF EXISTS txid;
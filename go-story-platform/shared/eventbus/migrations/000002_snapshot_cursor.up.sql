@@ -0,0 +1,10 @@
+-- Consumer đọc message theo (txid, id) và chỉ đọc message của các transaction đã kết thúc
+-- (txid nhỏ hơn xmin của snapshot hiện tại). id được cấp lúc INSERT nên các transaction có thể
+-- commit không theo thứ tự id; so theo txid thì message commit muộn không bao giờ bị vượt qua.
+-- Cần PostgreSQL 13 trở lên (pg_current_xact_id, pg_current_snapshot).
+ALTER TABLE bus_messages ADD COLUMN IF NOT EXISTS txid BIGINT NOT NULL DEFAULT (pg_current_xact_id()::text::bigint);
+CREATE INDEX IF NOT EXISTS idx_bus_messages_txid_id ON bus_messages (txid, id);
+
+ALTER TABLE bus_consumer_offsets ADD COLUMN IF NOT EXISTS last_txid BIGINT NOT NULL DEFAULT 0;
+-- Message có sẵn đều mang txid của migration này, nên consumer đã đọc dở tiếp tục từ last_message_id như trước
+UPDATE bus_consumer_offsets SET last_txid = pg_current_xact_id()::text::bigint WHERE last_message_id > 0;
@@ -0,0 +1,247 @@
+package eventbus
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"shared/migrate"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationTable là bảng lịch sử migration của event bus, tách khỏi schema_migrations
+// để không đụng độ nếu event bus dùng chung database với một service
+const migrationTable = "event_bus_schema_migrations"
+
+// busMessage là một dòng trong bảng bus_messages dùng chung giữa các service
+type busMessage struct {
+	ID          uint64    `gorm:"primaryKey"`
+	EventID     string    `gorm:"size:36;uniqueIndex;not null"`
+	Type        string    `gorm:"index;not null"`
+	Source      string    `gorm:"not null"`
+	AggregateID string    `gorm:"not null"`
+	Payload     string    `gorm:"type:jsonb;not null"`
+	OccurredAt  time.Time `gorm:"not null"`
+	InsertedAt  time.Time `gorm:"not null;default:now()"`
+	// TxID là ID transaction đã ghi message, do DB gán (xem migration 000002_snapshot_cursor)
+	TxID int64 `gorm:"column:txid;->"`
+}
+
+func (busMessage) TableName() string { return "bus_messages" }
+
+// busOffset lưu vị trí (txid, id) của message cuối cùng mỗi consumer đã xử lý xong
+type busOffset struct {
+	Consumer      string `gorm:"primaryKey"`
+	LastTxID      int64  `gorm:"column:last_txid;not null;default:0"`
+	LastMessageID uint64 `gorm:"not null;default:0"`
+	UpdatedAt     time.Time
+}
+
+func (busOffset) TableName() string { return "bus_consumer_offsets" }
+
+// PostgresBroker lưu message vào bảng bus_messages của một database dùng chung.
+// Consumer poll message theo thứ tự (txid, id), chỉ lấy message của các transaction đã kết thúc,
+// và ghi lại vị trí đã đọc trong bus_consumer_offsets, nên consumer khởi động lại sẽ đọc tiếp
+// từ chỗ cũ. Các replica dùng chung tên consumer lần lượt giữ khóa dòng offset khi poll, nên
+// mỗi lô chỉ do một replica xử lý.
+type PostgresBroker struct {
+	db           *gorm.DB
+	pollers      sync.WaitGroup
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// NewPostgresBroker kết nối tới database của event bus theo dsn
+func NewPostgresBroker(dsn string) (*PostgresBroker, error) {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	return NewPostgresBrokerWithDB(db)
+}
+
+// NewPostgresBrokerWithDB tạo PostgresBroker trên kết nối có sẵn. Database của event bus
+// không thuộc service nào nên broker tự chạy migration của nó khi khởi tạo.
+func NewPostgresBrokerWithDB(db *gorm.DB) (*PostgresBroker, error) {
+	source, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	m, err := migrate.New(db, source)
+	if err != nil {
+		return nil, err
+	}
+	m.HistoryTable = migrationTable
+	if _, err := m.Up(0); err != nil {
+		return nil, err
+	}
+
+	return &PostgresBroker{db: db, PollInterval: time.Second, BatchSize: 100}, nil
+}
+
+// Publish ghi message vào bus_messages; message trùng EventID bị bỏ qua
+func (b *PostgresBroker) Publish(ctx context.Context, msg Message) error {
+	row := busMessage{
+		EventID:     msg.ID,
+		Type:        msg.Type,
+		Source:      msg.Source,
+		AggregateID: msg.AggregateID,
+		Payload:     string(msg.Payload),
+		OccurredAt:  msg.OccurredAt,
+	}
+	return b.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "event_id"}}, DoNothing: true}).
+		Create(&row).Error
+}
+
+// Subscribe chạy một goroutine poll message cho consumer tới khi ctx bị hủy.
+// Message được xử lý tuần tự theo thứ tự ghi; khi handler lỗi, consumer dừng ở message đó và
+// thử lại ở lần poll sau để không làm mất hay đảo thứ tự event.
+func (b *PostgresBroker) Subscribe(ctx context.Context, consumer string, eventTypes []string, handler Handler) error {
+	offset := busOffset{Consumer: consumer}
+	if err := b.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&offset).Error; err != nil {
+		return err
+	}
+
+	b.run(ctx, consumer, func(ctx context.Context) error {
+		return b.pollShared(ctx, consumer, eventTypes, handler)
+	})
+	return nil
+}
+
+// SubscribeLocal chạy consumer chỉ của process này: vị trí đọc giữ trong bộ nhớ, bắt đầu từ
+// các message ghi sau lúc đăng ký. Mọi replica đều nhận đủ message.
+func (b *PostgresBroker) SubscribeLocal(ctx context.Context, consumer string, eventTypes []string, handler Handler) error {
+	var xmin int64
+	if err := b.db.WithContext(ctx).
+		Raw("SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint").
+		Scan(&xmin).Error; err != nil {
+		return err
+	}
+	// Bỏ qua mọi message của các transaction đã kết thúc trước lúc đăng ký
+	cursor := busOffset{LastTxID: xmin - 1, LastMessageID: math.MaxInt64}
+
+	b.run(ctx, consumer, func(ctx context.Context) error {
+		return b.poll(ctx, b.db.WithContext(ctx), &cursor, eventTypes, handler)
+	})
+	return nil
+}
+
+// run gọi poll mỗi PollInterval trong một goroutine cho tới khi ctx bị hủy
+func (b *PostgresBroker) run(ctx context.Context, consumer string, poll func(ctx context.Context) error) {
+	b.pollers.Add(1)
+	go func() {
+		defer b.pollers.Done()
+		ticker := time.NewTicker(b.PollInterval)
+		defer ticker.Stop()
+		for {
+			if err := poll(ctx); err != nil && ctx.Err() == nil {
+				slog.Error("Event bus: consumer lỗi", "consumer", consumer, "error", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// pollShared xử lý một lô message của consumer dùng chung giữa các replica. Dòng offset được
+// khóa trong suốt lô; replica khác đang giữ khóa thì bỏ qua lượt này. Vị trí của các message
+// đã xử lý xong được lưu kể cả khi handler lỗi giữa lô.
+func (b *PostgresBroker) pollShared(ctx context.Context, consumer string, eventTypes []string, handler Handler) error {
+	var pollErr error
+	err := b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var offset busOffset
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("consumer = ?", consumer).
+			Limit(1).
+			Find(&offset)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		start := offset
+		pollErr = b.poll(ctx, tx, &offset, eventTypes, handler)
+		if offset == start {
+			return nil
+		}
+		return tx.Model(&busOffset{}).
+			Where("consumer = ?", consumer).
+			Updates(map[string]interface{}{
+				"last_txid":       offset.LastTxID,
+				"last_message_id": offset.LastMessageID,
+				"updated_at":      time.Now(),
+			}).Error
+	})
+	if err != nil {
+		return err
+	}
+	return pollErr
+}
+
+// poll đọc một lô message sau cursor và gọi handler lần lượt, dời cursor sau mỗi message xử lý
+// xong. Chỉ đọc message có txid nhỏ hơn xmin của snapshot hiện tại: mọi transaction trước xmin
+// đã kết thúc, còn transaction đang chạy hoặc bắt đầu sau đều có txid >= xmin, nên không message
+// nào commit muộn lại nằm sau cursor.
+func (b *PostgresBroker) poll(ctx context.Context, db *gorm.DB, cursor *busOffset, eventTypes []string, handler Handler) error {
+	var rows []busMessage
+	err := db.
+		Where("(txid, id) > (?, ?) AND type IN ?", cursor.LastTxID, cursor.LastMessageID, eventTypes).
+		Where("txid < pg_snapshot_xmin(pg_current_snapshot())::text::bigint").
+		Order("txid ASC, id ASC").
+		Limit(b.BatchSize).
+		Find(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		msg := Message{
+			ID:          row.EventID,
+			Type:        row.Type,
+			Source:      row.Source,
+			AggregateID: row.AggregateID,
+			Payload:     []byte(row.Payload),
+			OccurredAt:  row.OccurredAt,
+		}
+		if err := handler(ctx, msg); err != nil {
+			return err
+		}
+		cursor.LastTxID = row.TxID
+		cursor.LastMessageID = row.ID
+	}
+	return nil
+}
+
+// Ping kiểm tra kết nối tới database của event bus
+func (b *PostgresBroker) Ping(ctx context.Context) error {
+	sqlDB, err := b.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// Close chờ các consumer đang poll xử lý xong lô hiện tại rồi đóng kết nối tới database của event bus
+func (b *PostgresBroker) Close() error {
+	b.pollers.Wait()
+	sqlDB, err := b.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
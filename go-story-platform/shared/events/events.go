@@ -0,0 +1,114 @@
+// Package events định nghĩa các domain event được trao đổi giữa user-service,
+// content-service và payment-service. Payload được mã hóa JSON nên chỉ được thêm trường
+// mới, không đổi tên hay đổi kiểu trường đã có.
+package events
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Tên các loại event, dùng làm Message.Type trên broker
+const (
+	TypeUserRegistered      = "user.registered"
+	TypeUserDeleted         = "user.deleted"
+	TypeUserWalletChanged   = "user.wallet_changed"
+	TypeBookPublished       = "book.published"
+	TypeChapterPurchased    = "chapter.purchased"
+	TypePurchaseTransferred = "purchase.transferred"
+)
+
+// Event là một domain event có thể ghi vào outbox
+type Event interface {
+	// EventType trả về một trong các hằng Type*
+	EventType() string
+	// AggregateID là ID của đối tượng phát sinh event (user, book, purchase...)
+	AggregateID() string
+}
+
+// UserRegistered phát ra khi user-service tạo tài khoản mới
+type UserRegistered struct {
+	UserID        uint      `json:"user_id"`
+	Username      string    `json:"username"`
+	WalletAddress string    `json:"wallet_address,omitempty"`
+	Role          string    `json:"role"`
+	RegisteredAt  time.Time `json:"registered_at"`
+}
+
+func (e UserRegistered) EventType() string   { return TypeUserRegistered }
+func (e UserRegistered) AggregateID() string { return strconv.FormatUint(uint64(e.UserID), 10) }
+
+// UserDeleted phát ra khi user bị xóa (soft delete) ở user-service
+type UserDeleted struct {
+	UserID    uint      `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+func (e UserDeleted) EventType() string   { return TypeUserDeleted }
+func (e UserDeleted) AggregateID() string { return strconv.FormatUint(uint64(e.UserID), 10) }
+
+// UserWalletChanged phát ra khi địa chỉ ví của user thay đổi: liên kết ví qua SIWE (Verified = true)
+// hoặc sửa địa chỉ trong hồ sơ (mất trạng thái xác thực, Verified = false)
+type UserWalletChanged struct {
+	UserID        uint      `json:"user_id"`
+	WalletAddress string    `json:"wallet_address"`
+	Verified      bool      `json:"verified"`
+	ChangedAt     time.Time `json:"changed_at"`
+}
+
+func (e UserWalletChanged) EventType() string   { return TypeUserWalletChanged }
+func (e UserWalletChanged) AggregateID() string { return strconv.FormatUint(uint64(e.UserID), 10) }
+
+// BookPublished phát ra khi một truyện bắt đầu hiển thị cho người đọc ở content-service
+type BookPublished struct {
+	BookID      uint      `json:"book_id"`
+	AuthorID    uint      `json:"author_id"`
+	Title       string    `json:"title"`
+	IsPremium   bool      `json:"is_premium"`
+	Price       int       `json:"price"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+func (e BookPublished) EventType() string   { return TypeBookPublished }
+func (e BookPublished) AggregateID() string { return strconv.FormatUint(uint64(e.BookID), 10) }
+
+// ChapterPurchased phát ra khi payment-service ghi nhận một lần mua thành công.
+// Giống PurchasedBook, ChapterID = 0 nghĩa là mua trọn bộ truyện.
+type ChapterPurchased struct {
+	PurchaseID    uint      `json:"purchase_id"`
+	TransactionID uint      `json:"transaction_id"`
+	UserID        uint      `json:"user_id"`
+	BookID        uint      `json:"book_id"`
+	ChapterID     uint      `json:"chapter_id"`
+	Price         int       `json:"price"`
+	PurchasedAt   time.Time `json:"purchased_at"`
+}
+
+func (e ChapterPurchased) EventType() string   { return TypeChapterPurchased }
+func (e ChapterPurchased) AggregateID() string { return strconv.FormatUint(uint64(e.PurchaseID), 10) }
+
+// PurchaseTransferred phát ra khi đối soát on-chain thấy token sở hữu của một lần mua đã sang ví
+// khác: quyền đọc chuyển từ FromUserID sang ToUserID. ToUserID = 0 khi ví đang giữ token chưa
+// liên kết với tài khoản nào (quyền đọc tạm thời không thuộc về ai).
+type PurchaseTransferred struct {
+	PurchaseID    uint      `json:"purchase_id"` // Bản ghi mua mới của người nhận, 0 nếu không có
+	FromUserID    uint      `json:"from_user_id"`
+	ToUserID      uint      `json:"to_user_id"`
+	BookID        uint      `json:"book_id"`
+	ChapterID     uint      `json:"chapter_id"`
+	NFTTokenID    string    `json:"nft_token_id"`
+	OwnerAddress  string    `json:"owner_address"`
+	TransferredAt time.Time `json:"transferred_at"`
+}
+
+func (e PurchaseTransferred) EventType() string   { return TypePurchaseTransferred }
+func (e PurchaseTransferred) AggregateID() string { return e.NFTTokenID }
+
+// Decode giải mã payload của message vào event đích, ví dụ:
+//
+//	var e events.UserRegistered
+//	err := events.Decode(msg.Payload, &e)
+func Decode(payload []byte, event Event) error {
+	return json.Unmarshal(payload, event)
+}
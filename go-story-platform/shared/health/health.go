@@ -0,0 +1,143 @@
+// Package health cung cấp hai endpoint kiểm tra sức khỏe cho mỗi service:
+//   - /healthz (liveness): process còn chạy và phục vụ được HTTP, không kiểm tra gì thêm để
+//     orchestrator không restart service chỉ vì database tạm thời gián đoạn
+//   - /readyz (readiness): database và các phụ thuộc đều trả lời; trả 503 khi có kiểm tra lỗi
+//     hoặc service đang dừng, để load balancer ngừng gửi request mới
+//
+// Body trả về có cùng dạng ApiResponse của các service.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// checkTimeout giới hạn thời gian của mỗi kiểm tra trong /readyz
+const checkTimeout = 2 * time.Second
+
+// Check kiểm tra một phụ thuộc, trả về lỗi khi phụ thuộc không dùng được
+type Check func(ctx context.Context) error
+
+// Checker giữ danh sách kiểm tra của service
+type Checker struct {
+	names    []string
+	checks   map[string]Check
+	draining atomic.Bool
+}
+
+// response có cùng dạng JSON với dto.ApiResponse của các service
+type response struct {
+	Success bool              `json:"success"`
+	Message string            `json:"message"`
+	Data    map[string]string `json:"data,omitempty"`
+}
+
+// New tạo Checker chưa có kiểm tra nào
+func New() *Checker {
+	return &Checker{checks: make(map[string]Check)}
+}
+
+// Add thêm kiểm tra name vào /readyz
+func (h *Checker) Add(name string, check Check) {
+	if _, exists := h.checks[name]; !exists {
+		h.names = append(h.names, name)
+	}
+	h.checks[name] = check
+}
+
+// Drain đánh dấu service đang dừng: /readyz trả 503 từ đây trở đi
+func (h *Checker) Drain() {
+	h.draining.Store(true)
+}
+
+// Liveness là handler của /healthz
+func (h *Checker) Liveness(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, response{Success: true, Message: "Service đang chạy"})
+}
+
+// Readiness là handler của /readyz. Các kiểm tra chạy song song, kết quả từng kiểm tra
+// nằm trong data ("ok" hoặc "fail"). Nội dung lỗi (host, địa chỉ IP của dependency) chỉ ghi vào log
+// vì endpoint này không cần đăng nhập.
+func (h *Checker) Readiness(w http.ResponseWriter, r *http.Request) {
+	if h.draining.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, response{Success: false, Message: "Service đang dừng"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	results := make(map[string]string, len(h.names))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	ready := true
+	for _, name := range h.names {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+			result := "ok"
+			err := check(ctx)
+			if err != nil {
+				result = "fail"
+				slog.WarnContext(ctx, "Kiểm tra readiness thất bại", "check", name, "error", err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			results[name] = result
+			if err != nil {
+				ready = false
+			}
+		}(name, h.checks[name])
+	}
+	wg.Wait()
+
+	if !ready {
+		writeJSON(w, http.StatusServiceUnavailable, response{Success: false, Message: "Service chưa sẵn sàng", Data: results})
+		return
+	}
+	writeJSON(w, http.StatusOK, response{Success: true, Message: "Service sẵn sàng", Data: results})
+}
+
+// DB kiểm tra kết nối tới database
+func DB(db *gorm.DB) Check {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// HTTP kiểm tra service khác bằng GET url, chấp nhận mọi mã 2xx
+func HTTP(url string) Check {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("%s trả về mã %d", url, resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body response) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,238 @@
+// Package lifecycle điều phối vòng đời của một service: khởi động các thành phần (HTTP, gRPC,
+// tiến trình nền, kết nối) theo thứ tự đăng ký, và khi nhận SIGINT/SIGTERM hoặc một thành phần
+// lỗi thì dừng chúng theo thứ tự ngược lại trong thời hạn ShutdownTimeout.
+//
+// Thứ tự ngược giúp request đang xử lý được trả lời trước khi tiến trình nền dừng, và kết nối
+// database đóng sau cùng khi không còn ai dùng.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"shared/rpc"
+
+	"google.golang.org/grpc"
+)
+
+// Config là thời hạn dừng service. Hết hạn mà thành phần chưa dừng xong thì bỏ qua để tiến trình
+// thoát kịp trước khi orchestrator (Docker, Kubernetes) buộc kill.
+//
+// DrainDelay là thời gian chờ giữa lúc báo /readyz không sẵn sàng và lúc bắt đầu dừng thành phần,
+// để load balancer kịp thấy readiness fail và ngừng gửi request mới. Thời gian grace của
+// orchestrator phải đủ cho cả DrainDelay lẫn ShutdownTimeout.
+type Config struct {
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" default:"15s" min:"1s"`
+	DrainDelay      time.Duration `env:"SHUTDOWN_DRAIN_DELAY" default:"5s" min:"0s"`
+}
+
+// Component là một thành phần có vòng đời trong service
+type Component struct {
+	Name string
+	// Run chạy thành phần tới khi ctx bị hủy hoặc gặp lỗi. Trả về lỗi trước khi service dừng
+	// sẽ kéo theo dừng cả service. Nil với thành phần chỉ cần dọn dẹp khi dừng.
+	Run func(ctx context.Context) error
+	// Stop dừng thành phần trong thời hạn của ctx, được gọi trước khi ctx của Run bị hủy.
+	// Nil thì việc hủy ctx của Run là đủ.
+	Stop func(ctx context.Context) error
+}
+
+// App chạy các Component của một service
+type App struct {
+	name       string
+	cfg        Config
+	components []Component
+	onShutdown []func()
+}
+
+// New tạo App cho service name
+func New(name string, cfg Config) *App {
+	return &App{name: name, cfg: cfg}
+}
+
+// Add đăng ký thành phần. Thành phần được khởi động theo thứ tự Add và dừng theo thứ tự ngược lại.
+func (a *App) Add(c Component) {
+	a.components = append(a.components, c)
+}
+
+// OnShutdown đăng ký hàm chạy ngay khi bắt đầu dừng, DrainDelay trước khi dừng thành phần đầu tiên
+// (ví dụ báo /readyz không còn sẵn sàng để load balancer ngừng gửi request mới)
+func (a *App) OnShutdown(fn func()) {
+	a.onShutdown = append(a.onShutdown, fn)
+}
+
+// running là một thành phần đã khởi động
+type running struct {
+	Component
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Run khởi động mọi thành phần và chờ tới khi nhận SIGINT/SIGTERM hoặc một thành phần lỗi,
+// sau đó dừng tất cả. Trả về lỗi của thành phần đã làm service dừng, hoặc lỗi khi dừng.
+func (a *App) Run() error {
+	signals, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	failed := make(chan error, len(a.components))
+	started := make([]*running, 0, len(a.components))
+	for _, c := range a.components {
+		r := &running{Component: c, done: make(chan struct{})}
+		started = append(started, r)
+		if c.Run == nil {
+			close(r.done)
+			continue
+		}
+
+		var ctx context.Context
+		ctx, r.cancel = context.WithCancel(context.Background())
+		go func() {
+			defer close(r.done)
+			if err := r.Run(ctx); err != nil && ctx.Err() == nil {
+				failed <- fmt.Errorf("%s: %w", r.Name, err)
+			}
+		}()
+	}
+	slog.Info(a.name+" đã khởi động", "components", len(started))
+
+	var cause error
+	select {
+	case <-signals.Done():
+		slog.Info(a.name+" nhận tín hiệu dừng, đang dừng", "timeout", a.cfg.ShutdownTimeout.String())
+	case cause = <-failed:
+		slog.Error(a.name+" có thành phần lỗi, đang dừng", "error", cause)
+	}
+
+	for _, fn := range a.onShutdown {
+		fn()
+	}
+	if len(a.onShutdown) > 0 && a.cfg.DrainDelay > 0 {
+		slog.Info(a.name+" chờ load balancer ngừng gửi request", "delay", a.cfg.DrainDelay.String())
+		time.Sleep(a.cfg.DrainDelay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.ShutdownTimeout)
+	defer cancel()
+	errs := []error{cause}
+	for i := len(started) - 1; i >= 0; i-- {
+		if err := stop(ctx, started[i]); err != nil {
+			errs = append(errs, fmt.Errorf("dừng %s: %w", started[i].Name, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	slog.Info(a.name + " đã dừng")
+	return nil
+}
+
+// stop gọi Stop, hủy ctx của Run rồi chờ Run kết thúc, tất cả trong thời hạn của ctx
+func stop(ctx context.Context, r *running) error {
+	result := make(chan error, 1)
+	go func() {
+		var err error
+		if r.Stop != nil {
+			err = r.Stop(ctx)
+		}
+		if r.cancel != nil {
+			r.cancel()
+		}
+		<-r.done
+		result <- err
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		if r.cancel != nil {
+			r.cancel()
+		}
+		return errors.New("quá thời hạn dừng")
+	}
+}
+
+// HTTP là HTTP server lắng nghe trên port. Khi dừng, server ngừng nhận kết nối mới và chờ các
+// request đang xử lý trả lời xong.
+func HTTP(name string, port int, handler http.Handler) Component {
+	server := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: handler}
+	return Component{
+		Name: name,
+		Run: func(ctx context.Context) error {
+			if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		Stop: server.Shutdown,
+	}
+}
+
+// GRPC là gRPC server lắng nghe trên addr. Khi dừng, server chờ các RPC đang chạy xong;
+// hết thời hạn thì cắt ngang.
+func GRPC(name string, server *grpc.Server, addr string) Component {
+	return Component{
+		Name: name,
+		Run: func(ctx context.Context) error {
+			return rpc.Serve(server, addr)
+		},
+		Stop: func(ctx context.Context) error {
+			stopped := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+				return nil
+			case <-ctx.Done():
+				server.Stop()
+				return ctx.Err()
+			}
+		},
+	}
+}
+
+// Worker là tiến trình nền chạy tới khi ctx bị hủy, như outbox relay hay scheduler
+func Worker(name string, run func(ctx context.Context)) Component {
+	return Component{
+		Name: name,
+		Run: func(ctx context.Context) error {
+			run(ctx)
+			return nil
+		},
+	}
+}
+
+// Background là thành phần tự chạy goroutine của nó khi start được gọi, như consumer đăng ký
+// với event bus. start lỗi thì service dừng; các goroutine phải dừng khi ctx bị hủy.
+func Background(name string, start func(ctx context.Context) error) Component {
+	return Component{
+		Name: name,
+		Run: func(ctx context.Context) error {
+			if err := start(ctx); err != nil {
+				return err
+			}
+			<-ctx.Done()
+			return nil
+		},
+	}
+}
+
+// Closer là tài nguyên chỉ cần đóng khi dừng, như kết nối database hay event bus
+func Closer(name string, close func() error) Component {
+	return Component{
+		Name: name,
+		Stop: func(ctx context.Context) error {
+			return close()
+		},
+	}
+}
@@ -0,0 +1,66 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+	"sync/atomic"
+)
+
+// HeaderRequestID là header mang request ID giữa client, gateway và các service.
+// MetadataRequestID là khóa tương ứng trong metadata gRPC.
+const (
+	HeaderRequestID   = "X-Request-ID"
+	MetadataRequestID = "x-request-id"
+)
+
+// validRequestID giới hạn request ID nhận từ bên ngoài để không ghi chuỗi tùy ý vào log
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._\-]{1,64}$`)
+
+// requestInfo là thông tin của request đang xử lý. userID được ghi sau khi xác thực,
+// khi context đã được tạo, nên dùng atomic thay vì tạo context mới.
+type requestInfo struct {
+	id     string
+	route  string
+	userID atomic.Uint64
+}
+
+type requestKey struct{}
+
+// NewRequestID tạo request ID ngẫu nhiên
+func NewRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// ValidRequestID cho biết id nhận từ header/metadata có dùng được làm request ID không
+func ValidRequestID(id string) bool {
+	return validRequestID.MatchString(id)
+}
+
+// WithRequest gắn request ID và route vào ctx để mọi log trong request mang theo
+func WithRequest(ctx context.Context, requestID, route string) context.Context {
+	return context.WithValue(ctx, requestKey{}, &requestInfo{id: requestID, route: route})
+}
+
+// RequestID trả về request ID trong ctx, rỗng nếu ctx không thuộc request nào
+func RequestID(ctx context.Context) string {
+	if info := requestFrom(ctx); info != nil {
+		return info.id
+	}
+	return ""
+}
+
+// SetUserID ghi user đã xác thực của request trong ctx; các log sau đó mang user_id
+func SetUserID(ctx context.Context, userID uint) {
+	if info := requestFrom(ctx); info != nil {
+		info.userID.Store(uint64(userID))
+	}
+}
+
+func requestFrom(ctx context.Context) *requestInfo {
+	info, _ := ctx.Value(requestKey{}).(*requestInfo)
+	return info
+}
@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware gán request ID cho mỗi request (giữ X-Request-ID hợp lệ do client hoặc gateway
+// gửi, ngược lại tạo mới), trả lại trong header response và ghi một dòng access log khi
+// request kết thúc. Phải đặt trước mọi middleware khác, kể cả Recovery.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(HeaderRequestID)
+		if !ValidRequestID(requestID) {
+			requestID = NewRequestID()
+		}
+		// Ghi lại vào header request để reverse proxy ở gateway chuyển tiếp xuống service
+		c.Request.Header.Set(HeaderRequestID, requestID)
+		c.Header(HeaderRequestID, requestID)
+
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+		ctx := WithRequest(c.Request.Context(), requestID, route)
+		c.Request = c.Request.WithContext(ctx)
+
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		case route == "/healthz" || route == "/readyz":
+			level = slog.LevelDebug
+		}
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.Int("status", status),
+			slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+			slog.Int("bytes", c.Writer.Size()),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", c.Errors.String()))
+		}
+		slog.LogAttrs(ctx, level, "HTTP request", attrs...)
+	}
+}
+
+// Recovery bắt panic trong handler, ghi log kèm request ID và trả 500 với message chung
+// theo dạng ApiResponse thay vì để lộ chi tiết lỗi
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(c *gin.Context, recovered any) {
+		slog.ErrorContext(c.Request.Context(), "Panic khi xử lý request", "panic", recovered)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Lỗi server",
+		})
+	})
+}
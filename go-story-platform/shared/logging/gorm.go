@@ -0,0 +1,22 @@
+package logging
+
+import (
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+// slowQuery là ngưỡng để một câu SQL bị ghi log cảnh báo
+const slowQuery = 200 * time.Millisecond
+
+// Gorm trả về logger cho gorm ghi qua slog: chỉ ghi câu SQL lỗi hoặc chậm, và ghi câu SQL
+// dạng tham số hóa để giá trị (email, mật khẩu đã băm, token) không lọt vào log
+func Gorm() logger.Interface {
+	return logger.NewSlogLogger(slog.Default(), logger.Config{
+		SlowThreshold:             slowQuery,
+		LogLevel:                  logger.Warn,
+		IgnoreRecordNotFoundError: true,
+		ParameterizedQueries:      true,
+	})
+}
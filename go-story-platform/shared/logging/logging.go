@@ -0,0 +1,116 @@
+// Package logging cấu hình slog làm logger chung của các service: log dạng JSON, mỗi dòng log
+// trong một request tự mang request_id, route và user_id, và dữ liệu nhạy cảm (mật khẩu, token,
+// địa chỉ email) được che trước khi ghi ra.
+//
+// Request ID đi theo header X-Request-ID qua gateway và các lời gọi HTTP/gRPC giữa các service,
+// nên log của cùng một request ở mọi service có thể ghép lại bằng request_id.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Config chọn mức và định dạng log
+type Config struct {
+	Level  string `env:"LOG_LEVEL" default:"info"`  // debug, info, warn, error
+	Format string `env:"LOG_FORMAT" default:"json"` // json, hoặc text khi đọc log trên máy local
+}
+
+// Setup tạo logger của service theo cfg và đặt làm logger mặc định của slog lẫn package log,
+// nên các lời gọi log.Printf còn lại cũng ra cùng định dạng
+func Setup(service string, cfg Config) error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return fmt.Errorf("LOG_LEVEL không hợp lệ: %q", cfg.Level)
+	}
+
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: redact}
+	var handler slog.Handler
+	switch cfg.Format {
+	case "json":
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	case "text":
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	default:
+		return fmt.Errorf("LOG_FORMAT không hỗ trợ: %q", cfg.Format)
+	}
+
+	logger := slog.New(contextHandler{handler}).With("service", service)
+	slog.SetDefault(logger)
+	// Sau SetDefault, package log ghi qua logger này; lời gọi log còn lại chỉ là log.Fatal lúc khởi động
+	slog.SetLogLoggerLevel(slog.LevelError)
+	return nil
+}
+
+// contextHandler thêm request_id, route và user_id của request trong ctx vào mỗi bản ghi
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if info := requestFrom(ctx); info != nil {
+		r.AddAttrs(slog.String("request_id", info.id))
+		if info.route != "" {
+			r.AddAttrs(slog.String("route", info.route))
+		}
+		if userID := info.userID.Load(); userID != 0 {
+			r.AddAttrs(slog.Uint64("user_id", userID))
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// Redacted thay cho giá trị bị che
+const Redacted = "[REDACTED]"
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// redact che toàn bộ giá trị của các khóa nhạy cảm và che phần tên của mọi địa chỉ email
+// xuất hiện trong message, chuỗi hoặc lỗi (ví dụ lỗi unique của Postgres chứa email)
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if sensitiveKey(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+
+	switch v := a.Value.Any().(type) {
+	case string:
+		if strings.Contains(v, "@") {
+			return slog.String(a.Key, MaskEmails(v))
+		}
+	case error:
+		return slog.String(a.Key, MaskEmails(v.Error()))
+	}
+	return a
+}
+
+// sensitiveKey cho biết giá trị của khóa a không bao giờ được ghi ra log
+func sensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	switch key {
+	case "token", "access_token", "refresh_token", "authorization", "signature":
+		return true
+	}
+	return strings.Contains(key, "password") || strings.Contains(key, "secret")
+}
+
+// MaskEmails che phần tên của các địa chỉ email trong s, giữ ký tự đầu và tên miền
+// để vẫn phân biệt được khi điều tra (alice@example.com thành a***@example.com)
+func MaskEmails(s string) string {
+	return emailPattern.ReplaceAllStringFunc(s, func(email string) string {
+		at := strings.LastIndex(email, "@")
+		return email[:1] + "***" + email[at:]
+	})
+}
@@ -0,0 +1,126 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Usage là hướng dẫn dùng lệnh migrate, in ra khi tham số không hợp lệ
+const Usage = `Cách dùng: <service> migrate <lệnh>
+  up [n]          chạy n migration chưa chạy (mặc định tất cả)
+  down [n]        hoàn tác n migration mới nhất (mặc định 1)
+  status          liệt kê migration và trạng thái
+  create <tên>    tạo cặp file up/down mới trong thư mục migrations`
+
+var nameRegex = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// RunCLI xử lý lệnh "migrate" của service. connect chỉ được gọi với các lệnh cần DB;
+// dir là thư mục chứa file migration trên đĩa, dùng cho lệnh create.
+func RunCLI(args []string, connect func() *gorm.DB, source fs.FS, dir string) error {
+	if len(args) == 0 {
+		return errors.New(Usage)
+	}
+
+	if args[0] == "create" {
+		if len(args) != 2 {
+			return errors.New(Usage)
+		}
+		up, down, err := Create(dir, args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Println("✅ Đã tạo", up)
+		fmt.Println("✅ Đã tạo", down)
+		return nil
+	}
+
+	switch args[0] {
+	case "up", "down", "status":
+	default:
+		return errors.New(Usage)
+	}
+
+	n := 0
+	if len(args) > 1 {
+		var err error
+		if n, err = strconv.Atoi(args[1]); err != nil || n < 1 {
+			return errors.New(Usage)
+		}
+	}
+
+	m, err := New(connect(), source)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		done, err := m.Up(n)
+		for _, mig := range done {
+			fmt.Printf("✅ Đã chạy %d_%s\n", mig.Version, mig.Name)
+		}
+		if err == nil && len(done) == 0 {
+			fmt.Println("Schema đã ở phiên bản mới nhất")
+		}
+		return err
+	case "down":
+		done, err := m.Down(n)
+		for _, mig := range done {
+			fmt.Printf("↩️ Đã hoàn tác %d_%s\n", mig.Version, mig.Name)
+		}
+		return err
+	case "status":
+		statuses, err := m.Status()
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			state := "chưa chạy"
+			switch {
+			case s.Missing:
+				state = "đã chạy, không có file"
+			case s.AppliedAt != nil:
+				state = "đã chạy lúc " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%06d  %-40s %s\n", s.Version, s.Name, state)
+		}
+		return nil
+	default:
+		return errors.New(Usage)
+	}
+}
+
+// Create tạo cặp file up/down rỗng với phiên bản kế tiếp trong dir
+func Create(dir, name string) (upPath, downPath string, err error) {
+	if !nameRegex.MatchString(name) {
+		return "", "", errors.New("tên migration chỉ gồm chữ thường, số và dấu gạch dưới")
+	}
+
+	existing, err := Load(os.DirFS(dir))
+	if err != nil {
+		return "", "", err
+	}
+	next := int64(1)
+	if len(existing) > 0 {
+		next = existing[len(existing)-1].Version + 1
+	}
+
+	prefix := filepath.Join(dir, fmt.Sprintf("%06d_%s", next, name))
+	upPath, downPath = prefix+".up.sql", prefix+".down.sql"
+	header := "-- " + strings.ReplaceAll(name, "_", " ") + "\n"
+	if err := os.WriteFile(upPath, []byte(header), 0o644); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(downPath, []byte(header), 0o644); err != nil {
+		return "", "", err
+	}
+	return upPath, downPath, nil
+}
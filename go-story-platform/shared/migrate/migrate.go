@@ -0,0 +1,316 @@
+// Package migrate chạy các migration SQL có đánh số phiên bản cho từng service.
+//
+// Mỗi migration gồm hai file trong thư mục migrations của service:
+//
+//	000002_add_foo.up.sql
+//	000002_add_foo.down.sql
+//
+// Các phiên bản đã chạy được ghi trong bảng schema_migrations (xem Migrator.HistoryTable). Mỗi migration chạy trong một
+// DB transaction riêng, trừ khi dòng đầu file up/down là "-- migrate:no-transaction"
+// (cần cho CREATE INDEX CONCURRENTLY).
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultHistoryTable là bảng lưu lịch sử migration trong database của service
+const DefaultHistoryTable = "schema_migrations"
+
+// lockKey là khóa advisory của Postgres để hai process không cùng chạy migration
+const lockKey = 727100
+
+const noTransactionDirective = "-- migrate:no-transaction"
+
+// ErrSchemaBehind được trả về khi database còn migration chưa chạy
+var ErrSchemaBehind = errors.New("schema database chưa được cập nhật")
+
+var fileNameRegex = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration là một cặp script up/down của một phiên bản
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status là trạng thái của một migration so với database
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time // nil = chưa chạy
+	Missing   bool       // Đã chạy trên DB nhưng không còn file (DB mới hơn code)
+}
+
+// historyRow là một dòng của bảng lịch sử migration
+type historyRow struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// Migrator chạy các migration của một service trên một database
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+	// HistoryTable là tên bảng lịch sử, đổi khi nhiều bộ migration dùng chung một database
+	HistoryTable string
+}
+
+// New đọc các file migration trong source (thường là embed.FS của service)
+func New(db *gorm.DB, source fs.FS) (*Migrator, error) {
+	migrations, err := Load(source)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations, HistoryTable: DefaultHistoryTable}, nil
+}
+
+// Load đọc và kiểm tra các file *.up.sql / *.down.sql ở thư mục gốc của source,
+// trả về danh sách migration theo phiên bản tăng dần
+func Load(source fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		match := fileNameRegex.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("tên file migration không hợp lệ: %s", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(source, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d có hai tên khác nhau: %s và %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" {
+			return nil, fmt.Errorf("migration %d_%s thiếu file up", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Status trả về trạng thái của mọi migration, gồm cả phiên bản có trên DB mà không còn file
+func (m *Migrator) Status() ([]Status, error) {
+	applied, err := m.applied(m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Status, 0, len(m.migrations))
+	known := map[int64]bool{}
+	for _, mig := range m.migrations {
+		known[mig.Version] = true
+		s := Status{Version: mig.Version, Name: mig.Name}
+		if row, ok := applied[mig.Version]; ok {
+			appliedAt := row.AppliedAt
+			s.AppliedAt = &appliedAt
+		}
+		result = append(result, s)
+	}
+	for version, row := range applied {
+		if !known[version] {
+			appliedAt := row.AppliedAt
+			result = append(result, Status{Version: version, Name: row.Name, AppliedAt: &appliedAt, Missing: true})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+// Pending trả về các migration chưa chạy theo thứ tự phiên bản
+func (m *Migrator) Pending() ([]Migration, error) {
+	applied, err := m.applied(m.db)
+	if err != nil {
+		return nil, err
+	}
+	return m.pending(applied), nil
+}
+
+// RequireUpToDate trả về ErrSchemaBehind nếu còn migration chưa chạy.
+// Service gọi hàm này lúc khởi động và từ chối phục vụ nếu có lỗi.
+func (m *Migrator) RequireUpToDate() error {
+	statuses, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	var behind []string
+	for _, s := range statuses {
+		if s.Missing {
+			slog.Warn("Migration đã chạy trên DB nhưng không có trong code", "version", s.Version, "name", s.Name)
+			continue
+		}
+		if s.AppliedAt == nil {
+			behind = append(behind, fmt.Sprintf("%d_%s", s.Version, s.Name))
+		}
+	}
+	if len(behind) > 0 {
+		return fmt.Errorf("%w, còn %d migration chưa chạy: %s", ErrSchemaBehind, len(behind), strings.Join(behind, ", "))
+	}
+	return nil
+}
+
+// Up chạy tối đa n migration chưa chạy (n <= 0 = tất cả) và trả về các migration đã chạy
+func (m *Migrator) Up(n int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.pending(applied) {
+			if n > 0 && len(done) >= n {
+				break
+			}
+			if err := run(conn, mig.Up, func(tx *gorm.DB) error {
+				return tx.Table(m.HistoryTable).Create(&historyRow{Version: mig.Version, Name: mig.Name, AppliedAt: time.Now()}).Error
+			}); err != nil {
+				return fmt.Errorf("migration %d_%s lỗi: %w", mig.Version, mig.Name, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down hoàn tác n migration mới nhất đã chạy (n <= 0 được coi là 1)
+func (m *Migrator) Down(n int) ([]Migration, error) {
+	if n <= 0 {
+		n = 1
+	}
+
+	byVersion := map[int64]Migration{}
+	for _, mig := range m.migrations {
+		byVersion[mig.Version] = mig
+	}
+
+	var done []Migration
+	err := m.withLock(func(conn *gorm.DB) error {
+		var rows []historyRow
+		if err := conn.Table(m.HistoryTable).Order("version DESC").Limit(n).Find(&rows).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			mig, ok := byVersion[row.Version]
+			if !ok {
+				return fmt.Errorf("không có file cho migration %d_%s để hoàn tác", row.Version, row.Name)
+			}
+			if strings.TrimSpace(mig.Down) == "" {
+				return fmt.Errorf("migration %d_%s không có file down", mig.Version, mig.Name)
+			}
+			if err := run(conn, mig.Down, func(tx *gorm.DB) error {
+				return tx.Table(m.HistoryTable).Where("version = ?", mig.Version).Delete(&historyRow{}).Error
+			}); err != nil {
+				return fmt.Errorf("hoàn tác migration %d_%s lỗi: %w", mig.Version, mig.Name, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// withLock giữ một kết nối riêng có khóa advisory trong suốt fn
+func (m *Migrator) withLock(fn func(conn *gorm.DB) error) error {
+	return m.db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", lockKey).Error; err != nil {
+			return err
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", lockKey)
+
+		if err := ensureHistoryTable(conn, m.HistoryTable); err != nil {
+			return err
+		}
+		return fn(conn)
+	})
+}
+
+// applied đọc các phiên bản đã chạy; bảng lịch sử chưa có nghĩa là chưa chạy gì
+func (m *Migrator) applied(db *gorm.DB) (map[int64]historyRow, error) {
+	result := map[int64]historyRow{}
+	if !db.Migrator().HasTable(m.HistoryTable) {
+		return result, nil
+	}
+
+	var rows []historyRow
+	if err := db.Table(m.HistoryTable).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.Version] = row
+	}
+	return result, nil
+}
+
+func (m *Migrator) pending(applied map[int64]historyRow) []Migration {
+	var result []Migration
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; !ok {
+			result = append(result, mig)
+		}
+	}
+	return result
+}
+
+func ensureHistoryTable(db *gorm.DB, table string) error {
+	return db.Exec(`CREATE TABLE IF NOT EXISTS ` + table + ` (
+		version    BIGINT PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL
+	)`).Error
+}
+
+// run chạy script rồi record (ghi/xóa lịch sử), trong cùng transaction nếu script cho phép
+func run(conn *gorm.DB, script string, record func(tx *gorm.DB) error) error {
+	if strings.HasPrefix(strings.TrimSpace(script), noTransactionDirective) {
+		if err := conn.Exec(script).Error; err != nil {
+			return err
+		}
+		return record(conn)
+	}
+
+	return conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(script).Error; err != nil {
+			return err
+		}
+		return record(tx)
+	})
+}
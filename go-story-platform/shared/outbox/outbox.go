@@ -0,0 +1,46 @@
+// Package outbox hiện thực transactional outbox: event được ghi vào bảng outbox_messages
+// trong cùng DB transaction với thay đổi nghiệp vụ, sau đó Relay đọc và đẩy lên broker.
+// Nhờ vậy không có chuyện dữ liệu đã lưu mà event bị mất, hoặc event phát ra cho một
+// thay đổi đã bị rollback.
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"shared/eventbus"
+	"shared/events"
+
+	"gorm.io/gorm"
+)
+
+// Message là một event chờ gửi trong bảng outbox_messages của service
+type Message struct {
+	ID          uint64     `gorm:"primaryKey"`
+	EventID     string     `gorm:"size:36;uniqueIndex;not null"`
+	Type        string     `gorm:"not null"`
+	AggregateID string     `gorm:"not null"`
+	Payload     string     `gorm:"type:jsonb;not null"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime"`
+	PublishedAt *time.Time `gorm:"index"` // nil = chưa gửi lên broker
+	Attempts    int        `gorm:"not null;default:0"`
+	LastError   string
+}
+
+func (Message) TableName() string { return "outbox_messages" }
+
+// Enqueue ghi event vào outbox. tx phải là DB transaction đang chứa thay đổi nghiệp vụ
+// tương ứng để hai việc cùng commit hoặc cùng rollback.
+func Enqueue(tx *gorm.DB, event events.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&Message{
+		EventID:     eventbus.NewEventID(),
+		Type:        event.EventType(),
+		AggregateID: event.AggregateID(),
+		Payload:     string(payload),
+	}).Error
+}
@@ -0,0 +1,91 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"shared/eventbus"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Relay định kỳ đọc các event chưa gửi trong outbox và publish lên broker theo thứ tự id
+type Relay struct {
+	db        *gorm.DB
+	broker    eventbus.Broker
+	source    string
+	Interval  time.Duration
+	BatchSize int
+}
+
+// NewRelay tạo Relay cho service source (ví dụ "user-service")
+func NewRelay(db *gorm.DB, broker eventbus.Broker, source string) *Relay {
+	return &Relay{db: db, broker: broker, source: source, Interval: time.Second, BatchSize: 100}
+}
+
+// Run chạy vòng lặp relay tới khi ctx bị hủy
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.Flush(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("Outbox relay lỗi", "source", r.source, "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush gửi một lô event chưa gửi và trả về số event đã gửi thành công.
+// Các dòng được khóa bằng FOR UPDATE SKIP LOCKED nên chạy nhiều instance cùng lúc vẫn an toàn.
+// Khi publish lỗi, relay ghi lại lỗi và dừng lô để event sau không vượt lên trước.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	sent := 0
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var pending []Message
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL").
+			Order("id ASC").
+			Limit(r.BatchSize).
+			Find(&pending).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range pending {
+			msg := &pending[i]
+			pubErr := r.broker.Publish(ctx, eventbus.Message{
+				ID:          msg.EventID,
+				Type:        msg.Type,
+				Source:      r.source,
+				AggregateID: msg.AggregateID,
+				Payload:     json.RawMessage(msg.Payload),
+				OccurredAt:  msg.CreatedAt,
+			})
+			if pubErr != nil {
+				return tx.Model(msg).Updates(map[string]interface{}{
+					"attempts":   gorm.Expr("attempts + 1"),
+					"last_error": pubErr.Error(),
+				}).Error
+			}
+
+			now := time.Now()
+			if err := tx.Model(msg).Updates(map[string]interface{}{
+				"published_at": now,
+				"attempts":     gorm.Expr("attempts + 1"),
+				"last_error":   "",
+			}).Error; err != nil {
+				return err
+			}
+			sent++
+		}
+		return nil
+	})
+	return sent, err
+}
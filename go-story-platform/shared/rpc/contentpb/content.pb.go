@@ -0,0 +1,368 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: contentpb/content.proto
+
+// Hợp đồng gRPC nội bộ của content-service, chỉ dùng giữa các service (không mở ra internet).
+
+package contentpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Book struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// ID tác giả bên user-service
+	AuthorId  uint64 `protobuf:"varint,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	IsPremium bool   `protobuf:"varint,4,opt,name=is_premium,json=isPremium,proto3" json:"is_premium,omitempty"`
+	// Giá mua trọn bộ (xu), chỉ áp dụng khi is_premium
+	Price         int64  `protobuf:"varint,5,opt,name=price,proto3" json:"price,omitempty"`
+	Status        string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Book) Reset() {
+	*x = Book{}
+	mi := &file_contentpb_content_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Book) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Book) ProtoMessage() {}
+
+func (x *Book) ProtoReflect() protoreflect.Message {
+	mi := &file_contentpb_content_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Book.ProtoReflect.Descriptor instead.
+func (*Book) Descriptor() ([]byte, []int) {
+	return file_contentpb_content_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Book) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Book) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Book) GetAuthorId() uint64 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *Book) GetIsPremium() bool {
+	if x != nil {
+		return x.IsPremium
+	}
+	return false
+}
+
+func (x *Book) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Book) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type Chapter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	BookId        uint64                 `protobuf:"varint,2,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	ChapterNumber int32                  `protobuf:"varint,3,opt,name=chapter_number,json=chapterNumber,proto3" json:"chapter_number,omitempty"`
+	// Giá mua lẻ chương (xu), 0 = không bán lẻ
+	Price         int64  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	Status        string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Chapter) Reset() {
+	*x = Chapter{}
+	mi := &file_contentpb_content_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Chapter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chapter) ProtoMessage() {}
+
+func (x *Chapter) ProtoReflect() protoreflect.Message {
+	mi := &file_contentpb_content_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chapter.ProtoReflect.Descriptor instead.
+func (*Chapter) Descriptor() ([]byte, []int) {
+	return file_contentpb_content_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Chapter) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Chapter) GetBookId() uint64 {
+	if x != nil {
+		return x.BookId
+	}
+	return 0
+}
+
+func (x *Chapter) GetChapterNumber() int32 {
+	if x != nil {
+		return x.ChapterNumber
+	}
+	return 0
+}
+
+func (x *Chapter) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Chapter) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type GetBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookRequest) Reset() {
+	*x = GetBookRequest{}
+	mi := &file_contentpb_content_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookRequest) ProtoMessage() {}
+
+func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contentpb_content_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookRequest.ProtoReflect.Descriptor instead.
+func (*GetBookRequest) Descriptor() ([]byte, []int) {
+	return file_contentpb_content_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetBookRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetChapterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        uint64                 `protobuf:"varint,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	ChapterId     uint64                 `protobuf:"varint,2,opt,name=chapter_id,json=chapterId,proto3" json:"chapter_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChapterRequest) Reset() {
+	*x = GetChapterRequest{}
+	mi := &file_contentpb_content_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChapterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChapterRequest) ProtoMessage() {}
+
+func (x *GetChapterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contentpb_content_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChapterRequest.ProtoReflect.Descriptor instead.
+func (*GetChapterRequest) Descriptor() ([]byte, []int) {
+	return file_contentpb_content_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetChapterRequest) GetBookId() uint64 {
+	if x != nil {
+		return x.BookId
+	}
+	return 0
+}
+
+func (x *GetChapterRequest) GetChapterId() uint64 {
+	if x != nil {
+		return x.ChapterId
+	}
+	return 0
+}
+
+var File_contentpb_content_proto protoreflect.FileDescriptor
+
+const file_contentpb_content_proto_rawDesc = "" +
+	"\n" +
+	"\x17contentpb/content.proto\x12\x10story.content.v1\"\x96\x01\n" +
+	"\x04Book\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\x04R\bauthorId\x12\x1d\n" +
+	"\n" +
+	"is_premium\x18\x04 \x01(\bR\tisPremium\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x03R\x05price\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\"\x87\x01\n" +
+	"\aChapter\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
+	"\abook_id\x18\x02 \x01(\x04R\x06bookId\x12%\n" +
+	"\x0echapter_number\x18\x03 \x01(\x05R\rchapterNumber\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x03R\x05price\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\" \n" +
+	"\x0eGetBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"K\n" +
+	"\x11GetChapterRequest\x12\x17\n" +
+	"\abook_id\x18\x01 \x01(\x04R\x06bookId\x12\x1d\n" +
+	"\n" +
+	"chapter_id\x18\x02 \x01(\x04R\tchapterId2\xa3\x01\n" +
+	"\x0eContentService\x12C\n" +
+	"\aGetBook\x12 .story.content.v1.GetBookRequest\x1a\x16.story.content.v1.Book\x12L\n" +
+	"\n" +
+	"GetChapter\x12#.story.content.v1.GetChapterRequest\x1a\x19.story.content.v1.ChapterB\x16Z\x14shared/rpc/contentpbb\x06proto3"
+
+var (
+	file_contentpb_content_proto_rawDescOnce sync.Once
+	file_contentpb_content_proto_rawDescData []byte
+)
+
+func file_contentpb_content_proto_rawDescGZIP() []byte {
+	file_contentpb_content_proto_rawDescOnce.Do(func() {
+		file_contentpb_content_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_contentpb_content_proto_rawDesc), len(file_contentpb_content_proto_rawDesc)))
+	})
+	return file_contentpb_content_proto_rawDescData
+}
+
+var file_contentpb_content_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_contentpb_content_proto_goTypes = []any{
+	(*Book)(nil),              // 0: story.content.v1.Book
+	(*Chapter)(nil),           // 1: story.content.v1.Chapter
+	(*GetBookRequest)(nil),    // 2: story.content.v1.GetBookRequest
+	(*GetChapterRequest)(nil), // 3: story.content.v1.GetChapterRequest
+}
+var file_contentpb_content_proto_depIdxs = []int32{
+	2, // 0: story.content.v1.ContentService.GetBook:input_type -> story.content.v1.GetBookRequest
+	3, // 1: story.content.v1.ContentService.GetChapter:input_type -> story.content.v1.GetChapterRequest
+	0, // 2: story.content.v1.ContentService.GetBook:output_type -> story.content.v1.Book
+	1, // 3: story.content.v1.ContentService.GetChapter:output_type -> story.content.v1.Chapter
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_contentpb_content_proto_init() }
+func file_contentpb_content_proto_init() {
+	if File_contentpb_content_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_contentpb_content_proto_rawDesc), len(file_contentpb_content_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_contentpb_content_proto_goTypes,
+		DependencyIndexes: file_contentpb_content_proto_depIdxs,
+		MessageInfos:      file_contentpb_content_proto_msgTypes,
+	}.Build()
+	File_contentpb_content_proto = out.File
+	file_contentpb_content_proto_goTypes = nil
+	file_contentpb_content_proto_depIdxs = nil
+}
@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"shared/logging"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// propagateRequestID gửi request ID trong ctx của người gọi sang service kia qua metadata,
+// để log hai phía của lời gọi có cùng request_id
+func propagateRequestID(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if requestID := logging.RequestID(ctx); requestID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, logging.MetadataRequestID, requestID)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// logRequests nhận request ID từ metadata (tạo mới nếu không có) và ghi log mỗi lời gọi RPC
+func logRequests(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(logging.MetadataRequestID); len(values) > 0 && logging.ValidRequestID(values[0]) {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = logging.NewRequestID()
+	}
+	ctx = logging.WithRequest(ctx, requestID, info.FullMethod)
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	level := slog.LevelInfo
+	attrs := []slog.Attr{
+		slog.String("code", status.Code(err).String()),
+		slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+	}
+	if err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.Any("error", err))
+	}
+	slog.LogAttrs(ctx, level, "gRPC request", attrs...)
+	return resp, err
+}
@@ -0,0 +1,162 @@
+// Package rpc là lớp gRPC nội bộ giữa các service. Hợp đồng protobuf và code sinh ra nằm ở
+// userpb, contentpb, paymentpb; package này lo phần kết nối dùng chung: deadline mặc định,
+// tự thử lại khi service tạm thời không khả dụng, chứng chỉ mTLS (xem TLSConfig) và
+// chuyển request ID giữa các service để ghép log.
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative userpb/user.proto contentpb/content.proto paymentpb/payment.proto
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// serviceConfig áp dụng cho mọi method: deadline mặc định 3 giây khi người gọi không đặt
+// deadline ngắn hơn, và thử lại tối đa 3 lần khi gặp UNAVAILABLE. Các RPC nội bộ đều là
+// thao tác đọc nên thử lại an toàn.
+const serviceConfig = `{
+	"methodConfig": [{
+		"name": [{}],
+		"timeout": "3s",
+		"retryPolicy": {
+			"maxAttempts": 3,
+			"initialBackoff": "0.1s",
+			"maxBackoff": "1s",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE"]
+		}
+	}]
+}`
+
+// TLSConfig là chứng chỉ gRPC của service. Kênh gRPC nội bộ luôn dùng mTLS: server và client
+// đều phải có cả ba file. Chỉ khi chạy local mới đặt GRPC_INSECURE=true để bỏ TLS; khi đó
+// gRPC server chỉ nghe trên 127.0.0.1.
+type TLSConfig struct {
+	// Chứng chỉ của chính service: server dùng để phục vụ, client trình cho server khi kết nối
+	CertFile string `env:"GRPC_TLS_CERT"`
+	KeyFile  string `env:"GRPC_TLS_KEY"`
+	// CA để kiểm tra chứng chỉ phía bên kia
+	CAFile string `env:"GRPC_TLS_CA"`
+	// Insecure tắt TLS, chỉ dùng khi chạy local
+	Insecure bool `env:"GRPC_INSECURE"`
+}
+
+// ListenAddr là địa chỉ gRPC server lắng nghe trên port: mọi interface khi dùng mTLS,
+// chỉ 127.0.0.1 khi Insecure
+func (c TLSConfig) ListenAddr(port int) string {
+	if c.Insecure {
+		return fmt.Sprintf("127.0.0.1:%d", port)
+	}
+	return fmt.Sprintf(":%d", port)
+}
+
+// validate kiểm tra đủ chứng chỉ cho mTLS khi không bật Insecure
+func (c TLSConfig) validate() error {
+	if c.Insecure {
+		return nil
+	}
+	if c.CertFile == "" || c.KeyFile == "" || c.CAFile == "" {
+		return errors.New("gRPC bắt buộc mTLS: cần GRPC_TLS_CERT, GRPC_TLS_KEY và GRPC_TLS_CA (GRPC_INSECURE=true chỉ dùng khi chạy local)")
+	}
+	return nil
+}
+
+// Dial tạo kết nối tới service tại target (host:port). Kết nối được mở lười khi gọi RPC đầu tiên
+// nên Dial không lỗi khi service kia chưa chạy.
+func Dial(target string, cfg TLSConfig) (*grpc.ClientConn, error) {
+	creds, err := clientCredentials(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.NewClient(target,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		grpc.WithUnaryInterceptor(propagateRequestID),
+	)
+}
+
+// NewServer tạo gRPC server với chứng chỉ trong cfg
+func NewServer(cfg TLSConfig) (*grpc.Server, error) {
+	creds, err := serverCredentials(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.NewServer(grpc.Creds(creds), grpc.UnaryInterceptor(logRequests)), nil
+}
+
+// Serve lắng nghe trên addr (xem TLSConfig.ListenAddr) và phục vụ server cho tới khi server dừng
+func Serve(server *grpc.Server, addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return server.Serve(lis)
+}
+
+// clientCredentials dựng chứng chỉ mTLS phía client: kiểm tra server bằng CA và trình cert/key của service
+func clientCredentials(c TLSConfig) (credentials.TransportCredentials, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	if c.Insecure {
+		return insecure.NewCredentials(), nil
+	}
+	pool, err := loadCertPool(c.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("không đọc được chứng chỉ gRPC: %w", err)
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// serverCredentials dựng chứng chỉ mTLS phía server: bắt buộc client trình chứng chỉ do CA cấp
+func serverCredentials(c TLSConfig) (credentials.TransportCredentials, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	if c.Insecure {
+		return insecure.NewCredentials(), nil
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("không đọc được chứng chỉ gRPC: %w", err)
+	}
+	pool, err := loadCertPool(c.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// loadCertPool đọc file PEM chứa CA
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("không đọc được CA gRPC: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("file CA gRPC không hợp lệ: %s", caFile)
+	}
+	return pool, nil
+}